@@ -0,0 +1,223 @@
+// Package assetexport writes assets as CSV, JSON Lines or XLSX files while they are read, without keeping them in memory.
+package assetexport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"platform-go-challenge/domain"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrWrongFormat = errors.New("wrong export format")
+)
+
+type Format string
+
+const (
+	CSVFormat   = Format("csv")
+	JSONLFormat = Format("jsonl")
+	XLSXFormat  = Format("xlsx")
+)
+
+func (f Format) ContentType() string {
+	switch f {
+	case CSVFormat:
+		return "text/csv; charset=utf-8"
+	case XLSXFormat:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/x-ndjson"
+}
+
+// Writer writes the assets to a file of a format. Close completes the file and has to be called
+// even when there are no assets.
+type Writer interface {
+	Write(assets []domain.Asset) error
+	Close() error
+}
+
+// NewWriter returns a writer of assets of the type to w. Every line of a JSON Lines file is the JSON of an asset.
+// The CSV and XLSX files have a header with the fields of the assets followed by the JSON fields of their data,
+// in which lists of texts are separated with "|" and the other values that are not texts are JSON.
+func NewWriter(w io.Writer, format Format, assetType domain.AssetType) (Writer, error) {
+	err := Validate(format, assetType)
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	switch assetType {
+	case domain.InsightAssetType:
+		data = domain.Insight{}
+	case domain.ChartAssetType:
+		data = domain.Chart{}
+	case domain.AudienceAssetType:
+		data = domain.Audience{}
+	}
+	switch format {
+	case JSONLFormat:
+		return &jsonlWriter{enc: json.NewEncoder(w)}, nil
+	case CSVFormat:
+		return newTableWriter(&csvRows{w: csv.NewWriter(w)}, reflect.TypeOf(data))
+	}
+	rows, err := newXLSXRows(w)
+	if err != nil {
+		return nil, err
+	}
+	return newTableWriter(rows, reflect.TypeOf(data))
+}
+
+// Validate checks that assets of the type can be exported to the format.
+func Validate(format Format, assetType domain.AssetType) error {
+	switch assetType {
+	case domain.InsightAssetType, domain.ChartAssetType, domain.AudienceAssetType:
+	default:
+		return fmt.Errorf("%w: asset type is not correct", ErrWrongFormat)
+	}
+	switch format {
+	case CSVFormat, JSONLFormat, XLSXFormat:
+	default:
+		return fmt.Errorf("%w: format should be csv, jsonl or xlsx", ErrWrongFormat)
+	}
+	return nil
+}
+
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonlWriter) Write(assets []domain.Asset) error {
+	for i := range assets {
+		err := w.enc.Encode(&assets[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *jsonlWriter) Close() error {
+	return nil
+}
+
+// rowWriter writes the rows of a table.
+type rowWriter interface {
+	writeRow(cells []string) error
+	// flush writes the buffered rows
+	flush() error
+	close() error
+}
+
+// assetColumns are the columns of the fields of the assets that come before the columns of their data.
+var assetColumns = []string{"id", "version", "status", "visibility", "locale", "publishAt", "expireAt", "tags", "note"}
+
+type dataColumn struct {
+	name  string
+	index int
+}
+
+type tableWriter struct {
+	rows    rowWriter
+	columns []dataColumn
+}
+
+func newTableWriter(rows rowWriter, dataType reflect.Type) (*tableWriter, error) {
+	w := &tableWriter{rows: rows}
+	header := append([]string{}, assetColumns...)
+	for i := 0; i < dataType.NumField(); i++ {
+		name := strings.Split(dataType.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		w.columns = append(w.columns, dataColumn{name: name, index: i})
+		header = append(header, name)
+	}
+	return w, rows.writeRow(header)
+}
+
+func (w *tableWriter) Write(assets []domain.Asset) error {
+	for _, a := range assets {
+		cells := []string{
+			strconv.FormatUint(uint64(a.ID), 10),
+			strconv.FormatUint(uint64(a.Version), 10),
+			string(a.Status),
+			string(a.Visibility),
+			a.Locale,
+			timeCell(a.PublishAt),
+			timeCell(a.ExpireAt),
+			strings.Join(a.Tags, "|"),
+			"",
+		}
+		if a.Note != nil {
+			cells[8] = a.Note.Text
+		}
+		data := reflect.Indirect(reflect.ValueOf(a.Data))
+		for _, c := range w.columns {
+			if !data.IsValid() {
+				cells = append(cells, "")
+				continue
+			}
+			cells = append(cells, valueCell(data.Field(c.index)))
+		}
+		err := w.rows.writeRow(cells)
+		if err != nil {
+			return err
+		}
+	}
+	return w.rows.flush()
+}
+
+func (w *tableWriter) Close() error {
+	return w.rows.close()
+}
+
+func timeCell(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// valueCell returns texts as they are, lists of texts separated with "|" and the other values as JSON.
+func valueCell(v reflect.Value) string {
+	switch {
+	case v.Kind() == reflect.String:
+		return v.String()
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		texts := []string{}
+		for i := 0; i < v.Len(); i++ {
+			texts = append(texts, v.Index(i).String())
+		}
+		return strings.Join(texts, "|")
+	case v.Kind() == reflect.Ptr && v.IsNil():
+		return ""
+	}
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+type csvRows struct {
+	w *csv.Writer
+}
+
+func (r *csvRows) writeRow(cells []string) error {
+	return r.w.Write(cells)
+}
+
+func (r *csvRows) flush() error {
+	r.w.Flush()
+	return r.w.Error()
+}
+
+func (r *csvRows) close() error {
+	return r.flush()
+}
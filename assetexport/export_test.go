@@ -0,0 +1,109 @@
+package assetexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"platform-go-challenge/domain"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testAudiences = []domain.Asset{
+	{
+		ID:      1,
+		Version: 2,
+		Status:  domain.PublishedStatus,
+		Tags:    []string{"gaming", "youth"},
+		Note:    &domain.FavouriteNote{Text: "for the <Q3> report"},
+		Data: &domain.Audience{
+			AgeMin:          18,
+			AgeMax:          24,
+			Countries:       []string{"GR", "FR"},
+			HoursSpentRange: &domain.IntRange{Min: 1, Max: 3},
+			Description:     "young gamers, in Europe",
+		},
+	},
+	{
+		ID:   2,
+		Data: &domain.Audience{AgeMin: 30, AgeMax: 40, Description: "adults"},
+	},
+}
+
+func TestCSVExport(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, CSVFormat, domain.AudienceAssetType)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Write(testAudiences))
+	assert.NoError(t, w.Close())
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(records))
+	row := map[string]string{}
+	for i, name := range records[0] {
+		row[name] = records[1][i]
+	}
+	assert.Equal(t, "1", row["id"])
+	assert.Equal(t, "gaming|youth", row["tags"])
+	assert.Equal(t, "for the <Q3> report", row["note"])
+	assert.Equal(t, "GR|FR", row["countries"])
+	assert.Equal(t, `{"min":1,"max":3}`, row["hoursSpentRange"])
+	assert.Equal(t, "young gamers, in Europe", row["description"])
+	assert.Equal(t, "", row["numberOfPurchasesRange"])
+}
+
+func TestJSONLExport(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, JSONLFormat, domain.AudienceAssetType)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Write(testAudiences))
+	assert.NoError(t, w.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 2, len(lines))
+	asset := domain.Asset{Data: &domain.Audience{}}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &asset))
+	assert.Equal(t, uint(1), asset.ID)
+	assert.Equal(t, []string{"GR", "FR"}, asset.Data.(*domain.Audience).Countries)
+}
+
+func TestXLSXExport(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, XLSXFormat, domain.AudienceAssetType)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Write(testAudiences[:1]))
+	assert.NoError(t, w.Write(testAudiences[1:]))
+	assert.NoError(t, w.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	names := []string{}
+	var sheet string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := f.Open()
+			assert.NoError(t, err)
+			b, _ := io.ReadAll(rc)
+			rc.Close()
+			sheet = string(b)
+		}
+	}
+	assert.Contains(t, names, "[Content_Types].xml")
+	assert.Contains(t, names, "xl/workbook.xml")
+	assert.Equal(t, 3, strings.Count(sheet, "<row "))
+	assert.Contains(t, sheet, "for the &lt;Q3&gt; report")
+	assert.True(t, strings.HasSuffix(sheet, "</sheetData></worksheet>"))
+}
+
+func TestWrongExportFormat(t *testing.T) {
+	_, err := NewWriter(io.Discard, Format("pdf"), domain.InsightAssetType)
+	assert.ErrorIs(t, err, ErrWrongFormat)
+	_, err = NewWriter(io.Discard, CSVFormat, domain.AssetType("users"))
+	assert.ErrorIs(t, err, ErrWrongFormat)
+}
@@ -0,0 +1,101 @@
+package assetexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// maxCellLength is the longest text of a cell of a spreadsheet.
+const maxCellLength = 32767
+
+// xlsxParts are the parts of a workbook with a single sheet, which is written after them.
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Assets" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+// xlsxRows writes the rows to the sheet of a workbook with inline texts, so that nothing is kept
+// in memory for a table of shared texts.
+type xlsxRows struct {
+	zw    *zip.Writer
+	sheet io.Writer
+	buf   bytes.Buffer
+	row   int
+}
+
+func newXLSXRows(w io.Writer) (*xlsxRows, error) {
+	r := &xlsxRows{zw: zip.NewWriter(w)}
+	for _, p := range xlsxParts {
+		f, err := r.zw.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.WriteString(f, p.content)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sheet, err := r.zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	r.sheet = sheet
+	r.buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return r, nil
+}
+
+func (r *xlsxRows) writeRow(cells []string) error {
+	r.row++
+	fmt.Fprintf(&r.buf, `<row r="%d">`, r.row)
+	for _, c := range cells {
+		if c == "" {
+			r.buf.WriteString(`<c/>`)
+			continue
+		}
+		if len(c) > maxCellLength {
+			c = c[:maxCellLength]
+			for !utf8.ValidString(c) {
+				c = c[:len(c)-1]
+			}
+		}
+		r.buf.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		err := xml.EscapeText(&r.buf, []byte(c))
+		if err != nil {
+			return err
+		}
+		r.buf.WriteString(`</t></is></c>`)
+	}
+	r.buf.WriteString(`</row>`)
+	return nil
+}
+
+func (r *xlsxRows) flush() error {
+	_, err := r.buf.WriteTo(r.sheet)
+	if err != nil {
+		return err
+	}
+	return r.zw.Flush()
+}
+
+func (r *xlsxRows) close() error {
+	r.buf.WriteString(`</sheetData></worksheet>`)
+	err := r.flush()
+	if err != nil {
+		return err
+	}
+	return r.zw.Close()
+}
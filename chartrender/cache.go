@@ -0,0 +1,37 @@
+package chartrender
+
+import "sync"
+
+// Cache keeps the last rendered charts in memory and drops the oldest ones when it is full.
+// The keys contain the version of the chart, so the renderings of old versions are never served.
+type Cache struct {
+	mu    sync.Mutex
+	size  int
+	keys  []string
+	items map[string][]byte
+}
+
+func NewCache(size int) *Cache {
+	return &Cache{size: size, items: map[string][]byte{}}
+}
+
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.items[key]
+	return b, ok
+}
+
+func (c *Cache) Add(key string, b []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.items[key]; ok {
+		return
+	}
+	if len(c.keys) >= c.size {
+		delete(c.items, c.keys[0])
+		c.keys = c.keys[1:]
+	}
+	c.keys = append(c.keys, key)
+	c.items[key] = b
+}
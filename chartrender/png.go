@@ -0,0 +1,136 @@
+package chartrender
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+)
+
+type pngCanvas struct {
+	img *image.RGBA
+}
+
+func newPNGCanvas(width, height int) *pngCanvas {
+	return &pngCanvas{img: image.NewRGBA(image.Rect(0, 0, width, height))}
+}
+
+func (cv *pngCanvas) set(x, y int, c color.RGBA) {
+	if image.Pt(x, y).In(cv.img.Rect) {
+		cv.img.SetRGBA(x, y, c)
+	}
+}
+
+// line stamps a square of the width of the line every half pixel along it.
+func (cv *pngCanvas) line(x1, y1, x2, y2 float64, c color.RGBA, width float64) {
+	steps := math.Max(math.Abs(x2-x1), math.Abs(y2-y1)) * 2
+	half := int(math.Max(0, math.Round(width/2-0.5)))
+	for i := 0.0; i <= steps; i++ {
+		t := 0.0
+		if steps > 0 {
+			t = i / steps
+		}
+		x := int(math.Round(x1 + t*(x2-x1)))
+		y := int(math.Round(y1 + t*(y2-y1)))
+		for dx := -half; dx <= half; dx++ {
+			for dy := -half; dy <= half; dy++ {
+				cv.set(x+dx, y+dy, c)
+			}
+		}
+	}
+}
+
+func (cv *pngCanvas) polyline(points []point, c color.RGBA, width float64) {
+	for i := 1; i < len(points); i++ {
+		cv.line(points[i-1].x, points[i-1].y, points[i].x, points[i].y, c, width)
+	}
+}
+
+func (cv *pngCanvas) rect(x, y, w, h float64, c color.RGBA) {
+	for px := int(math.Round(x)); px < int(math.Round(x+w)); px++ {
+		for py := int(math.Round(y)); py < int(math.Round(y+h)); py++ {
+			cv.set(px, py, c)
+		}
+	}
+}
+
+func (cv *pngCanvas) circle(x, y, r float64, c color.RGBA) {
+	for px := int(x - r); px <= int(x+r); px++ {
+		for py := int(y - r); py <= int(y+r); py++ {
+			dx, dy := float64(px)-x, float64(py)-y
+			if dx*dx+dy*dy <= r*r {
+				cv.set(px, py, c)
+			}
+		}
+	}
+}
+
+func (cv *pngCanvas) wedge(cx, cy, r, from, to float64, c color.RGBA) {
+	for px := int(cx - r); px <= int(cx+r); px++ {
+		for py := int(cy - r); py <= int(cy+r); py++ {
+			dx, dy := float64(px)-cx, float64(py)-cy
+			if dx*dx+dy*dy > r*r {
+				continue
+			}
+			angle := math.Atan2(dx, -dy)
+			if angle < 0 {
+				angle += 2 * math.Pi
+			}
+			if angle >= from && angle < to {
+				cv.set(px, py, c)
+			}
+		}
+	}
+}
+
+// fontScale gives the number of pixels of a dot of the bitmap font for a font size.
+func fontScale(size float64) int {
+	return int(math.Max(1, math.Round(size/8)))
+}
+
+func (cv *pngCanvas) text(x, y float64, s string, size float64, a anchor, c color.RGBA) {
+	scale := fontScale(size)
+	width := cv.textWidth(s, size)
+	left := x
+	switch a {
+	case anchorMiddle:
+		left = x - width/2
+	case anchorEnd:
+		left = x - width
+	}
+	top := int(math.Round(y)) - glyphHeight*scale/2
+	for i, r := range []rune(s) {
+		g := glyph(r)
+		gx := int(math.Round(left)) + i*(glyphWidth+1)*scale
+		for row, line := range g {
+			for col, dot := range line {
+				if dot != '#' {
+					continue
+				}
+				for dx := 0; dx < scale; dx++ {
+					for dy := 0; dy < scale; dy++ {
+						cv.set(gx+col*scale+dx, top+row*scale+dy, c)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (cv *pngCanvas) textWidth(s string, size float64) float64 {
+	n := len([]rune(s))
+	if n == 0 {
+		return 0
+	}
+	return float64((n*(glyphWidth+1) - 1) * fontScale(size))
+}
+
+func (cv *pngCanvas) encode() ([]byte, error) {
+	var b bytes.Buffer
+	err := png.Encode(&b, cv.img)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
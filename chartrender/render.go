@@ -0,0 +1,429 @@
+// Package chartrender draws the charts as SVG or PNG images without any external dependency.
+package chartrender
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"platform-go-challenge/domain"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrWrongOptions = errors.New("wrong render options")
+)
+
+type Format string
+
+const (
+	SVGFormat = Format("svg")
+	PNGFormat = Format("png")
+)
+
+func (f Format) ContentType() string {
+	if f == PNGFormat {
+		return "image/png"
+	}
+	return "image/svg+xml"
+}
+
+const (
+	DefaultWidth  = 800
+	DefaultHeight = 500
+	MinSize       = 200
+	MaxSize       = 4000
+)
+
+type Options struct {
+	Format Format
+	Width  int
+	Height int
+}
+
+// Validate sets the defaults of the empty options and checks the rest.
+func (o *Options) Validate() error {
+	if o.Format == "" {
+		o.Format = SVGFormat
+	}
+	if o.Width == 0 {
+		o.Width = DefaultWidth
+	}
+	if o.Height == 0 {
+		o.Height = DefaultHeight
+	}
+	if o.Format != SVGFormat && o.Format != PNGFormat {
+		return fmt.Errorf("%w: format should be svg or png", ErrWrongOptions)
+	}
+	if o.Width < MinSize || o.Width > MaxSize || o.Height < MinSize || o.Height > MaxSize {
+		return fmt.Errorf("%w: width and height should be between %d and %d", ErrWrongOptions, MinSize, MaxSize)
+	}
+	return nil
+}
+
+// Key identifies a rendering of a version of a chart with the titles in the locale in the cache.
+func Key(chartID, version uint, locale string, opts Options) string {
+	return fmt.Sprintf("%d:%d:%s:%s:%dx%d", chartID, version, locale, opts.Format, opts.Width, opts.Height)
+}
+
+var (
+	white     = color.RGBA{255, 255, 255, 255}
+	black     = color.RGBA{33, 33, 33, 255}
+	gray      = color.RGBA{120, 120, 120, 255}
+	lightGray = color.RGBA{225, 225, 225, 255}
+	palette   = []color.RGBA{
+		{31, 119, 180, 255},
+		{255, 127, 14, 255},
+		{44, 160, 44, 255},
+		{214, 39, 40, 255},
+		{148, 103, 189, 255},
+		{140, 86, 75, 255},
+		{227, 119, 194, 255},
+		{23, 190, 207, 255},
+	}
+)
+
+func seriesColor(i int) color.RGBA {
+	return palette[i%len(palette)]
+}
+
+type anchor int
+
+const (
+	anchorStart anchor = iota
+	anchorMiddle
+	anchorEnd
+)
+
+type point struct {
+	x, y float64
+}
+
+// canvas is implemented by the SVG and the PNG backends.
+// Angles of wedges are in radians, clockwise from 12 o'clock, and texts are vertically centered on y.
+type canvas interface {
+	line(x1, y1, x2, y2 float64, c color.RGBA, width float64)
+	polyline(points []point, c color.RGBA, width float64)
+	rect(x, y, w, h float64, c color.RGBA)
+	circle(x, y, r float64, c color.RGBA)
+	wedge(cx, cy, r, from, to float64, c color.RGBA)
+	text(x, y float64, s string, size float64, a anchor, c color.RGBA)
+	textWidth(s string, size float64) float64
+	encode() ([]byte, error)
+}
+
+const (
+	titleSize = 18
+	labelSize = 12
+	tickSize  = 10
+)
+
+// Render draws the chart with its title, its axes and a legend of its series.
+func Render(chart *domain.Chart, opts Options) ([]byte, error) {
+	err := opts.Validate()
+	if err != nil {
+		return nil, err
+	}
+	var cv canvas
+	if opts.Format == PNGFormat {
+		cv = newPNGCanvas(opts.Width, opts.Height)
+	} else {
+		cv = newSVGCanvas(opts.Width, opts.Height)
+	}
+	c := *chart
+	c.SetDefaults()
+	series := c.AllSeries()
+
+	w := float64(opts.Width)
+	h := float64(opts.Height)
+	cv.rect(0, 0, w, h, white)
+	cv.text(w/2, 25, c.Title, titleSize, anchorMiddle, black)
+
+	legend := []string{}
+	if c.Kind == domain.PieChartKind {
+		legend = series[0].Categories
+	} else {
+		for _, s := range series {
+			legend = append(legend, s.Name)
+		}
+	}
+	legendWidth := 0.0
+	for _, v := range legend {
+		legendWidth = math.Max(legendWidth, cv.textWidth(v, labelSize))
+	}
+	legendWidth = math.Min(legendWidth+30, w/3)
+
+	area := plotArea{left: 70, top: 60, right: w - legendWidth - 20, bottom: h - 60}
+	drawLegend(cv, legend, area.right+15, area.top)
+
+	switch c.Kind {
+	case domain.PieChartKind:
+		drawPie(cv, series[0], area)
+	case domain.BarChartKind:
+		drawBars(cv, &c, series, area)
+	default:
+		drawXY(cv, &c, series, area)
+	}
+	return cv.encode()
+}
+
+type plotArea struct {
+	left, top, right, bottom float64
+}
+
+func (a plotArea) width() float64 {
+	return a.right - a.left
+}
+
+func (a plotArea) height() float64 {
+	return a.bottom - a.top
+}
+
+func drawLegend(cv canvas, names []string, x, y float64) {
+	for i, v := range names {
+		cy := y + float64(i)*20
+		cv.rect(x, cy-5, 10, 10, seriesColor(i))
+		cv.text(x+16, cy, v, labelSize, anchorStart, black)
+	}
+}
+
+// scale maps the values of an axis to pixels.
+type scale struct {
+	min, max   float64
+	from, to   float64
+	ticks      []float64
+	tickFormat func(float64) string
+}
+
+func (s scale) at(v float64) float64 {
+	return s.from + (v-s.min)/(s.max-s.min)*(s.to-s.from)
+}
+
+// maxTicks bounds the ticks of an axis whatever its range.
+const maxTicks = 1000
+
+// niceScale rounds the range to a step of 1, 2 or 5 times a power of ten with about 5 ticks.
+func niceScale(min, max, from, to float64) scale {
+	if min == max {
+		d := 1.0
+		if min-d == min {
+			d = math.Abs(min) / 10
+		}
+		min -= d
+		max += d
+	}
+	raw := (max - min) / 5
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := magnitude * 10
+	for _, m := range []float64{1, 2, 5} {
+		if raw <= m*magnitude {
+			step = m * magnitude
+			break
+		}
+	}
+	s := scale{
+		min:  math.Floor(min/step) * step,
+		max:  math.Ceil(max/step) * step,
+		from: from,
+		to:   to,
+	}
+	decimals := int(math.Max(0, -math.Floor(math.Log10(step))))
+	s.tickFormat = func(v float64) string {
+		return strconv.FormatFloat(v, 'f', decimals, 64)
+	}
+	// Ranges far from zero can make step vanish next to min in floating point,
+	// so ticks are counted rather than accumulated.
+	if step == 0 || math.IsNaN(step) || math.IsInf(step, 0) || s.min+step == s.min {
+		s.ticks = []float64{s.min}
+		return s
+	}
+	n := int(math.Round((s.max - s.min) / step))
+	if n > maxTicks {
+		n = maxTicks
+	}
+	for i := 0; i <= n; i++ {
+		s.ticks = append(s.ticks, s.min+float64(i)*step)
+	}
+	return s
+}
+
+func timeScale(min, max, from, to float64) scale {
+	if min == max {
+		min -= 3600
+		max += 3600
+	}
+	s := scale{min: min, max: max, from: from, to: to}
+	layout := "2006-01-02"
+	if max-min < 2*24*3600 {
+		layout = "15:04"
+	}
+	s.tickFormat = func(v float64) string {
+		return time.Unix(int64(v), 0).UTC().Format(layout)
+	}
+	for i := 0; i <= 4; i++ {
+		s.ticks = append(s.ticks, min+float64(i)*(max-min)/4)
+	}
+	return s
+}
+
+func yScale(series []domain.Series, area plotArea, withZero bool) scale {
+	min, max := math.Inf(1), math.Inf(-1)
+	if withZero {
+		min, max = 0, 0
+	}
+	for _, s := range series {
+		for _, y := range s.Y {
+			min = math.Min(min, y)
+			max = math.Max(max, y)
+		}
+	}
+	return niceScale(min, max, area.bottom, area.top)
+}
+
+func drawYAxis(cv canvas, c *domain.Chart, ys scale, area plotArea) {
+	for _, v := range ys.ticks {
+		y := ys.at(v)
+		cv.line(area.left, y, area.right, y, lightGray, 1)
+		cv.text(area.left-6, y, ys.tickFormat(v), tickSize, anchorEnd, gray)
+	}
+	cv.line(area.left, area.top, area.left, area.bottom, gray, 1)
+	cv.line(area.left, area.bottom, area.right, area.bottom, gray, 1)
+	cv.text(10, area.top-15, c.YTitle, labelSize, anchorStart, black)
+	cv.text(area.left+area.width()/2, area.bottom+40, c.XTitle, labelSize, anchorMiddle, black)
+}
+
+func xValues(c *domain.Chart, s domain.Series) []float64 {
+	if c.XAxis == domain.TimeAxisType {
+		xs := []float64{}
+		for _, t := range s.Times {
+			xs = append(xs, float64(t.Unix()))
+		}
+		return xs
+	}
+	return s.X
+}
+
+// drawXY draws the line and the scatter charts.
+func drawXY(cv canvas, c *domain.Chart, series []domain.Series, area plotArea) {
+	ys := yScale(series, area, false)
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, s := range series {
+		for _, x := range xValues(c, s) {
+			min = math.Min(min, x)
+			max = math.Max(max, x)
+		}
+	}
+	var xs scale
+	if c.XAxis == domain.TimeAxisType {
+		xs = timeScale(min, max, area.left, area.right)
+	} else {
+		xs = niceScale(min, max, area.left, area.right)
+	}
+	drawYAxis(cv, c, ys, area)
+	for _, v := range xs.ticks {
+		x := xs.at(v)
+		cv.line(x, area.bottom, x, area.bottom+4, gray, 1)
+		cv.text(x, area.bottom+14, xs.tickFormat(v), tickSize, anchorMiddle, gray)
+	}
+	for i, s := range series {
+		values := xValues(c, s)
+		points := []point{}
+		for j := range s.Y {
+			points = append(points, point{xs.at(values[j]), ys.at(s.Y[j])})
+		}
+		if c.Kind == domain.ScatterChartKind {
+			for _, p := range points {
+				cv.circle(p.x, p.y, 3, seriesColor(i))
+			}
+			continue
+		}
+		sort.Slice(points, func(a, b int) bool {
+			return points[a].x < points[b].x
+		})
+		cv.polyline(points, seriesColor(i), 2)
+	}
+}
+
+// drawBars draws a group of bars for every category, or for every time of a time axis.
+func drawBars(cv canvas, c *domain.Chart, series []domain.Series, area plotArea) {
+	labels := []string{}
+	index := map[string]int{}
+	keys := make([][]string, len(series))
+	if c.XAxis == domain.TimeAxisType {
+		times := []time.Time{}
+		for i, s := range series {
+			for _, t := range s.Times {
+				key := t.UTC().Format(time.RFC3339)
+				keys[i] = append(keys[i], key)
+				if _, ok := index[key]; !ok {
+					index[key] = 0
+					times = append(times, t)
+				}
+			}
+		}
+		sort.Slice(times, func(a, b int) bool {
+			return times[a].Before(times[b])
+		})
+		for i, t := range times {
+			index[t.UTC().Format(time.RFC3339)] = i
+			labels = append(labels, t.UTC().Format("2006-01-02"))
+		}
+	} else {
+		for i, s := range series {
+			keys[i] = s.Categories
+			for _, v := range s.Categories {
+				if _, ok := index[v]; !ok {
+					index[v] = len(labels)
+					labels = append(labels, v)
+				}
+			}
+		}
+	}
+
+	ys := yScale(series, area, true)
+	drawYAxis(cv, c, ys, area)
+	band := area.width() / float64(len(labels))
+	every := 1
+	widest := 0.0
+	for _, v := range labels {
+		widest = math.Max(widest, cv.textWidth(v, tickSize))
+	}
+	if widest > 0 {
+		every = int(math.Ceil((widest + 6) / band))
+	}
+	for i, v := range labels {
+		if i%every == 0 {
+			cv.text(area.left+(float64(i)+0.5)*band, area.bottom+14, v, tickSize, anchorMiddle, gray)
+		}
+	}
+	barWidth := band * 0.8 / float64(len(series))
+	zero := ys.at(0)
+	for i, s := range series {
+		for j, y := range s.Y {
+			x := area.left + float64(index[keys[i][j]])*band + band*0.1 + float64(i)*barWidth
+			top := math.Min(zero, ys.at(y))
+			cv.rect(x, top, barWidth, math.Abs(ys.at(y)-zero), seriesColor(i))
+		}
+	}
+}
+
+func drawPie(cv canvas, s domain.Series, area plotArea) {
+	total := 0.0
+	for _, y := range s.Y {
+		total += y
+	}
+	if total == 0 {
+		return
+	}
+	r := math.Min(area.width(), area.height())/2 - 10
+	cx := area.left + area.width()/2
+	cy := area.top + area.height()/2
+	from := 0.0
+	for i, y := range s.Y {
+		to := from + y/total*2*math.Pi
+		cv.wedge(cx, cy, r, from, to, seriesColor(i))
+		from = to
+	}
+}
@@ -0,0 +1,128 @@
+package chartrender
+
+import (
+	"bytes"
+	"image/png"
+	"platform-go-challenge/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testCharts = []*domain.Chart{
+	{
+		Title:  "Relationship between tax and GDP",
+		XTitle: "GDP",
+		YTitle: "Tax",
+		Data: domain.XYData{
+			X: []float64{1, 2, 3, 4, 5},
+			Y: []float64{1, 4, 9, 16, 25},
+		},
+	},
+	{
+		Title:  "Sales per country",
+		XTitle: "Country",
+		YTitle: "Sales",
+		Kind:   domain.BarChartKind,
+		XAxis:  domain.CategoryAxisType,
+		Series: []domain.Series{
+			{Name: "2021", Categories: []string{"Sweden", "Greece"}, Y: []float64{10, -20}},
+			{Name: "2022", Categories: []string{"Sweden", "Greece"}, Y: []float64{15, 25}},
+		},
+	},
+	{
+		Title:  "Share of devices",
+		XTitle: "Device",
+		YTitle: "Users",
+		Kind:   domain.PieChartKind,
+		XAxis:  domain.CategoryAxisType,
+		Series: []domain.Series{
+			{Name: "users", Categories: []string{"mobile", "desktop"}, Y: []float64{70, 30}},
+		},
+	},
+	{
+		Title:  "Daily visits",
+		XTitle: "Day",
+		YTitle: "Visits",
+		Kind:   domain.ScatterChartKind,
+		XAxis:  domain.NumericAxisType,
+		Series: []domain.Series{
+			{Name: "visits", X: []float64{0.1, 0.2, 0.3}, Y: []float64{100, 100, 100}},
+		},
+	},
+	{
+		Title:  "Daily visits",
+		XTitle: "Day",
+		YTitle: "Visits",
+		XAxis:  domain.TimeAxisType,
+		Series: []domain.Series{
+			{Name: "visits", Times: []time.Time{time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 9, 0, 0, 0, 0, time.UTC)}, Y: []float64{100, 120}},
+		},
+	},
+}
+
+func TestRenderSVG(t *testing.T) {
+	for _, v := range testCharts {
+		b, err := Render(v, Options{Format: SVGFormat})
+		assert.NoError(t, err)
+		svg := string(b)
+		assert.True(t, strings.HasPrefix(svg, "<svg"))
+		assert.True(t, strings.HasSuffix(svg, "</svg>"))
+		assert.Contains(t, svg, v.Title)
+	}
+	b, err := Render(testCharts[1], Options{})
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), ">2022</text>")
+	assert.Contains(t, string(b), ">Greece</text>")
+}
+
+func TestRenderPNG(t *testing.T) {
+	for _, v := range testCharts {
+		b, err := Render(v, Options{Format: PNGFormat, Width: 400, Height: 300})
+		assert.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(b))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, img.Bounds().Dx())
+		assert.Equal(t, 300, img.Bounds().Dy())
+	}
+}
+
+func TestRenderWrongOptions(t *testing.T) {
+	_, err := Render(testCharts[0], Options{Format: Format("gif")})
+	assert.ErrorIs(t, err, ErrWrongOptions)
+	_, err = Render(testCharts[0], Options{Width: 10})
+	assert.ErrorIs(t, err, ErrWrongOptions)
+	_, err = Render(testCharts[0], Options{Height: MaxSize + 1})
+	assert.ErrorIs(t, err, ErrWrongOptions)
+}
+
+func TestCache(t *testing.T) {
+	c := NewCache(2)
+	c.Add("a", []byte("a"))
+	c.Add("b", []byte("b"))
+	c.Add("c", []byte("c"))
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	b, ok := c.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("c"), b)
+}
+
+func TestRenderHugeValues(t *testing.T) {
+	for _, y := range [][]float64{{1e16, 1e16 + 2}, {1e16, 1e16}, {-1e308, 1e308}} {
+		c := &domain.Chart{
+			Title: "Huge values",
+			Data:  domain.XYData{X: []float64{1, 2}, Y: y},
+		}
+		for _, f := range []Format{SVGFormat, PNGFormat} {
+			b, err := Render(c, Options{Format: f})
+			assert.NoError(t, err)
+			assert.NotEmpty(t, b)
+		}
+		s := niceScale(y[0], y[1], 0, 100)
+		assert.NotEmpty(t, s.ticks)
+		assert.LessOrEqual(t, len(s.ticks), maxTicks+1)
+	}
+}
@@ -0,0 +1,78 @@
+package chartrender
+
+import (
+	"fmt"
+	"html"
+	"image/color"
+	"math"
+	"strings"
+)
+
+type svgCanvas struct {
+	b strings.Builder
+}
+
+func newSVGCanvas(width, height int) *svgCanvas {
+	cv := &svgCanvas{}
+	fmt.Fprintf(&cv.b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`, width, height, width, height)
+	return cv
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func (cv *svgCanvas) line(x1, y1, x2, y2 float64, c color.RGBA, width float64) {
+	fmt.Fprintf(&cv.b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="%.1f"/>`, x1, y1, x2, y2, svgColor(c), width)
+}
+
+func (cv *svgCanvas) polyline(points []point, c color.RGBA, width float64) {
+	coords := []string{}
+	for _, p := range points {
+		coords = append(coords, fmt.Sprintf("%.1f,%.1f", p.x, p.y))
+	}
+	fmt.Fprintf(&cv.b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="%.1f"/>`, strings.Join(coords, " "), svgColor(c), width)
+}
+
+func (cv *svgCanvas) rect(x, y, w, h float64, c color.RGBA) {
+	fmt.Fprintf(&cv.b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`, x, y, w, h, svgColor(c))
+}
+
+func (cv *svgCanvas) circle(x, y, r float64, c color.RGBA) {
+	fmt.Fprintf(&cv.b, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"/>`, x, y, r, svgColor(c))
+}
+
+func (cv *svgCanvas) wedge(cx, cy, r, from, to float64, c color.RGBA) {
+	if to-from >= 2*math.Pi-1e-9 {
+		cv.circle(cx, cy, r, c)
+		return
+	}
+	large := 0
+	if to-from > math.Pi {
+		large = 1
+	}
+	x1, y1 := cx+r*math.Sin(from), cy-r*math.Cos(from)
+	x2, y2 := cx+r*math.Sin(to), cy-r*math.Cos(to)
+	fmt.Fprintf(&cv.b, `<path d="M%.1f,%.1f L%.1f,%.1f A%.1f,%.1f 0 %d 1 %.1f,%.1f Z" fill="%s"/>`, cx, cy, x1, y1, r, r, large, x2, y2, svgColor(c))
+}
+
+func (cv *svgCanvas) text(x, y float64, s string, size float64, a anchor, c color.RGBA) {
+	textAnchor := "start"
+	switch a {
+	case anchorMiddle:
+		textAnchor = "middle"
+	case anchorEnd:
+		textAnchor = "end"
+	}
+	fmt.Fprintf(&cv.b, `<text x="%.1f" y="%.1f" font-size="%.0f" text-anchor="%s" dominant-baseline="middle" fill="%s">%s</text>`, x, y, size, textAnchor, svgColor(c), html.EscapeString(s))
+}
+
+// textWidth estimates the width of the text for an average sans-serif font.
+func (cv *svgCanvas) textWidth(s string, size float64) float64 {
+	return float64(len([]rune(s))) * size * 0.6
+}
+
+func (cv *svgCanvas) encode() ([]byte, error) {
+	cv.b.WriteString("</svg>")
+	return []byte(cv.b.String()), nil
+}
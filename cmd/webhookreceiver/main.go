@@ -0,0 +1,56 @@
+// Command webhookreceiver is a local receiver of the webhooks of the server, to try them out.
+// It verifies the signatures of the deliveries with the secret of the webhook and prints them,
+// and it can fail the first deliveries to see them retried.
+//
+//	go run ./cmd/webhookreceiver -addr :9000 -secret <secret of the webhook> -fail 2
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"net/http"
+	"platform-go-challenge/domain"
+	"strconv"
+	"sync"
+)
+
+func main() {
+	addr := flag.String("addr", ":9000", "address that the receiver listens to")
+	secret := flag.String("secret", "", "secret of the webhook, the signatures are not verified without it")
+	fail := flag.Int("fail", 0, "number of the first deliveries that are answered with 500")
+	flag.Parse()
+
+	var mu sync.Mutex
+	received := 0
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		payload, err := io.ReadAll(io.LimitReader(r.Body, 16<<20))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		event := r.Header.Get(domain.WebhookEventHeader)
+		delivery := r.Header.Get(domain.WebhookDeliveryHeader)
+		if *secret != "" {
+			timestamp, _ := strconv.ParseInt(r.Header.Get(domain.WebhookTimestampHeader), 10, 64)
+			if !domain.VerifyWebhookSignature(*secret, timestamp, payload, r.Header.Get(domain.WebhookSignatureHeader)) {
+				log.Printf("delivery %s of %s: wrong signature", delivery, event)
+				http.Error(w, "wrong signature", http.StatusUnauthorized)
+				return
+			}
+		}
+		mu.Lock()
+		received++
+		failed := received <= *fail
+		mu.Unlock()
+		if failed {
+			log.Printf("delivery %s of %s: failed on purpose", delivery, event)
+			http.Error(w, "failed on purpose", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("delivery %s of %s: %s", delivery, event, payload)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	log.Printf("receiving webhooks on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
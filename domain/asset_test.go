@@ -42,7 +42,7 @@ func TestAddAssetWrongInputFailure(t *testing.T) {
 
 func TestAddAssetSuccess(t *testing.T) {
 	mdb := &MockDB{}
-	mdb.addAsset = func(ctx context.Context, asset InputAsset) (*Asset, error) {
+	mdb.addAsset = func(ctx context.Context, scope AssetScope, asset InputAsset) (*Asset, error) {
 		newAsset := Asset{
 			ID:   1,
 			Data: asset.Data,
@@ -106,13 +106,16 @@ func TestUpdateAssetWrongInputFailure(t *testing.T) {
 
 func TestUpdateAssetSuccess(t *testing.T) {
 	mdb := &MockDB{}
-	mdb.updateAsset = func(ctx context.Context, assetID uint, asset InputAsset) (*Asset, error) {
+	mdb.updateAsset = func(ctx context.Context, scope AssetScope, assetID uint, asset InputAsset) (*Asset, error) {
 		newAsset := Asset{
 			ID:   assetID,
 			Data: asset.Data,
 		}
 		return &newAsset, nil
 	}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, assetType AssetType, assetID uint) (*Asset, error) {
+		return &Asset{ID: assetID, Status: DraftStatus}, nil
+	}
 	dom := NewDomain(mdb)
 	ctx := context.Background()
 	usr := &User{
@@ -131,6 +134,38 @@ func TestUpdateAssetSuccess(t *testing.T) {
 	}
 }
 
+func TestUpdateReviewedAssetFailure(t *testing.T) {
+	mdb := &MockDB{}
+	status := PublishedStatus
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, assetType AssetType, assetID uint) (*Asset, error) {
+		return &Asset{ID: assetID, Status: status}, nil
+	}
+	updated := false
+	mdb.updateAsset = func(ctx context.Context, scope AssetScope, assetID uint, asset InputAsset) (*Asset, error) {
+		updated = true
+		return &Asset{ID: assetID, Data: asset.Data}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+	}
+	for _, status = range []AssetStatus{InReviewStatus, ScheduledStatus, PublishedStatus} {
+		_, err := dom.UpdateAsset(ctx, usr, 1, InputAsset{Data: CorrectInputTestAssetData[0]})
+		assert.ErrorIs(t, err, ErrWrongStatusInput)
+	}
+	assert.False(t, updated)
+
+	// the content can be edited again after moving the asset back to draft
+	for _, status = range []AssetStatus{DraftStatus, ArchivedStatus} {
+		_, err := dom.UpdateAsset(ctx, usr, 1, InputAsset{Data: CorrectInputTestAssetData[0]})
+		assert.NoError(t, err)
+	}
+	assert.True(t, updated)
+}
+
 func TestListAssetFailure(t *testing.T) {
 	dom := NewDomain(&MockDB{})
 	ctx := context.Background()
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func regionName(region string) (string, bool) {
+	countries()
+	name, ok := regionNames[strings.ToLower(region)]
+	return name, ok
+}
+
+func validGender(g GenderType) bool {
+	switch g {
+	case MaleGenderType, FemaleGenderType, NonBinaryGenderType, OtherGenderType, UndisclosedGenderType:
+		return true
+	}
+	return false
+}
+
+func validDevice(d DeviceType) bool {
+	switch d {
+	case MobileDeviceType, DesktopDeviceType, TabletDeviceType, TVDeviceType:
+		return true
+	}
+	return false
+}
+
+func validateRange(name string, r *IntRange, min, max int) error {
+	if r.Min < min || r.Max > max || r.Min > r.Max {
+		return fmt.Errorf("%s should be a range between %d and %d", name, min, max)
+	}
+	return nil
+}
+
+// validateAudienceSegment checks the dimensions of the audience that the validator tags cannot express.
+// Every dimension is given either with the single value of the first audiences or with its list or range.
+func validateAudienceSegment(a *Audience) error {
+	if a.AgeMin > a.AgeMax {
+		return errors.New("age min is greater than age max")
+	}
+
+	if a.Gender != "" && len(a.Genders) > 0 {
+		return errors.New("gender and genders cannot be both set")
+	}
+	if len(a.AllGenders()) == 0 {
+		return errors.New("gender is required")
+	}
+	for _, g := range a.AllGenders() {
+		if !validGender(g) {
+			return errors.New("gender is not correct")
+		}
+	}
+
+	if a.Country != "" && len(a.Countries) > 0 {
+		return errors.New("country and countries cannot be both set")
+	}
+	if a.Country == "" && len(a.Countries) == 0 && len(a.Regions) == 0 {
+		return errors.New("a country or a region is required")
+	}
+	for _, c := range a.AllCountries() {
+		_, err := CountryCode(c)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range a.Regions {
+		if _, ok := regionName(r); !ok {
+			return fmt.Errorf("region %s does not exist", r)
+		}
+	}
+
+	if (a.HoursSpent != 0) == (a.HoursSpentRange != nil) {
+		return errors.New("set either the hours spent or their range")
+	}
+	if a.HoursSpentRange != nil {
+		err := validateRange("hours spent", a.HoursSpentRange, 0, 24)
+		if err != nil {
+			return err
+		}
+	}
+	if (a.NumberOfPurchases != 0) == (a.NumberOfPurchasesRange != nil) {
+		return errors.New("set either the number of purchases or their range")
+	}
+	if a.NumberOfPurchasesRange != nil {
+		err := validateRange("number of purchases", a.NumberOfPurchasesRange, 0, 100)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, d := range a.Devices {
+		if !validDevice(d) {
+			return fmt.Errorf("device %s is not correct", d)
+		}
+	}
+	return nil
+}
+
+// normalizeAudience stores the countries of a valid audience as ISO alpha-2 codes and its regions in a single form.
+func normalizeAudience(a *Audience) {
+	if a.Country != "" {
+		a.Country, _ = CountryCode(a.Country)
+	}
+	for i, c := range a.Countries {
+		a.Countries[i], _ = CountryCode(c)
+	}
+	for i, r := range a.Regions {
+		a.Regions[i], _ = regionName(r)
+	}
+}
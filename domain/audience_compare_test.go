@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareAudiences(t *testing.T) {
+	audiences := map[uint]*Audience{
+		1: {
+			AgeMin:            20,
+			AgeMax:            39,
+			Genders:           []GenderType{FemaleGenderType, MaleGenderType},
+			Countries:         []string{"SE", "GR"},
+			HoursSpentRange:   &IntRange{Min: 1, Max: 4},
+			NumberOfPurchases: 3,
+			Interests:         []string{"cooking", "running"},
+			Description:       "first",
+		},
+		2: {
+			AgeMin:                 30,
+			AgeMax:                 49,
+			Gender:                 FemaleGenderType,
+			Regions:                []string{"Northern Europe"},
+			HoursSpentRange:        &IntRange{Min: 3, Max: 6},
+			NumberOfPurchasesRange: &IntRange{Min: 0, Max: 10},
+			Interests:              []string{"running"},
+			Devices:                []DeviceType{MobileDeviceType},
+			Description:            "second",
+		},
+	}
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		a, ok := audiences[assetID]
+		if !ok {
+			return nil, errors.New("record not found")
+		}
+		return &Asset{ID: assetID, Data: a}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+	}
+	cmp, err := dom.CompareAudiences(ctx, usr, []uint{1, 2})
+	assert.NoError(t, err)
+	assert.NotNil(t, cmp.Intersection)
+	assert.Equal(t, 30, cmp.Intersection.AgeMin)
+	assert.Equal(t, 39, cmp.Intersection.AgeMax)
+	assert.Equal(t, []GenderType{FemaleGenderType}, cmp.Intersection.Genders)
+	assert.Equal(t, []string{"SE"}, cmp.Intersection.Countries)
+	assert.Equal(t, &IntRange{Min: 3, Max: 4}, cmp.Intersection.HoursSpentRange)
+	assert.Equal(t, &IntRange{Min: 3, Max: 3}, cmp.Intersection.NumberOfPurchasesRange)
+	assert.Equal(t, []string{"running"}, cmp.Intersection.Interests)
+	assert.Equal(t, []DeviceType{MobileDeviceType}, cmp.Intersection.Devices)
+
+	assert.Equal(t, 7, len(cmp.Dimensions))
+	age := cmp.Dimensions[0]
+	assert.Equal(t, "age", age.Dimension)
+	assert.InDelta(t, 10.0/30.0, age.Similarity, 1e-9)
+	gender := cmp.Dimensions[1]
+	assert.Equal(t, [][]string{{"male"}, {}}, gender.Only)
+	assert.InDelta(t, 0.5, gender.Similarity, 1e-9)
+	country := cmp.Dimensions[2]
+	assert.Equal(t, []string{"GR"}, country.Only[0])
+	assert.Contains(t, country.Only[1], "NO")
+	device := cmp.Dimensions[6]
+	assert.InDelta(t, 0.25, device.Similarity, 1e-9)
+	assert.True(t, cmp.Similarity > 0 && cmp.Similarity < 1)
+
+	audiences[3] = &Audience{
+		AgeMin:            60,
+		AgeMax:            70,
+		Gender:            FemaleGenderType,
+		Country:           "SE",
+		HoursSpent:        3,
+		NumberOfPurchases: 3,
+		Description:       "third",
+	}
+	cmp, err = dom.CompareAudiences(ctx, usr, []uint{1, 3})
+	assert.NoError(t, err)
+	assert.Nil(t, cmp.Intersection)
+	assert.Equal(t, 0.0, cmp.Dimensions[0].Similarity)
+}
+
+func TestCompareAudiencesFailure(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return nil, errors.New("record not found")
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+	}
+	_, err := dom.CompareAudiences(ctx, usr, []uint{1})
+	assert.ErrorIs(t, err, ErrWrongQueryInput)
+	_, err = dom.CompareAudiences(ctx, usr, []uint{1, 1})
+	assert.ErrorIs(t, err, ErrWrongQueryInput)
+	_, err = dom.CompareAudiences(ctx, usr, []uint{1, 2})
+	assert.ErrorIs(t, err, ErrAssetNotFound)
+	_, err = dom.CompareAudiences(ctx, nil, []uint{1, 2})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAudienceNormalizesSegment(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.addAsset = func(ctx context.Context, scope AssetScope, asset InputAsset) (*Asset, error) {
+		return &Asset{ID: 1, Data: asset.Data}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+	}
+	asset, err := dom.AddAsset(ctx, usr, InputAsset{Data: &Audience{
+		AgeMax:            35,
+		AgeMin:            18,
+		Genders:           []GenderType{NonBinaryGenderType},
+		Countries:         []string{"se"},
+		Regions:           []string{"northern europe"},
+		HoursSpentRange:   &IntRange{Min: 2, Max: 5},
+		NumberOfPurchases: 3,
+		Description:       "bla bla",
+	}})
+	assert.NoError(t, err)
+	audience := asset.Data.(*Audience)
+	assert.Equal(t, []string{"SE"}, audience.Countries)
+	assert.Equal(t, []string{"Northern Europe"}, audience.Regions)
+	assert.Equal(t, IntRange{Min: 3, Max: 3}, audience.NumberOfPurchasesBounds())
+}
+
+func TestAddAudienceStoresCountryCodes(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.addAsset = func(ctx context.Context, scope AssetScope, asset InputAsset) (*Asset, error) {
+		return &Asset{ID: 1, Data: asset.Data}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+	}
+	asset, err := dom.AddAsset(ctx, usr, InputAsset{Data: &Audience{
+		AgeMax:            35,
+		AgeMin:            18,
+		Gender:            FemaleGenderType,
+		Country:           "germany",
+		HoursSpent:        3,
+		NumberOfPurchases: 3,
+		Description:       "bla bla",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, "DE", asset.Data.(*Audience).Country)
+
+	asset, err = dom.AddAsset(ctx, usr, InputAsset{Data: &Audience{
+		AgeMax:            35,
+		AgeMin:            18,
+		Gender:            FemaleGenderType,
+		Countries:         []string{"Deutschland", "GRC", "se"},
+		HoursSpent:        3,
+		NumberOfPurchases: 3,
+		Description:       "bla bla",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"DE", "GR", "SE"}, asset.Data.(*Audience).Countries)
+}
@@ -0,0 +1,221 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// seriesPoints holds the values of a series as numbers. For a category axis the X values are
+// the positions of the categories, for a time axis they are seconds since the Unix epoch.
+type seriesPoints struct {
+	axis       AxisType
+	x          []float64
+	categories []string
+	y          []float64
+}
+
+func newSeriesPoints(axis AxisType, s Series) seriesPoints {
+	p := seriesPoints{axis: axis, y: s.Y}
+	switch axis {
+	case CategoryAxisType:
+		p.categories = s.Categories
+		for i := range s.Y {
+			p.x = append(p.x, float64(i))
+		}
+	case TimeAxisType:
+		for _, t := range s.Times {
+			p.x = append(p.x, float64(t.UnixNano())/1e9)
+		}
+	default:
+		p.x = s.X
+	}
+	return p
+}
+
+// sorted returns the points in ascending X order.
+func (p seriesPoints) sorted() seriesPoints {
+	idx := make([]int, len(p.y))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		return p.x[idx[a]] < p.x[idx[b]]
+	})
+	s := seriesPoints{axis: p.axis}
+	for _, i := range idx {
+		s.x = append(s.x, p.x[i])
+		s.y = append(s.y, p.y[i])
+		if p.categories != nil {
+			s.categories = append(s.categories, p.categories[i])
+		}
+	}
+	return s
+}
+
+// toSeries converts the points back to a series of the X axis type.
+func (p seriesPoints) toSeries(name string) Series {
+	s := Series{Name: name, Y: p.y}
+	switch p.axis {
+	case CategoryAxisType:
+		s.Categories = p.categories
+	case TimeAxisType:
+		for _, x := range p.x {
+			s.Times = append(s.Times, time.Unix(0, int64(x*1e9)).UTC())
+		}
+	default:
+		s.X = p.x
+	}
+	return s
+}
+
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// linearFit fits y = slope*x + intercept with least squares and returns the correlation of x and y.
+// The fit is nil when all X values are equal and the correlation is nil when the X or the Y values are all equal.
+func linearFit(x, y []float64) (*LinearFit, *float64) {
+	if len(x) < 2 {
+		return nil, nil
+	}
+	mx, my := mean(x), mean(y)
+	var sxy, sxx, syy float64
+	for i := range x {
+		dx, dy := x[i]-mx, y[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 {
+		return nil, nil
+	}
+	fit := &LinearFit{Slope: sxy / sxx, Intercept: my - sxy/sxx*mx, R2: 1}
+	if syy == 0 {
+		return fit, nil
+	}
+	r := sxy / math.Sqrt(sxx*syy)
+	fit.R2 = r * r
+	return fit, &r
+}
+
+// movingAverage averages every window of consecutive points and places the result at the last point of the window.
+func movingAverage(p seriesPoints, window int) seriesPoints {
+	out := seriesPoints{axis: p.axis}
+	sum := 0.0
+	for i := range p.y {
+		sum += p.y[i]
+		if i >= window {
+			sum -= p.y[i-window]
+		}
+		if i >= window-1 {
+			out.x = append(out.x, p.x[i])
+			out.y = append(out.y, sum/float64(window))
+			if p.categories != nil {
+				out.categories = append(out.categories, p.categories[i])
+			}
+		}
+	}
+	return out
+}
+
+// resample splits the X range in buckets of equal width and averages the points of every bucket
+// at its center. Empty buckets are skipped.
+func resample(p seriesPoints, buckets int) seriesPoints {
+	out := seriesPoints{axis: p.axis}
+	if len(p.x) == 0 {
+		return out
+	}
+	min, max := p.x[0], p.x[0]
+	for _, x := range p.x {
+		min = math.Min(min, x)
+		max = math.Max(max, x)
+	}
+	if min == max {
+		return seriesPoints{axis: p.axis, x: []float64{min}, y: []float64{mean(p.y)}}
+	}
+	width := (max - min) / float64(buckets)
+	sums := make([]float64, buckets)
+	counts := make([]int, buckets)
+	for i, x := range p.x {
+		b := int((x - min) / width)
+		if b >= buckets {
+			b = buckets - 1
+		}
+		sums[b] += p.y[i]
+		counts[b]++
+	}
+	for b := range sums {
+		if counts[b] == 0 {
+			continue
+		}
+		out.x = append(out.x, min+(float64(b)+0.5)*width)
+		out.y = append(out.y, sums[b]/float64(counts[b]))
+	}
+	return out
+}
+
+// downsample keeps at most maxPoints points of the sorted points with the
+// Largest-Triangle-Three-Buckets algorithm, which keeps the visual shape of the series.
+func downsample(p seriesPoints, maxPoints int) seriesPoints {
+	n := len(p.y)
+	if maxPoints >= n || maxPoints < 3 {
+		return p
+	}
+	keep := []int{0}
+	every := float64(n-2) / float64(maxPoints-2)
+	a := 0
+	for i := 0; i < maxPoints-2; i++ {
+		start := int(float64(i)*every) + 1
+		end := int(float64(i+1)*every) + 1
+		nextEnd := int(float64(i+2)*every) + 1
+		if nextEnd > n {
+			nextEnd = n
+		}
+		// the average point of the next bucket is the third vertex of the triangles
+		avgX, avgY := 0.0, 0.0
+		for j := end; j < nextEnd; j++ {
+			avgX += p.x[j]
+			avgY += p.y[j]
+		}
+		if nextEnd > end {
+			avgX /= float64(nextEnd - end)
+			avgY /= float64(nextEnd - end)
+		} else {
+			avgX, avgY = p.x[n-1], p.y[n-1]
+		}
+		best, bestArea := start, -1.0
+		for j := start; j < end; j++ {
+			area := math.Abs((p.x[a]-avgX)*(p.y[j]-p.y[a]) - (p.x[a]-p.x[j])*(avgY-p.y[a]))
+			if area > bestArea {
+				best, bestArea = j, area
+			}
+		}
+		keep = append(keep, best)
+		a = best
+	}
+	keep = append(keep, n-1)
+	out := seriesPoints{axis: p.axis}
+	for _, i := range keep {
+		out.x = append(out.x, p.x[i])
+		out.y = append(out.y, p.y[i])
+		if p.categories != nil {
+			out.categories = append(out.categories, p.categories[i])
+		}
+	}
+	return out
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeChartSuccess(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return &Asset{ID: assetID, Version: 2, Data: &Chart{
+			Title:  "Relationship between tax and GDP",
+			XTitle: "GDP",
+			YTitle: "Tax",
+			Data: XYData{
+				X: []float64{4, 1, 2, 3, 5},
+				Y: []float64{9, 3, 5, 7, 11},
+			},
+		}}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+	}
+	analytics, err := dom.AnalyzeChart(ctx, usr, 1, ChartAnalyticsQuery{MovingAverage: 2, Resample: 2, MaxPoints: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), analytics.Version)
+	assert.Equal(t, 1, len(analytics.Series))
+	s := analytics.Series[0]
+	assert.Equal(t, "Tax", s.Name)
+	assert.Equal(t, 5, s.Count)
+	assert.Equal(t, 3.0, s.Min)
+	assert.Equal(t, 11.0, s.Max)
+	assert.Equal(t, 7.0, s.Mean)
+	assert.Equal(t, 7.0, s.Median)
+	assert.InDelta(t, 2.0, s.Regression.Slope, 1e-9)
+	assert.InDelta(t, 1.0, s.Regression.Intercept, 1e-9)
+	assert.InDelta(t, 1.0, s.Regression.R2, 1e-9)
+	assert.InDelta(t, 1.0, *s.Correlation, 1e-9)
+	// the moving average 4, 6, 8, 10 is downsampled to 3 points too
+	assert.Equal(t, 3, len(s.MovingAverage.Y))
+	assert.Equal(t, 4.0, s.MovingAverage.Y[0])
+	assert.Equal(t, 10.0, s.MovingAverage.Y[2])
+	assert.Equal(t, 5.0, s.MovingAverage.X[2])
+	assert.Equal(t, []float64{4, 9}, s.Resampled.Y)
+	assert.Equal(t, []float64{2, 4}, s.Resampled.X)
+	assert.Equal(t, 3, len(s.Downsampled.Y))
+	assert.Equal(t, 1.0, s.Downsampled.X[0])
+	assert.Equal(t, 5.0, s.Downsampled.X[2])
+}
+
+func TestGetChartToRender(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		if assetID == 2 {
+			return &Asset{ID: assetID, Data: &Insight{Text: "text"}}, nil
+		}
+		return &Asset{ID: assetID, Version: 2, Data: &Chart{Title: "Relationship between tax and GDP"}}, nil
+	}
+	mdb.listSimilarities = func(ctx context.Context, items []FavouriteItem) ([]AssetSimilarity, error) {
+		t.Error("the related assets are read to render a chart")
+		return nil, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{ID: 1, Username: "manos"}
+	asset, err := dom.GetChartToRender(ctx, usr, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), asset.Version)
+	// rendering a chart is not a view of it
+	assert.Equal(t, 0, len(dom.views))
+
+	_, err = dom.GetChartToRender(ctx, usr, 2)
+	assert.ErrorIs(t, err, ErrAssetNotFound)
+	_, err = dom.GetChartToRender(ctx, nil, 1)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestAnalyzeChartWrongQueryFailure(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return &Asset{ID: assetID, Data: CorrectInputTestAssetData[3]}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+	}
+	_, err := dom.AnalyzeChart(ctx, usr, 1, ChartAnalyticsQuery{MaxPoints: 2})
+	assert.ErrorIs(t, err, ErrWrongQueryInput)
+	_, err = dom.AnalyzeChart(ctx, usr, 1, ChartAnalyticsQuery{Resample: 2})
+	assert.ErrorIs(t, err, ErrWrongQueryInput)
+	analytics, err := dom.AnalyzeChart(ctx, usr, 1, ChartAnalyticsQuery{MovingAverage: 2})
+	assert.NoError(t, err)
+	assert.Nil(t, analytics.Series[0].Regression)
+	assert.Equal(t, []string{"Greece"}, analytics.Series[0].MovingAverage.Categories)
+}
+
+func TestDownsampleKeepsPeaks(t *testing.T) {
+	p := seriesPoints{axis: NumericAxisType}
+	for i := 0; i < 1000; i++ {
+		p.x = append(p.x, float64(i))
+		y := 0.0
+		if i == 500 {
+			y = 100
+		}
+		p.y = append(p.y, y)
+	}
+	ds := downsample(p, 10)
+	assert.Equal(t, 10, len(ds.y))
+	assert.Contains(t, ds.y, 100.0)
+	assert.Equal(t, 0.0, ds.x[0])
+	assert.Equal(t, 999.0, ds.x[9])
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCollectionWrongInputFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+	}
+	_, err := dom.CreateCollection(ctx, usr, Collection{Name: ""})
+	assert.ErrorIs(t, err, ErrWrongCollectionInput)
+	_, err = dom.CreateCollection(ctx, nil, Collection{Name: "retail"})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestAddCollectionItemOfOtherUserFailure(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getCollection = func(ctx context.Context, userID, collectionID uint) (*Collection, error) {
+		return nil, errors.New("record not found")
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+	}
+	_, err := dom.AddCollectionItem(ctx, usr, 1, CollectionItem{AssetType: ChartAssetType, AssetID: 1})
+	assert.ErrorIs(t, err, ErrCollectionNotFound)
+}
+
+func TestAddCollectionItemNotVisibleAssetFailure(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getCollection = func(ctx context.Context, userID, collectionID uint) (*Collection, error) {
+		return &Collection{ID: collectionID, Name: "retail"}, nil
+	}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return nil, errors.New("record not found")
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+	}
+	_, err := dom.AddCollectionItem(ctx, usr, 1, CollectionItem{AssetType: ChartAssetType, AssetID: 1})
+	assert.ErrorIs(t, err, ErrAssetNotFound)
+	_, err = dom.AddCollectionItem(ctx, usr, 1, CollectionItem{AssetType: ChartAssetType})
+	assert.ErrorIs(t, err, ErrWrongCollectionInput)
+}
+
+func TestAddCollectionItemSuccess(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getCollection = func(ctx context.Context, userID, collectionID uint) (*Collection, error) {
+		return &Collection{ID: collectionID, Name: "retail"}, nil
+	}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return &Asset{ID: assetID, Data: CorrectInputTestAssetData[1]}, nil
+	}
+	mdb.addCollectionItem = func(ctx context.Context, collectionID uint, item CollectionItem) (*CollectionItem, error) {
+		item.ID = 1
+		item.Position = 1
+		return &item, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+	}
+	item, err := dom.AddCollectionItem(ctx, usr, 1, CollectionItem{AssetType: ChartAssetType, AssetID: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, item.Position)
+	assert.NotNil(t, item.Asset)
+	assert.Equal(t, uint(3), item.Asset.ID)
+}
+
+func TestReorderCollectionWrongInputFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+	}
+	err := dom.ReorderCollection(ctx, usr, 1, []uint{})
+	assert.ErrorIs(t, err, ErrWrongCollectionInput)
+	err = dom.ReorderCollection(ctx, usr, 1, []uint{1, 2, 1})
+	assert.ErrorIs(t, err, ErrWrongCollectionInput)
+}
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func commentMockDB() *MockDB {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		if assetID == 404 {
+			return nil, errors.New("record not found")
+		}
+		return &Asset{ID: assetID, OrgID: 1, Data: &Insight{Text: "text"}}, nil
+	}
+	mdb.findUser = func(ctx context.Context, username string) (*User, error) {
+		switch username {
+		case "maria":
+			return &User{ID: 2, Username: username}, nil
+		case "nikos":
+			return &User{ID: 3, Username: username}, nil
+		case "eleni":
+			return &User{ID: 4, Username: username}, nil
+		}
+		return nil, errors.New("record not found")
+	}
+	// eleni is a user of another organization
+	mdb.isOrganizationMember = func(ctx context.Context, orgID, userID uint) (bool, error) {
+		return orgID == 1 && userID != 4, nil
+	}
+	mdb.getComment = func(ctx context.Context, at AssetType, assetID, commentID uint) (*Comment, error) {
+		if assetID != 1 || commentID > 2 {
+			return nil, errors.New("record not found")
+		}
+		// the first comment is of manos and the second one is deleted
+		return &Comment{ID: commentID, AssetType: at, AssetID: assetID, AuthorID: 1, Deleted: commentID == 2}, nil
+	}
+	return mdb
+}
+
+func TestAddComment(t *testing.T) {
+	mdb := commentMockDB()
+	var mentioned []uint
+	mdb.addComment = func(ctx context.Context, comment Comment, mentionIDs []uint) (*Comment, error) {
+		mentioned = mentionIDs
+		comment.ID = 3
+		return &comment, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{ID: 1, Username: "manos"}
+
+	// the unknown users, the repeated mentions and the emails are ignored
+	comment, err := dom.AddComment(ctx, usr, 1, InsightAssetType, CommentInput{
+		Text: "@maria and @nikos., see @kostas and @maria, mail me at manos@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), comment.AuthorID)
+	assert.Equal(t, []uint{2, 3}, mentioned)
+
+	// the users of other organizations are ignored
+	_, err = dom.AddComment(ctx, usr, 1, InsightAssetType, CommentInput{Text: "@eleni and @maria"})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{2}, mentioned)
+
+	comment, err = dom.AddComment(ctx, usr, 1, InsightAssetType, CommentInput{Text: "reply", ParentID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), comment.ParentID)
+
+	// the parent is a comment of the same asset
+	_, err = dom.AddComment(ctx, usr, 7, InsightAssetType, CommentInput{Text: "reply", ParentID: 1})
+	assert.ErrorIs(t, err, ErrCommentNotFound)
+	_, err = dom.AddComment(ctx, usr, 404, InsightAssetType, CommentInput{Text: "text"})
+	assert.ErrorIs(t, err, ErrAssetNotFound)
+	_, err = dom.AddComment(ctx, usr, 1, InsightAssetType, CommentInput{})
+	assert.ErrorIs(t, err, ErrWrongCommentInput)
+	_, err = dom.AddComment(ctx, usr, 1, InsightAssetType, CommentInput{
+		Text: "@a1 @a2 @a3 @a4 @a5 @a6 @a7 @a8 @a9 @a10 @a11"})
+	assert.ErrorIs(t, err, ErrWrongCommentInput)
+	_, err = dom.AddComment(ctx, nil, 1, InsightAssetType, CommentInput{Text: "text"})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestEditAndDeleteComment(t *testing.T) {
+	mdb := commentMockDB()
+	mdb.updateComment = func(ctx context.Context, commentID uint, text string, mentionIDs []uint) (*Comment, error) {
+		return &Comment{ID: commentID, Text: text}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	author := &User{ID: 1, Username: "manos"}
+	other := &User{ID: 2, Username: "maria"}
+
+	edited, err := dom.EditComment(ctx, author, 1, InsightAssetType, 1, CommentInput{Text: "edited"})
+	assert.NoError(t, err)
+	assert.Equal(t, "edited", edited.Text)
+	_, err = dom.EditComment(ctx, other, 1, InsightAssetType, 1, CommentInput{Text: "edited"})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	_, err = dom.EditComment(ctx, author, 1, InsightAssetType, 2, CommentInput{Text: "edited"})
+	assert.ErrorIs(t, err, ErrWrongCommentInput)
+	_, err = dom.EditComment(ctx, author, 1, InsightAssetType, 5, CommentInput{Text: "edited"})
+	assert.ErrorIs(t, err, ErrCommentNotFound)
+
+	// the administrators of the organization of the asset delete the comments of the others
+	err = dom.DeleteComment(ctx, other, 1, InsightAssetType, 1)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	err = dom.DeleteComment(ctx, &User{ID: 4, IsAdmin: true, OrgID: 2}, 1, InsightAssetType, 1)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	err = dom.DeleteComment(ctx, &User{ID: 4, IsAdmin: true, OrgID: 1}, 1, InsightAssetType, 1)
+	assert.NoError(t, err)
+	err = dom.DeleteComment(ctx, author, 1, InsightAssetType, 1)
+	assert.NoError(t, err)
+}
+
+func TestListAndModerateComments(t *testing.T) {
+	mdb := commentMockDB()
+	mdb.listComments = func(ctx context.Context, at AssetType, assetID uint, query QueryComments) (*ListedComments, error) {
+		return &ListedComments{Limit: query.Limit, FirstID: 1, LastID: 2, Comments: []Comment{
+			{ID: 1, Text: "first"},
+			{ID: 2, Text: "rude @maria", Mentions: []string{"maria"}, Hidden: true},
+		}}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{ID: 2, Username: "maria"}
+	admin := &User{ID: 4, IsAdmin: true, OrgID: 1}
+
+	ls, err := dom.ListComments(ctx, usr, 1, InsightAssetType, QueryComments{Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, "first", ls.Comments[0].Text)
+	assert.Equal(t, "", ls.Comments[1].Text)
+	assert.Nil(t, ls.Comments[1].Mentions)
+	ls, err = dom.ListComments(ctx, admin, 1, InsightAssetType, QueryComments{Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, "rude @maria", ls.Comments[1].Text)
+
+	_, err = dom.ListComments(ctx, usr, 1, InsightAssetType, QueryComments{Limit: 10, ParentID: 5})
+	assert.ErrorIs(t, err, ErrCommentNotFound)
+	_, err = dom.ListComments(ctx, usr, 1, InsightAssetType, QueryComments{})
+	assert.ErrorIs(t, err, ErrWrongQueryInput)
+
+	_, err = dom.ModerateComment(ctx, admin, 1, InsightAssetType, 1, CommentModeration{Hidden: true})
+	assert.NoError(t, err)
+	_, err = dom.ModerateComment(ctx, usr, 1, InsightAssetType, 1, CommentModeration{Hidden: true})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	_, err = dom.ModerateComment(ctx, &User{ID: 5, IsAdmin: true, OrgID: 2}, 1, InsightAssetType, 1, CommentModeration{Hidden: true})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestListMentions(t *testing.T) {
+	mdb := commentMockDB()
+	mdb.listMentions = func(ctx context.Context, userID uint, query QueryComments) (*ListedComments, error) {
+		return &ListedComments{Limit: query.Limit, FirstID: 1, LastID: 3, Comments: []Comment{
+			{ID: 1, AssetType: InsightAssetType, AssetID: 1, Text: "@maria"},
+			{ID: 3, AssetType: InsightAssetType, AssetID: 404, Text: "@maria"},
+		}}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	ls, err := dom.ListMentions(ctx, &User{ID: 2, Username: "maria"}, QueryComments{Limit: 10})
+	assert.NoError(t, err)
+	// the comments on the assets that the user does not see are removed, but the page keeps its last ID
+	assert.Equal(t, 1, len(ls.Comments))
+	assert.Equal(t, uint(3), ls.LastID)
+}
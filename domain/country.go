@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pariz/gountries"
+)
+
+var (
+	countriesOnce  sync.Once
+	countriesQuery *gountries.Query
+	// countryNames maps the lowercase english, native and translated names of the countries to their alpha-2 codes
+	countryNames map[string]string
+	// regionNames maps the lowercase regions and subregions of the countries to their names
+	regionNames map[string]string
+	// regionCountries maps the regions and subregions to the sorted alpha-2 codes of their countries
+	regionCountries map[string][]string
+)
+
+// translationLanguages maps the ISO 639-1 languages to the keys of the translations of gountries.
+var translationLanguages = map[string]string{
+	"cy": "CYM",
+	"de": "DEU",
+	"es": "SPA",
+	"fi": "FIN",
+	"fr": "FRA",
+	"hr": "HRV",
+	"it": "ITA",
+	"ja": "JPN",
+	"nl": "NLD",
+	"pt": "POR",
+	"ru": "RUS",
+}
+
+// countries loads the country data of gountries once, because loading them is slow.
+func countries() *gountries.Query {
+	countriesOnce.Do(func() {
+		countriesQuery = gountries.New()
+		countryNames = map[string]string{}
+		regionNames = map[string]string{}
+		regionCountries = map[string][]string{}
+		for code, c := range countriesQuery.FindAllCountries() {
+			names := []gountries.BaseLang{c.Name.BaseLang}
+			for _, n := range c.Name.Native {
+				names = append(names, n)
+			}
+			for _, n := range c.Translations {
+				names = append(names, n)
+			}
+			for _, n := range names {
+				for _, v := range []string{n.Common, n.Official} {
+					v = strings.ToLower(strings.TrimSpace(v))
+					if _, ok := countryNames[v]; v != "" && !ok {
+						countryNames[v] = code
+					}
+				}
+			}
+			for _, r := range []string{c.Region, c.SubRegion} {
+				if r != "" {
+					regionNames[strings.ToLower(r)] = r
+					regionCountries[r] = append(regionCountries[r], code)
+				}
+			}
+		}
+		for _, codes := range regionCountries {
+			sort.Strings(codes)
+		}
+		// the english names win over the translations of other countries
+		for code, c := range countriesQuery.FindAllCountries() {
+			countryNames[strings.ToLower(c.Name.Common)] = code
+			countryNames[strings.ToLower(c.Name.Official)] = code
+		}
+	})
+	return countriesQuery
+}
+
+// CountryCode returns the ISO 3166 alpha-2 code of a country that is given with its alpha-2 or alpha-3 code
+// or with its english, native or translated name, in any case.
+func CountryCode(country string) (string, error) {
+	q := countries()
+	country = strings.TrimSpace(country)
+	if len(country) == 2 || len(country) == 3 {
+		c, err := q.FindCountryByAlpha(country)
+		if err == nil {
+			return c.Codes.Alpha2, nil
+		}
+	}
+	code, ok := countryNames[strings.ToLower(country)]
+	if !ok {
+		return "", fmt.Errorf("country %s does not exist", country)
+	}
+	return code, nil
+}
+
+// CountryName returns the name of the country with the alpha-2 code in the first of the languages
+// that has a translation, or the english name. Unknown codes are returned as they are.
+func CountryName(code string, languages []string) string {
+	if len(code) != 2 {
+		return code
+	}
+	c, err := countries().FindCountryByAlpha(code)
+	if err != nil {
+		return code
+	}
+	for _, l := range languages {
+		if l == "en" {
+			break
+		}
+		t, ok := c.Translations[translationLanguages[l]]
+		if ok && t.Common != "" {
+			return t.Common
+		}
+	}
+	return c.Name.Common
+}
+
+// LocalizeCountries sets the names of the countries of the audience in the first supported of the languages.
+func (a *Audience) LocalizeCountries(languages []string) {
+	a.CountryNames = nil
+	for _, c := range a.AllCountries() {
+		a.CountryNames = append(a.CountryNames, CountryName(c, languages))
+	}
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountryCode(t *testing.T) {
+	for _, v := range []string{"DE", "de", "DEU", "Germany", "germany", "Federal Republic of Germany", "Deutschland", "Allemagne"} {
+		code, err := CountryCode(v)
+		assert.NoError(t, err, v)
+		assert.Equal(t, "DE", code, v)
+	}
+	_, err := CountryCode("Mordor")
+	assert.Error(t, err)
+	_, err = CountryCode("XX")
+	assert.Error(t, err)
+}
+
+func TestCountryName(t *testing.T) {
+	assert.Equal(t, "Germany", CountryName("DE", nil))
+	assert.Equal(t, "Allemagne", CountryName("DE", []string{"fr", "de"}))
+	assert.Equal(t, "Deutschland", CountryName("DE", []string{"el", "de"}))
+	assert.Equal(t, "Germany", CountryName("DE", []string{"en", "de"}))
+	assert.Equal(t, "Sweden", CountryName("Sweden", []string{"de"}))
+
+	audience := &Audience{Countries: []string{"SE", "GR"}}
+	audience.LocalizeCountries([]string{"es"})
+	assert.Equal(t, []string{"Suecia", "Grecia"}, audience.CountryNames)
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 var CorrectInputTestAssetData = []interface{}{
 	&Insight{
 		Text:        "40% of millenials spend more than 3hours on social media daily",
@@ -24,6 +26,52 @@ var CorrectInputTestAssetData = []interface{}{
 		NumberOfPurchases: 3,
 		Description:       "bla bla",
 	},
+	&Chart{
+		Description: "bla bla",
+		Title:       "Sales per country",
+		XTitle:      "Country",
+		YTitle:      "Sales",
+		Kind:        BarChartKind,
+		XAxis:       CategoryAxisType,
+		Series: []Series{
+			{Name: "2021", Categories: []string{"Sweden", "Greece"}, Y: []float64{10, 20}},
+			{Name: "2022", Categories: []string{"Sweden", "Greece"}, Y: []float64{15, 25}},
+		},
+	},
+	&Chart{
+		Description: "bla bla",
+		Title:       "Share of devices",
+		XTitle:      "Device",
+		YTitle:      "Users",
+		Kind:        PieChartKind,
+		XAxis:       CategoryAxisType,
+		Series: []Series{
+			{Name: "users", Categories: []string{"mobile", "desktop"}, Y: []float64{70, 30}},
+		},
+	},
+	&Chart{
+		Description: "bla bla",
+		Title:       "Daily visits",
+		XTitle:      "Day",
+		YTitle:      "Visits",
+		Kind:        LineChartKind,
+		XAxis:       TimeAxisType,
+		Series: []Series{
+			{Name: "visits", Times: []time.Time{time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)}, Y: []float64{100, 120}},
+		},
+	},
+	&Audience{
+		AgeMax:                 35,
+		AgeMin:                 18,
+		Genders:                []GenderType{FemaleGenderType, NonBinaryGenderType},
+		Countries:              []string{"se", "GR"},
+		Regions:                []string{"northern europe"},
+		HoursSpentRange:        &IntRange{Min: 2, Max: 5},
+		NumberOfPurchasesRange: &IntRange{Min: 0, Max: 10},
+		Interests:              []string{"gaming", "travel"},
+		Devices:                []DeviceType{MobileDeviceType, TabletDeviceType},
+		Description:            "bla bla",
+	},
 }
 
 var WrongInputTestAssetData = []interface{}{
@@ -160,6 +208,150 @@ var WrongInputTestAssetData = []interface{}{
 		HoursSpent:        3,
 		NumberOfPurchases: 3,
 	},
+	&Audience{
+		AgeMax:            30,
+		AgeMin:            20,
+		Gender:            FemaleGenderType,
+		Genders:           []GenderType{MaleGenderType},
+		Country:           "Sweden",
+		HoursSpent:        3,
+		NumberOfPurchases: 3,
+		Description:       "bla bla",
+	},
+	&Audience{
+		AgeMax:            30,
+		AgeMin:            20,
+		Genders:           []GenderType{FemaleGenderType},
+		Countries:         []string{"Sweden", "Gondor"},
+		HoursSpent:        3,
+		NumberOfPurchases: 3,
+		Description:       "bla bla",
+	},
+	&Audience{
+		AgeMax:            30,
+		AgeMin:            20,
+		Genders:           []GenderType{FemaleGenderType},
+		Regions:           []string{"Middle Earth"},
+		HoursSpent:        3,
+		NumberOfPurchases: 3,
+		Description:       "bla bla",
+	},
+	&Audience{
+		AgeMax:            30,
+		AgeMin:            20,
+		Genders:           []GenderType{FemaleGenderType},
+		Countries:         []string{"SE"},
+		HoursSpentRange:   &IntRange{Min: 5, Max: 2},
+		NumberOfPurchases: 3,
+		Description:       "bla bla",
+	},
+	&Audience{
+		AgeMax:                 30,
+		AgeMin:                 20,
+		Genders:                []GenderType{FemaleGenderType},
+		Countries:              []string{"SE"},
+		HoursSpent:             3,
+		NumberOfPurchases:      3,
+		NumberOfPurchasesRange: &IntRange{Min: 1, Max: 2},
+		Description:            "bla bla",
+	},
+	&Audience{
+		AgeMax:            30,
+		AgeMin:            20,
+		Genders:           []GenderType{FemaleGenderType},
+		Countries:         []string{"SE"},
+		HoursSpent:        3,
+		NumberOfPurchases: 3,
+		Devices:           []DeviceType{DeviceType("fridge")},
+		Description:       "bla bla",
+	},
+	&Audience{
+		AgeMax:            20,
+		AgeMin:            30,
+		Gender:            FemaleGenderType,
+		Country:           "Sweden",
+		HoursSpent:        3,
+		NumberOfPurchases: 3,
+		Description:       "bla bla",
+	},
+	&Chart{
+		Description: "bla bla",
+		Title:       "Share of devices",
+		XTitle:      "Device",
+		YTitle:      "Users",
+		Kind:        PieChartKind,
+		XAxis:       CategoryAxisType,
+		Series: []Series{
+			{Name: "2021", Categories: []string{"mobile", "desktop"}, Y: []float64{70, 30}},
+			{Name: "2022", Categories: []string{"mobile", "desktop"}, Y: []float64{80, 20}},
+		},
+	},
+	&Chart{
+		Description: "bla bla",
+		Title:       "Sales per country",
+		XTitle:      "Country",
+		YTitle:      "Sales",
+		Kind:        BarChartKind,
+		Series: []Series{
+			{Name: "2021", X: []float64{1, 2}, Y: []float64{10, 20}},
+		},
+	},
+	&Chart{
+		Description: "bla bla",
+		Title:       "Sales per country",
+		XTitle:      "Country",
+		YTitle:      "Sales",
+		Kind:        BarChartKind,
+		XAxis:       CategoryAxisType,
+		Series: []Series{
+			{Name: "2021", Categories: []string{"Sweden"}, Y: []float64{10, 20}},
+		},
+	},
+	&Chart{
+		Description: "bla bla",
+		Title:       "Sales per country",
+		XTitle:      "Country",
+		YTitle:      "Sales",
+		Kind:        BarChartKind,
+		XAxis:       CategoryAxisType,
+		Series: []Series{
+			{Name: "2021", Categories: []string{"Sweden"}, Y: []float64{10}},
+			{Name: "2021", Categories: []string{"Greece"}, Y: []float64{20}},
+		},
+	},
+	&Chart{
+		Description: "bla bla",
+		Title:       "Relationship between tax and GDP",
+		XTitle:      "GDP",
+		YTitle:      "Tax",
+		Kind:        ChartKind("radar"),
+		Data: XYData{
+			X: []float64{1, 2, 3, 4, 5},
+			Y: []float64{1, 2, 3, 4, 5},
+		},
+	},
+	&Chart{
+		Description: "bla bla",
+		Title:       "Relationship between tax and GDP",
+		XTitle:      "GDP",
+		YTitle:      "Tax",
+		Data: XYData{
+			X: []float64{1, 2, 3, 4, 5},
+			Y: []float64{1, 2, 3, 4, 5},
+		},
+		Series: []Series{
+			{Name: "tax", X: []float64{1, 2}, Y: []float64{1, 2}},
+		},
+	},
+	&Chart{
+		Description: "bla bla",
+		Title:       "Relationship between tax and GDP",
+		XTitle:      "GDP",
+		YTitle:      "Tax",
+		Series: []Series{
+			{Name: "", X: []float64{1, 2}, Y: []float64{1, 2}},
+		},
+	},
 }
 
 var WrongInputTestQueryData = []QueryAssets{
@@ -4,19 +4,42 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/go-playground/validator/v10"
-	"github.com/pariz/gountries"
 )
 
 func NewDomain(db IDBRepository) *Domain {
 	return &Domain{
-		validate: validator.New(),
-		repo:     db,
+		validate:      validator.New(),
+		repo:          db,
+		views:         make(chan AssetView, viewBufferSize),
+		webhookClient: &http.Client{Timeout: webhookTimeout},
 	}
 }
 
+func scopeOf(user *User) AssetScope {
+	return AssetScope{OrgID: user.OrgID, UserID: user.ID, IsAdmin: user.IsAdmin}
+}
+
 func (d *Domain) validateAsset(asset IAsset) error {
+	if ia, ok := asset.(*InputAsset); ok {
+		switch ia.Visibility {
+		case "", PublicVisibility, OrganizationVisibility, PrivateVisibility:
+		default:
+			err := errors.New("visibility is not correct")
+			return fmt.Errorf("%w: %v", ErrWrongAssetInput, err)
+		}
+		if ia.PublishAt != nil && ia.ExpireAt != nil && !ia.ExpireAt.After(*ia.PublishAt) {
+			err := errors.New("expireAt should be after publishAt")
+			return fmt.Errorf("%w: %v", ErrWrongAssetInput, err)
+		}
+		if ia.Locale != "" && !validLocale(ia.Locale) {
+			err := errors.New("locale should be a lowercase ISO 639-1 code")
+			return fmt.Errorf("%w: %v", ErrWrongAssetInput, err)
+		}
+	}
 	switch v := asset.GetData().(type) {
 	case *Insight:
 		err := d.validate.Struct(v)
@@ -29,13 +52,8 @@ func (d *Domain) validateAsset(asset IAsset) error {
 		if err != nil {
 			return fmt.Errorf("%w: %v", ErrWrongAssetInput, err)
 		}
-		if len(v.Data.X) == 0 || len(v.Data.Y) == 0 {
-			err := errors.New("data are empty")
-			return fmt.Errorf("%w: %v", ErrWrongAssetInput, err)
-		}
-
-		if len(v.Data.X) != len(v.Data.Y) {
-			err := errors.New("data are not equal")
+		err = validateChartSeries(v)
+		if err != nil {
 			return fmt.Errorf("%w: %v", ErrWrongAssetInput, err)
 		}
 	case *Audience:
@@ -43,20 +61,110 @@ func (d *Domain) validateAsset(asset IAsset) error {
 		if err != nil {
 			return fmt.Errorf("%w: %v", ErrWrongAssetInput, err)
 		}
-		query := gountries.New()
-		_, err = query.FindCountryByName(v.Country)
+		err = validateAudienceSegment(v)
 		if err != nil {
 			return fmt.Errorf("%w: %v", ErrWrongAssetInput, err)
 		}
-		if v.Gender != MaleGenderType && v.Gender != FemaleGenderType {
-			err := errors.New("gender is not correct")
-			return fmt.Errorf("%w: %v", ErrWrongAssetInput, err)
-		}
 
 	}
 	return nil
 }
 
+// validateChartSeries checks that the series of the chart fit its kind and its X axis type.
+func validateChartSeries(chart *Chart) error {
+	c := *chart
+	c.SetDefaults()
+	if len(c.Series) > 0 && (len(c.Data.X) > 0 || len(c.Data.Y) > 0) {
+		return errors.New("data and series cannot be both set")
+	}
+	if len(c.Series) == 0 && c.XAxis != NumericAxisType {
+		return errors.New("data are only numeric, use series instead")
+	}
+	switch c.XAxis {
+	case NumericAxisType, CategoryAxisType, TimeAxisType:
+	default:
+		return errors.New("x axis type is not correct")
+	}
+	series := c.AllSeries()
+	switch c.Kind {
+	case LineChartKind:
+		if c.XAxis == CategoryAxisType {
+			return errors.New("line charts need a numeric or time x axis")
+		}
+	case BarChartKind:
+		if c.XAxis == NumericAxisType {
+			return errors.New("bar charts need a category or time x axis")
+		}
+	case ScatterChartKind:
+		if c.XAxis != NumericAxisType {
+			return errors.New("scatter charts need a numeric x axis")
+		}
+	case PieChartKind:
+		if c.XAxis != CategoryAxisType {
+			return errors.New("pie charts need a category x axis")
+		}
+		if len(series) != 1 {
+			return errors.New("pie charts have exactly one series")
+		}
+		for _, y := range series[0].Y {
+			if y < 0 {
+				return errors.New("pie charts cannot have negative values")
+			}
+		}
+	default:
+		return errors.New("chart kind is not correct")
+	}
+	names := map[string]bool{}
+	for _, s := range series {
+		if names[s.Name] {
+			return fmt.Errorf("series %s exists more than once", s.Name)
+		}
+		names[s.Name] = true
+		var xLen int
+		switch c.XAxis {
+		case NumericAxisType:
+			xLen = len(s.X)
+			if len(s.Categories) > 0 || len(s.Times) > 0 {
+				return fmt.Errorf("series %s must have only numeric x values", s.Name)
+			}
+		case CategoryAxisType:
+			xLen = len(s.Categories)
+			if len(s.X) > 0 || len(s.Times) > 0 {
+				return fmt.Errorf("series %s must have only categories", s.Name)
+			}
+		case TimeAxisType:
+			xLen = len(s.Times)
+			if len(s.X) > 0 || len(s.Categories) > 0 {
+				return fmt.Errorf("series %s must have only times", s.Name)
+			}
+		}
+		if xLen == 0 || len(s.Y) == 0 {
+			return errors.New("data are empty")
+		}
+		if xLen != len(s.Y) {
+			return errors.New("data are not equal")
+		}
+	}
+	return nil
+}
+
+// prepareNewAsset sets the defaults of a valid asset before it is added.
+func prepareNewAsset(asset *InputAsset) {
+	if asset.Visibility == "" {
+		asset.Visibility = OrganizationVisibility
+	}
+	asset.Status = DraftStatus
+	if asset.Locale == "" {
+		asset.Locale = DefaultLocale
+	}
+	if c, ok := asset.Data.(*Chart); ok {
+		c.SetDefaults()
+	}
+	if a, ok := asset.Data.(*Audience); ok {
+		normalizeAudience(a)
+	}
+}
+
 func (d *Domain) AddAsset(ctx context.Context, user *User, asset InputAsset) (*Asset, error) {
 	if user == nil {
 		return nil, ErrUnauthorized
@@ -68,11 +176,13 @@ func (d *Domain) AddAsset(ctx context.Context, user *User, asset InputAsset) (*A
 	if !user.IsAdmin {
 		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
 	}
+	prepareNewAsset(&asset)
 
-	newAsset, err := d.repo.AddAsset(ctx, asset)
+	newAsset, err := d.repo.AddAsset(ctx, scopeOf(user), asset)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
 	}
+	d.publishEvents(ctx, assetEvent(AssetCreatedEvent, user, newAsset))
 	return newAsset, nil
 }
 
@@ -87,10 +197,25 @@ func (d *Domain) UpdateAsset(ctx context.Context, user *User, assetID uint, asse
 	if !user.IsAdmin {
 		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
 	}
-	newAsset, err := d.repo.UpdateAsset(ctx, assetID, asset)
+	current, err := d.getOwnedAsset(ctx, user, assetID, assetTypeOf(&Asset{Data: asset.Data}))
+	if err != nil {
+		return nil, err
+	}
+	if contentLocked(current.Status) {
+		err := fmt.Errorf("an asset cannot be edited while it is %s, move it back to draft first", current.Status)
+		return nil, fmt.Errorf("%w: %v", ErrWrongStatusInput, err)
+	}
+	if c, ok := asset.Data.(*Chart); ok {
+		c.SetDefaults()
+	}
+	if a, ok := asset.Data.(*Audience); ok {
+		normalizeAudience(a)
+	}
+	newAsset, err := d.repo.UpdateAsset(ctx, scopeOf(user), assetID, asset)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
 	}
+	d.publishEvents(ctx, assetEvent(AssetUpdatedEvent, user, newAsset))
 	return newAsset, nil
 }
 
@@ -102,16 +227,66 @@ func (d *Domain) DeleteAsset(ctx context.Context, user *User, assetID uint, asse
 		return fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
 	}
 
-	err := d.repo.RemoveFavouriteAssetFromEveryone(ctx, assetID, assetType)
+	asset, err := d.getOwnedAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return err
+	}
+
+	err = d.repo.RemoveFavouriteAssetFromEveryone(ctx, assetID, assetType)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+
+	err = d.repo.RemoveAssetGrants(ctx, assetType, assetID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+
+	err = d.repo.RemoveAssetFromCollections(ctx, assetType, assetID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+
+	err = d.repo.RemoveAssetTags(ctx, assetType, assetID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+
+	err = d.repo.RemoveAssetLinks(ctx, assetType, assetID)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
 	}
 
-	err = d.repo.DeleteAsset(ctx, assetType, assetID)
+	err = d.repo.RemoveAssetTranslations(ctx, assetType, assetID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+
+	err = d.repo.RemoveAssetSimilarities(ctx, assetType, assetID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+
+	err = d.repo.RemoveAssetViews(ctx, assetType, assetID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+
+	err = d.repo.RemoveAssetComments(ctx, assetType, assetID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	err = d.repo.RemoveAssetFeedback(ctx, assetType, assetID)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
 	}
 
+	err = d.repo.DeleteAsset(ctx, scopeOf(user), assetType, assetID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	d.publishEvents(ctx, assetEvent(AssetDeletedEvent, user, asset))
+
 	return nil
 }
 
@@ -119,10 +294,24 @@ func (d *Domain) GetAsset(ctx context.Context, user *User, assetID uint, assetTy
 	if user == nil {
 		return nil, ErrUnauthorized
 	}
-	asset, err := d.repo.GetAsset(ctx, assetType, assetID)
+	asset, err := d.repo.GetAsset(ctx, scopeOf(user), assetType, assetID)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
 	}
+	markExpired(asset, time.Now())
+	err = d.translateAssets(ctx, assetType, []*Asset{asset})
+	if err != nil {
+		return nil, err
+	}
+	asset.Links, err = d.visibleLinks(ctx, user, assetID, assetType)
+	if err != nil {
+		return nil, err
+	}
+	asset.Related, err = d.recommend(ctx, user, []FavouriteItem{{AssetType: assetType, AssetID: assetID}}, relatedAssets)
+	if err != nil {
+		return nil, err
+	}
+	d.recordView(AssetView{UserID: user.ID, AssetType: assetType, AssetID: assetID, ViewedAt: time.Now()})
 	return asset, nil
 }
 
@@ -144,29 +333,103 @@ func (d *Domain) ListAssets(ctx context.Context, user *User, query QueryAssets,
 	}
 
 	if favQuery == nil {
-		ls, err := d.repo.ListAssets(ctx, query)
+		ls, err := d.repo.ListAssets(ctx, scopeOf(user), query)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
 		}
+		markListedExpired(ls, time.Now())
+		err = d.translateListedAssets(ctx, ls)
+		if err != nil {
+			return nil, err
+		}
 		return ls, nil
 	}
 
-	la, err := d.repo.ListFavouriteAssets(ctx, user.ID, favQuery.OnlyFav, query)
+	la, err := d.repo.ListFavouriteAssets(ctx, scopeOf(user), user.ID, favQuery.OnlyFav, query)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
 	}
-	fmt.Println(la)
+	markListedExpired(la, time.Now())
+	err = d.translateListedAssets(ctx, la)
+	if err != nil {
+		return nil, err
+	}
 	return la, nil
 }
 
-func (d *Domain) FavouriteAsset(ctx context.Context, user *User, assetID uint, assetType AssetType, isFavourite bool) error {
+// FavouriteAsset favours or unfavours the asset and reports if the favourites of the user changed.
+// Favouring is idempotent, and the assets that the user cannot see, and is not favouring, are not found.
+func (d *Domain) FavouriteAsset(ctx context.Context, user *User, assetID uint, assetType AssetType, isFavourite bool) (bool, error) {
 	if user == nil {
-		return ErrUnauthorized
+		return false, ErrUnauthorized
 	}
 
-	_, err := d.repo.FavouriteAsset(ctx, user.ID, assetID, assetType, isFavourite)
+	changed, err := d.repo.FavouriteAsset(ctx, scopeOf(user), user.ID, assetID, assetType, isFavourite)
+	if errors.Is(err, ErrAssetNotFound) {
+		return false, err
+	}
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+		return false, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
 	}
-	return nil
+	if changed && isFavourite {
+		d.publishFavourite(ctx, user, assetID, assetType)
+	}
+	return changed, nil
+}
+
+// FavouriteAssets favours or unfavours the assets of the batch in a single transaction. The assets that fail,
+// like the assets that the user cannot see, are reported in their result and do not stop the others.
+func (d *Domain) FavouriteAssets(ctx context.Context, user *User, batch FavouriteBatch) ([]FavouriteResult, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(batch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongFavourInput, err)
+	}
+	seen := map[FavouriteItem]bool{}
+	for _, v := range batch.Items {
+		if seen[v] {
+			err := fmt.Errorf("asset %s %d exists more than once", v.AssetType, v.AssetID)
+			return nil, fmt.Errorf("%w: %v", ErrWrongFavourInput, err)
+		}
+		seen[v] = true
+		switch v.AssetType {
+		case InsightAssetType, ChartAssetType, AudienceAssetType:
+		default:
+			err := fmt.Errorf("asset type %s is not correct", v.AssetType)
+			return nil, fmt.Errorf("%w: %v", ErrWrongFavourInput, err)
+		}
+	}
+	results, err := d.repo.FavouriteAssets(ctx, scopeOf(user), user.ID, batch.Items, batch.Favourite)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	for _, v := range results {
+		if v.Changed && batch.Favourite {
+			d.publishFavourite(ctx, user, v.AssetID, v.AssetType)
+		}
+	}
+	return results, nil
+}
+
+// SetFavouriteNote keeps a note on an asset that the user has already favoured.
+// An empty text removes the note.
+func (d *Domain) SetFavouriteNote(ctx context.Context, user *User, assetID uint, assetType AssetType, note FavouriteNote) (*FavouriteNote, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(note)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongNoteInput, err)
+	}
+	note.UpdatedAt = time.Now()
+	found, err := d.repo.SetFavouriteNote(ctx, user.ID, assetID, assetType, note)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %v", ErrFavouriteNotFound, errors.New("favour the asset before adding a note"))
+	}
+	return &note, nil
 }
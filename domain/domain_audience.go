@@ -0,0 +1,247 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const maxComparedAudiences = 10
+
+// CompareAudiences compares the audiences with the IDs dimension by dimension and finds the segment of their
+// common users. The countries of the regions count as countries of the audience, and an empty list of
+// interests or devices matches all of them.
+func (d *Domain) CompareAudiences(ctx context.Context, user *User, audienceIDs []uint) (*AudienceComparison, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if len(audienceIDs) < 2 || len(audienceIDs) > maxComparedAudiences {
+		err := fmt.Errorf("compare from 2 to %d audiences", maxComparedAudiences)
+		return nil, fmt.Errorf("%w: %v", ErrWrongQueryInput, err)
+	}
+	seen := map[uint]bool{}
+	for _, id := range audienceIDs {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: %v", ErrWrongQueryInput, fmt.Errorf("audience %d exists more than once", id))
+		}
+		seen[id] = true
+	}
+	audiences := []*Audience{}
+	for _, id := range audienceIDs {
+		asset, err := d.repo.GetAsset(ctx, scopeOf(user), AudienceAssetType, id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrAssetNotFound, err)
+		}
+		audience, ok := asset.Data.(*Audience)
+		if !ok {
+			return nil, fmt.Errorf("%w: %v", ErrAssetNotFound, errors.New("the asset is not an audience"))
+		}
+		audiences = append(audiences, audience)
+	}
+	return compareAudiences(audienceIDs, audiences), nil
+}
+
+// compareAudiences compares the audiences dimension by dimension, the IDs are only kept in the comparison.
+func compareAudiences(audienceIDs []uint, audiences []*Audience) *AudienceComparison {
+	cmp := &AudienceComparison{AudienceIDs: audienceIDs}
+	intersection := &Audience{Description: fmt.Sprintf("Common users of the audiences %s", joinIDs(audienceIDs))}
+	empty := false
+
+	ages := []IntRange{}
+	hours := []IntRange{}
+	purchases := []IntRange{}
+	genders := [][]string{}
+	countries := [][]string{}
+	interests := [][]string{}
+	devices := [][]string{}
+	for _, a := range audiences {
+		ages = append(ages, IntRange{Min: a.AgeMin, Max: a.AgeMax})
+		hours = append(hours, a.HoursSpentBounds())
+		purchases = append(purchases, a.NumberOfPurchasesBounds())
+		gs := []string{}
+		for _, g := range a.AllGenders() {
+			gs = append(gs, string(g))
+		}
+		genders = append(genders, gs)
+		countries = append(countries, audienceCountryCodes(a))
+		interests = append(interests, a.Interests)
+		ds := []string{}
+		for _, v := range a.Devices {
+			ds = append(ds, string(v))
+		}
+		devices = append(devices, ds)
+	}
+
+	age := compareRanges("age", ages)
+	if age.CommonRange == nil {
+		empty = true
+	} else {
+		intersection.AgeMin = age.CommonRange.Min
+		intersection.AgeMax = age.CommonRange.Max
+	}
+	gender := compareValues("genders", genders, nil)
+	for _, g := range gender.Common {
+		intersection.Genders = append(intersection.Genders, GenderType(g))
+	}
+	country := compareValues("countries", countries, nil)
+	intersection.Countries = country.Common
+	hour := compareRanges("hoursSpent", hours)
+	intersection.HoursSpentRange = hour.CommonRange
+	purchase := compareRanges("numberOfPurchases", purchases)
+	intersection.NumberOfPurchasesRange = purchase.CommonRange
+	interest := compareValues("interests", interests, nil)
+	intersection.Interests = interest.Common
+	allDevices := []string{string(MobileDeviceType), string(DesktopDeviceType), string(TabletDeviceType), string(TVDeviceType)}
+	device := compareValues("devices", devices, allDevices)
+	for _, v := range device.Common {
+		intersection.Devices = append(intersection.Devices, DeviceType(v))
+	}
+	if len(gender.Common) == 0 || len(country.Common) == 0 || hour.CommonRange == nil || purchase.CommonRange == nil {
+		empty = true
+	}
+	if !allEmpty(interests) && len(interest.Common) == 0 {
+		empty = true
+	}
+	if !allEmpty(devices) && len(device.Common) == 0 {
+		empty = true
+	}
+	if allEmpty(devices) {
+		intersection.Devices = nil
+	}
+
+	cmp.Dimensions = []AudienceDimensionDiff{age, gender, country, hour, purchase, interest, device}
+	for _, v := range cmp.Dimensions {
+		cmp.Similarity += v.Similarity
+	}
+	cmp.Similarity /= float64(len(cmp.Dimensions))
+	if !empty {
+		cmp.Intersection = intersection
+	}
+	return cmp
+}
+
+func joinIDs(ids []uint) string {
+	s := []string{}
+	for _, id := range ids {
+		s = append(s, fmt.Sprint(id))
+	}
+	return strings.Join(s, ", ")
+}
+
+// audienceCountryCodes returns the sorted codes of the countries of the audience and of its regions.
+func audienceCountryCodes(a *Audience) []string {
+	countries()
+	codes := map[string]bool{}
+	for _, c := range a.AllCountries() {
+		codes[c] = true
+	}
+	for _, r := range a.Regions {
+		for _, c := range regionCountries[r] {
+			codes[c] = true
+		}
+	}
+	return sortedKeys(codes)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func allEmpty(values [][]string) bool {
+	for _, v := range values {
+		if len(v) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// compareValues compares lists of values. An empty list matches every value of all, or when all is nil,
+// every value of the other lists.
+func compareValues(dimension string, values [][]string, all []string) AudienceDimensionDiff {
+	if all == nil {
+		union := map[string]bool{}
+		for _, vs := range values {
+			for _, v := range vs {
+				union[v] = true
+			}
+		}
+		all = sortedKeys(union)
+	}
+	diff := AudienceDimensionDiff{Dimension: dimension, Similarity: 1}
+	sets := []map[string]bool{}
+	for _, vs := range values {
+		if len(vs) == 0 {
+			vs = all
+		}
+		set := map[string]bool{}
+		for _, v := range vs {
+			set[v] = true
+		}
+		sets = append(sets, set)
+	}
+	common := map[string]bool{}
+	for v := range sets[0] {
+		inAll := true
+		for _, set := range sets[1:] {
+			inAll = inAll && set[v]
+		}
+		if inAll {
+			common[v] = true
+		}
+	}
+	diff.Common = sortedKeys(common)
+	for _, vs := range values {
+		only := map[string]bool{}
+		for _, v := range vs {
+			if !common[v] {
+				only[v] = true
+			}
+		}
+		diff.Only = append(diff.Only, sortedKeys(only))
+	}
+	members := map[string]bool{}
+	for _, set := range sets {
+		for v := range set {
+			members[v] = true
+		}
+	}
+	if len(members) > 0 {
+		diff.Similarity = float64(len(common)) / float64(len(members))
+	}
+	return diff
+}
+
+// compareRanges compares inclusive ranges, their similarity is the length of their overlap over the length
+// of the range that covers all of them.
+func compareRanges(dimension string, ranges []IntRange) AudienceDimensionDiff {
+	diff := AudienceDimensionDiff{Dimension: dimension, Ranges: ranges}
+	overlap := ranges[0]
+	hull := ranges[0]
+	for _, r := range ranges[1:] {
+		if r.Min > overlap.Min {
+			overlap.Min = r.Min
+		}
+		if r.Max < overlap.Max {
+			overlap.Max = r.Max
+		}
+		if r.Min < hull.Min {
+			hull.Min = r.Min
+		}
+		if r.Max > hull.Max {
+			hull.Max = r.Max
+		}
+	}
+	if overlap.Min <= overlap.Max {
+		diff.CommonRange = &overlap
+		diff.Similarity = float64(overlap.Max-overlap.Min+1) / float64(hull.Max-hull.Min+1)
+	}
+	return diff
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// AnalyzeChart computes the statistics of every series of a stored chart and the requested transformations.
+// Regression and correlation are only computed for numeric and time X axes.
+// GetChartToRender returns a chart that the user sees, translated to the languages of the context, to draw it.
+// Unlike the asset detail it does not record a view nor read the links and the related assets, as the images
+// of the charts are fetched again and again by the pages that embed them.
+func (d *Domain) GetChartToRender(ctx context.Context, user *User, chartID uint) (*Asset, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	asset, err := d.repo.GetAsset(ctx, scopeOf(user), ChartAssetType, chartID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAssetNotFound, err)
+	}
+	if _, ok := asset.Data.(*Chart); !ok {
+		return nil, fmt.Errorf("%w: %v", ErrAssetNotFound, errors.New("the asset is not a chart"))
+	}
+	err = d.translateAssets(ctx, ChartAssetType, []*Asset{asset})
+	if err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+func (d *Domain) AnalyzeChart(ctx context.Context, user *User, chartID uint, query ChartAnalyticsQuery) (*ChartAnalytics, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongQueryInput, err)
+	}
+	asset, err := d.repo.GetAsset(ctx, scopeOf(user), ChartAssetType, chartID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAssetNotFound, err)
+	}
+	chart, ok := asset.Data.(*Chart)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrAssetNotFound, errors.New("the asset is not a chart"))
+	}
+	c := *chart
+	c.SetDefaults()
+	if query.Resample > 0 && c.XAxis == CategoryAxisType {
+		err := errors.New("only numeric and time axes can be resampled")
+		return nil, fmt.Errorf("%w: %v", ErrWrongQueryInput, err)
+	}
+
+	analytics := &ChartAnalytics{
+		ChartID: asset.ID,
+		Version: asset.Version,
+		Kind:    c.Kind,
+		XAxis:   c.XAxis,
+		Series:  []SeriesAnalytics{},
+	}
+	for _, s := range c.AllSeries() {
+		points := newSeriesPoints(c.XAxis, s)
+		if len(points.y) == 0 {
+			continue
+		}
+		sa := SeriesAnalytics{
+			Name:   s.Name,
+			Count:  len(points.y),
+			Min:    points.y[0],
+			Max:    points.y[0],
+			Mean:   mean(points.y),
+			Median: median(points.y),
+		}
+		for _, y := range points.y {
+			if y < sa.Min {
+				sa.Min = y
+			}
+			if y > sa.Max {
+				sa.Max = y
+			}
+		}
+		if c.XAxis != CategoryAxisType {
+			sa.Regression, sa.Correlation = linearFit(points.x, points.y)
+			points = points.sorted()
+		}
+		if query.MovingAverage > 0 {
+			ma := downsample(movingAverage(points, query.MovingAverage), query.MaxPoints).toSeries(s.Name)
+			sa.MovingAverage = &ma
+		}
+		if query.Resample > 0 {
+			rs := downsample(resample(points, query.Resample), query.MaxPoints).toSeries(s.Name)
+			sa.Resampled = &rs
+		}
+		if query.MaxPoints > 0 && len(points.y) > query.MaxPoints {
+			ds := downsample(points, query.MaxPoints).toSeries(s.Name)
+			sa.Downsampled = &ds
+		}
+		analytics.Series = append(analytics.Series, sa)
+	}
+	return analytics, nil
+}
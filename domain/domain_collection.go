@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+func (d *Domain) getUserCollection(ctx context.Context, user *User, collectionID uint) (*Collection, error) {
+	collection, err := d.repo.GetCollection(ctx, user.ID, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCollectionNotFound, err)
+	}
+	return collection, nil
+}
+
+func (d *Domain) CreateCollection(ctx context.Context, user *User, collection Collection) (*Collection, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(collection)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongCollectionInput, err)
+	}
+	newCollection, err := d.repo.AddCollection(ctx, user.ID, collection)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return newCollection, nil
+}
+
+func (d *Domain) RenameCollection(ctx context.Context, user *User, collectionID uint, collection Collection) (*Collection, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(collection)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongCollectionInput, err)
+	}
+	_, err = d.getUserCollection(ctx, user, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	newCollection, err := d.repo.UpdateCollection(ctx, user.ID, collectionID, collection)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return newCollection, nil
+}
+
+func (d *Domain) DeleteCollection(ctx context.Context, user *User, collectionID uint) error {
+	if user == nil {
+		return ErrUnauthorized
+	}
+	_, err := d.getUserCollection(ctx, user, collectionID)
+	if err != nil {
+		return err
+	}
+	err = d.repo.DeleteCollection(ctx, user.ID, collectionID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return nil
+}
+
+func (d *Domain) ListCollections(ctx context.Context, user *User) ([]Collection, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	collections, err := d.repo.ListCollections(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return collections, nil
+}
+
+func (d *Domain) AddCollectionItem(ctx context.Context, user *User, collectionID uint, item CollectionItem) (*CollectionItem, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(item)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongCollectionInput, err)
+	}
+	_, err = d.getUserCollection(ctx, user, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	asset, err := d.repo.GetAsset(ctx, scopeOf(user), item.AssetType, item.AssetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAssetNotFound, err)
+	}
+	newItem, err := d.repo.AddCollectionItem(ctx, collectionID, item)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	newItem.Asset = asset
+	return newItem, nil
+}
+
+func (d *Domain) RemoveCollectionItem(ctx context.Context, user *User, collectionID, itemID uint) error {
+	if user == nil {
+		return ErrUnauthorized
+	}
+	_, err := d.getUserCollection(ctx, user, collectionID)
+	if err != nil {
+		return err
+	}
+	err = d.repo.RemoveCollectionItem(ctx, collectionID, itemID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return nil
+}
+
+// ReorderCollection moves the given items to the top of the collection in the given order.
+// The items that are not given keep their relative order after them.
+func (d *Domain) ReorderCollection(ctx context.Context, user *User, collectionID uint, itemIDs []uint) error {
+	if user == nil {
+		return ErrUnauthorized
+	}
+	if len(itemIDs) == 0 {
+		return fmt.Errorf("%w: %v", ErrWrongCollectionInput, errors.New("no items to order"))
+	}
+	seen := map[uint]bool{}
+	for _, id := range itemIDs {
+		if seen[id] {
+			return fmt.Errorf("%w: %v", ErrWrongCollectionInput, fmt.Errorf("item %d exists more than once", id))
+		}
+		seen[id] = true
+	}
+	_, err := d.getUserCollection(ctx, user, collectionID)
+	if err != nil {
+		return err
+	}
+	err = d.repo.ReorderCollectionItems(ctx, collectionID, itemIDs)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return nil
+}
+
+func (d *Domain) ListCollectionItems(ctx context.Context, user *User, collectionID uint, query QueryCollectionItems) (*ListedCollectionItems, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongQueryInput, err)
+	}
+	_, err = d.getUserCollection(ctx, user, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	ls, err := d.repo.ListCollectionItems(ctx, collectionID, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	// the items stay in the collection even when their assets are not visible anymore,
+	// but their data are not returned
+	for i, v := range ls.Items {
+		asset, err := d.repo.GetAsset(ctx, scopeOf(user), v.AssetType, v.AssetID)
+		if err != nil {
+			continue
+		}
+		err = d.translateAssets(ctx, v.AssetType, []*Asset{asset})
+		if err != nil {
+			return nil, err
+		}
+		ls.Items[i].Asset = asset
+	}
+	return ls, nil
+}
@@ -0,0 +1,257 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxMentions is the number of users that a comment can mention
+const maxMentions = 10
+
+// mentionPattern finds the usernames after @ at the start of the text or after a space.
+var mentionPattern = regexp.MustCompile(`(?:^|\s)@([\p{L}\p{N}_.\-]+)`)
+
+// mentionedUsers returns the IDs of the users of the organization that the text mentions.
+// The unknown usernames and the users of other organizations are ignored, so that comments do not reveal them.
+// Every user belongs to the default organization with ID 0.
+func (d *Domain) mentionedUsers(ctx context.Context, orgID uint, text string) ([]uint, error) {
+	ids := []uint{}
+	seen := map[string]bool{}
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		username := strings.TrimRight(m[1], ".-")
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		if len(seen) > maxMentions {
+			return nil, fmt.Errorf("%w: %v", ErrWrongCommentInput, fmt.Errorf("more than %d users are mentioned", maxMentions))
+		}
+		u, err := d.repo.FindUser(ctx, username)
+		if err != nil {
+			continue
+		}
+		if orgID != 0 {
+			isMember, err := d.repo.IsOrganizationMember(ctx, orgID, u.ID)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+			}
+			if !isMember {
+				continue
+			}
+		}
+		ids = append(ids, u.ID)
+	}
+	return ids, nil
+}
+
+// canModerate tells if the user moderates the comments of the asset, as an administrator of its organization.
+func canModerate(user *User, asset *Asset) bool {
+	return user.IsAdmin && asset.OrgID == user.OrgID
+}
+
+// hideModerated removes the text of the hidden comments for the users that do not moderate them.
+func hideModerated(comments []Comment, moderator bool) {
+	if moderator {
+		return
+	}
+	for i := range comments {
+		if comments[i].Hidden {
+			comments[i].Text = ""
+			comments[i].Mentions = nil
+		}
+	}
+}
+
+func (d *Domain) getVisibleAsset(ctx context.Context, user *User, assetID uint, assetType AssetType) (*Asset, error) {
+	asset, err := d.repo.GetAsset(ctx, scopeOf(user), assetType, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAssetNotFound, err)
+	}
+	return asset, nil
+}
+
+func (d *Domain) getAssetComment(ctx context.Context, assetID uint, assetType AssetType, commentID uint) (*Comment, error) {
+	comment, err := d.repo.GetComment(ctx, assetType, assetID, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCommentNotFound, err)
+	}
+	return comment, nil
+}
+
+// AddComment adds a comment of the user on an asset that the user sees, or a reply to one of its comments.
+func (d *Domain) AddComment(ctx context.Context, user *User, assetID uint, assetType AssetType, input CommentInput) (*Comment, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongCommentInput, err)
+	}
+	asset, err := d.getVisibleAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return nil, err
+	}
+	if input.ParentID != 0 {
+		_, err = d.getAssetComment(ctx, assetID, assetType, input.ParentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	mentions, err := d.mentionedUsers(ctx, asset.OrgID, input.Text)
+	if err != nil {
+		return nil, err
+	}
+	comment := Comment{
+		AssetType: assetType,
+		AssetID:   assetID,
+		ParentID:  input.ParentID,
+		AuthorID:  user.ID,
+		Text:      input.Text,
+	}
+	newComment, err := d.repo.AddComment(ctx, comment, mentions)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return newComment, nil
+}
+
+// EditComment replaces the text of a comment of the user, and the users that it mentions.
+func (d *Domain) EditComment(ctx context.Context, user *User, assetID uint, assetType AssetType, commentID uint, input CommentInput) (*Comment, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongCommentInput, err)
+	}
+	asset, err := d.getVisibleAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := d.getAssetComment(ctx, assetID, assetType, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != user.ID {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only the author edits a comment"))
+	}
+	if comment.Deleted {
+		return nil, fmt.Errorf("%w: %v", ErrWrongCommentInput, errors.New("the comment is deleted"))
+	}
+	mentions, err := d.mentionedUsers(ctx, asset.OrgID, input.Text)
+	if err != nil {
+		return nil, err
+	}
+	newComment, err := d.repo.UpdateComment(ctx, commentID, input.Text, mentions)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return newComment, nil
+}
+
+// DeleteComment removes the text of a comment, which stays in its thread with its replies.
+// The author deletes a comment, and the administrators of the organization of the asset delete any comment.
+func (d *Domain) DeleteComment(ctx context.Context, user *User, assetID uint, assetType AssetType, commentID uint) error {
+	if user == nil {
+		return ErrUnauthorized
+	}
+	asset, err := d.getVisibleAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return err
+	}
+	comment, err := d.getAssetComment(ctx, assetID, assetType, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != user.ID && !canModerate(user, asset) {
+		return fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only the author or an administrator deletes a comment"))
+	}
+	err = d.repo.RemoveComment(ctx, commentID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return nil
+}
+
+// ModerateComment hides a comment on an asset of the active organization of the administrator, or shows it again.
+func (d *Domain) ModerateComment(ctx context.Context, user *User, assetID uint, assetType AssetType, commentID uint, moderation CommentModeration) (*Comment, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	_, err := d.getOwnedAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return nil, err
+	}
+	_, err = d.getAssetComment(ctx, assetID, assetType, commentID)
+	if err != nil {
+		return nil, err
+	}
+	err = d.repo.SetCommentHidden(ctx, commentID, moderation.Hidden)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return d.getAssetComment(ctx, assetID, assetType, commentID)
+}
+
+// ListComments lists a thread of the comments of an asset that the user sees, the comments without parent
+// or the replies to a comment.
+func (d *Domain) ListComments(ctx context.Context, user *User, assetID uint, assetType AssetType, query QueryComments) (*ListedComments, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongQueryInput, err)
+	}
+	asset, err := d.getVisibleAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return nil, err
+	}
+	if query.ParentID != 0 {
+		_, err = d.getAssetComment(ctx, assetID, assetType, query.ParentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	ls, err := d.repo.ListComments(ctx, assetType, assetID, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	hideModerated(ls.Comments, canModerate(user, asset))
+	return ls, nil
+}
+
+// ListMentions lists the comments that mention the user from the oldest one, on the assets that the user sees.
+// The pages are counted before the comments on the other assets are removed, so a page can have less comments
+// than its limit.
+func (d *Domain) ListMentions(ctx context.Context, user *User, query QueryComments) (*ListedComments, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongQueryInput, err)
+	}
+	ls, err := d.repo.ListMentions(ctx, user.ID, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	visible := []Comment{}
+	for _, v := range ls.Comments {
+		asset, err := d.repo.GetAsset(ctx, scopeOf(user), v.AssetType, v.AssetID)
+		if err != nil {
+			continue
+		}
+		comments := []Comment{v}
+		hideModerated(comments, canModerate(user, asset))
+		visible = append(visible, comments...)
+	}
+	ls.Comments = visible
+	return ls, nil
+}
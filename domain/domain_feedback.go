@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const defaultFlaggedLimit = 20
+
+// RateAsset sets the rating of the user on an asset that the user sees, replacing the previous one,
+// and returns the feedback of the asset with it.
+func (d *Domain) RateAsset(ctx context.Context, user *User, assetID uint, assetType AssetType, input RatingInput) (*AssetFeedback, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongFeedbackInput, err)
+	}
+	_, err = d.getVisibleAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return nil, err
+	}
+	feedback, err := d.repo.RateAsset(ctx, user.ID, assetType, assetID, input.Rating)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return feedback, nil
+}
+
+// UnrateAsset removes the rating of the user on an asset, also when the user does not see it anymore.
+func (d *Domain) UnrateAsset(ctx context.Context, user *User, assetID uint, assetType AssetType) (*AssetFeedback, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	feedback, err := d.repo.UnrateAsset(ctx, user.ID, assetType, assetID)
+	if err != nil {
+		if errors.Is(err, ErrAssetNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return feedback, nil
+}
+
+// FlagAsset flags an asset that the user sees as outdated or incorrect. A user has a single open flag
+// for each reason, so flagging again returns the open flag.
+func (d *Domain) FlagAsset(ctx context.Context, user *User, assetID uint, assetType AssetType, input FlagInput) (*AssetFlag, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongFeedbackInput, err)
+	}
+	_, err = d.getVisibleAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return nil, err
+	}
+	flag, err := d.repo.FlagAsset(ctx, AssetFlag{
+		AssetType: assetType,
+		AssetID:   assetID,
+		UserID:    user.ID,
+		Reason:    input.Reason,
+		Comment:   input.Comment,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return flag, nil
+}
+
+// FlaggedAssets is the queue of the assets with open flags of the organization of the administrator,
+// the assets with the most open flags first and then the assets flagged first.
+func (d *Domain) FlaggedAssets(ctx context.Context, user *User, query QueryFlaggedAssets) ([]FlaggedAsset, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	err := d.validate.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongQueryInput, err)
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultFlaggedLimit
+	}
+	flagged, err := d.repo.ListFlaggedAssets(ctx, user.OrgID, query.Type, query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	for i := range flagged {
+		flagged[i].Flags, err = d.repo.ListOpenFlags(ctx, flagged[i].AssetType, flagged[i].AssetID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+		}
+	}
+	return flagged, nil
+}
+
+// ResolveFlags resolves the open flags of an asset of the organization of the administrator
+// and returns their number.
+func (d *Domain) ResolveFlags(ctx context.Context, user *User, assetID uint, assetType AssetType, resolution FlagResolution) (int64, error) {
+	if user == nil {
+		return 0, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return 0, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	err := d.validate.Struct(resolution)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrWrongFeedbackInput, err)
+	}
+	_, err = d.getOwnedAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return 0, err
+	}
+	n, err := d.repo.ResolveFlags(ctx, assetType, assetID, resolution.Reason, user.ID)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return n, nil
+}
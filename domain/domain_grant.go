@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// getOwnedAsset returns the asset only when it belongs to the active organization of the user,
+// because public assets of other organizations are readable but not writable.
+func (d *Domain) getOwnedAsset(ctx context.Context, user *User, assetID uint, assetType AssetType) (*Asset, error) {
+	asset, err := d.repo.GetAsset(ctx, scopeOf(user), assetType, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAssetNotFound, err)
+	}
+	if asset.OrgID != user.OrgID {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("the asset belongs to another organization"))
+	}
+	return asset, nil
+}
+
+// getOrganizationUser returns the user only when it is a member of the organization, because the grants
+// and the groups of an organization only take effect for its members. Every user belongs to the default organization.
+func (d *Domain) getOrganizationUser(ctx context.Context, orgID, userID uint) (*User, error) {
+	u, err := d.repo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUserNotFound, err)
+	}
+	if orgID == 0 {
+		return u, nil
+	}
+	isMember, err := d.repo.IsOrganizationMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	if !isMember {
+		return nil, fmt.Errorf("%w: %v", ErrUserNotFound, errors.New("the user is not a member of the organization"))
+	}
+	return u, nil
+}
+
+func (d *Domain) CreateGroup(ctx context.Context, user *User, group Group) (*Group, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	err := d.validate.Struct(group)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongGroupInput, err)
+	}
+	group.OrgID = user.OrgID
+	newGroup, err := d.repo.AddGroup(ctx, group)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return newGroup, nil
+}
+
+func (d *Domain) AddGroupMember(ctx context.Context, user *User, groupID, userID uint) error {
+	if user == nil {
+		return ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	group, err := d.repo.GetGroup(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrGroupNotFound, err)
+	}
+	if group.OrgID != user.OrgID {
+		return fmt.Errorf("%w: %v", ErrGroupNotFound, errors.New("the group belongs to another organization"))
+	}
+	_, err = d.getOrganizationUser(ctx, user.OrgID, userID)
+	if err != nil {
+		return err
+	}
+	err = d.repo.AddGroupMember(ctx, groupID, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return nil
+}
+
+func (d *Domain) GrantAsset(ctx context.Context, user *User, assetID uint, assetType AssetType, grant AssetGrant) (*AssetGrant, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	if (grant.UserID == 0) == (grant.GroupID == 0) {
+		err := errors.New("grant either a user or a group")
+		return nil, fmt.Errorf("%w: %v", ErrWrongGrantInput, err)
+	}
+	_, err := d.getOwnedAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return nil, err
+	}
+	if grant.UserID != 0 {
+		_, err = d.getOrganizationUser(ctx, user.OrgID, grant.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if grant.GroupID != 0 {
+		group, err := d.repo.GetGroup(ctx, grant.GroupID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrGroupNotFound, err)
+		}
+		if group.OrgID != user.OrgID {
+			return nil, fmt.Errorf("%w: %v", ErrGroupNotFound, errors.New("the group belongs to another organization"))
+		}
+	}
+	newGrant, err := d.repo.AddAssetGrant(ctx, assetType, assetID, grant)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return newGrant, nil
+}
+
+func (d *Domain) RevokeAssetGrant(ctx context.Context, user *User, assetID uint, assetType AssetType, grantID uint) error {
+	if user == nil {
+		return ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	_, err := d.getOwnedAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return err
+	}
+	err = d.repo.RemoveAssetGrant(ctx, assetType, assetID, grantID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return nil
+}
+
+func (d *Domain) ListAssetGrants(ctx context.Context, user *User, assetID uint, assetType AssetType) ([]AssetGrant, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	_, err := d.getOwnedAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := d.repo.ListAssetGrants(ctx, assetType, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return grants, nil
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// linkRules gives the asset types that each relation is allowed to link from and to.
+var linkRules = map[RelationType][2]AssetType{
+	SupportedByRelation: {InsightAssetType, ChartAssetType},
+	AboutRelation:       {InsightAssetType, AudienceAssetType},
+}
+
+// visibleLinks returns the links of the asset in both directions,
+// without the ones whose other asset is not visible to the user.
+func (d *Domain) visibleLinks(ctx context.Context, user *User, assetID uint, assetType AssetType) ([]AssetLink, error) {
+	links, err := d.repo.ListAssetLinks(ctx, assetType, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	visible := []AssetLink{}
+	for _, v := range links {
+		otherType, otherID := v.ToType, v.ToID
+		if v.ToType == assetType && v.ToID == assetID {
+			otherType, otherID = v.FromType, v.FromID
+		}
+		_, err := d.repo.GetAsset(ctx, scopeOf(user), otherType, otherID)
+		if err == nil {
+			visible = append(visible, v)
+		}
+	}
+	return visible, nil
+}
+
+// LinkAssets links an asset of the active organization of the user to another visible asset.
+func (d *Domain) LinkAssets(ctx context.Context, user *User, assetID uint, assetType AssetType, link AssetLink) (*AssetLink, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	err := d.validate.Struct(link)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongLinkInput, err)
+	}
+	rule, ok := linkRules[link.Relation]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrWrongLinkInput, errors.New("relation is not correct"))
+	}
+	if rule[0] != assetType || rule[1] != link.ToType {
+		err := fmt.Errorf("relation %s links %s to %s", link.Relation, rule[0], rule[1])
+		return nil, fmt.Errorf("%w: %v", ErrWrongLinkInput, err)
+	}
+	_, err = d.getOwnedAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return nil, err
+	}
+	_, err = d.repo.GetAsset(ctx, scopeOf(user), link.ToType, link.ToID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAssetNotFound, err)
+	}
+	link.FromType = assetType
+	link.FromID = assetID
+	newLink, err := d.repo.AddAssetLink(ctx, link)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return newLink, nil
+}
+
+func (d *Domain) UnlinkAssets(ctx context.Context, user *User, assetID uint, assetType AssetType, linkID uint) error {
+	if user == nil {
+		return ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	_, err := d.getOwnedAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return err
+	}
+	err = d.repo.RemoveAssetLink(ctx, assetType, assetID, linkID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return nil
+}
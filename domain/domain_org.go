@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+func (d *Domain) CreateOrganization(ctx context.Context, user *User, org Organization) (*Organization, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	err := d.validate.Struct(org)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongOrganizationInput, err)
+	}
+	newOrg, err := d.repo.AddOrganization(ctx, org)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	// The creator becomes the first administrator of the organization, who can add its members.
+	err = d.repo.AddOrganizationMember(ctx, newOrg.ID, user.ID, true)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return newOrg, nil
+}
+
+// AddOrganizationMember adds a user to an organization. Only the administrators of that organization
+// are authorized, whatever organization is active, so that the administrators of one organization cannot join another one.
+func (d *Domain) AddOrganizationMember(ctx context.Context, user *User, orgID uint, member OrganizationMember) error {
+	if user == nil {
+		return ErrUnauthorized
+	}
+	_, err := d.repo.GetOrganization(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrOrganizationNotFound, err)
+	}
+	isOrgAdmin, err := d.repo.IsOrganizationAdmin(ctx, orgID, user.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	if !isOrgAdmin {
+		return fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators of the organization are authorized"))
+	}
+	_, err = d.repo.GetUser(ctx, member.UserID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUserNotFound, err)
+	}
+	exists, err := d.repo.IsOrganizationMember(ctx, orgID, member.UserID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	if exists {
+		return nil
+	}
+	err = d.repo.AddOrganizationMember(ctx, orgID, member.UserID, member.IsAdmin)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return nil
+}
+
+func (d *Domain) ListUserOrganizations(ctx context.Context, user *User) ([]Organization, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	orgs, err := d.repo.ListUserOrganizations(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return orgs, nil
+}
@@ -0,0 +1,314 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// similarNeighbours is the number of the most similar assets that are kept for every asset
+	similarNeighbours = 20
+	// minAudienceSimilarity is the similarity of the segments from which two audiences are related
+	minAudienceSimilarity = 0.5
+	// audienceSimilarityWeight weighs the similarity of the segments against the users that favour both audiences
+	audienceSimilarityWeight = 0.5
+	defaultRecommendations   = 10
+	// relatedAssets is the number of the related assets on the asset detail
+	relatedAssets = 5
+	// maxCooccurringFavourites is the number of the latest favourites of a user that are paired with each other,
+	// so that a user with many favourites does not add the square of them
+	maxCooccurringFavourites = 100
+	// maxRecommendCandidates is the number of the most similar assets that are read to recommend the visible ones
+	maxRecommendCandidates = 200
+	// maxAudienceComparisons bounds the pairs of audiences that a refresh compares, whatever the number of audiences
+	maxAudienceComparisons = 200000
+)
+
+// assetPair is an asset and another asset that is related to it.
+type assetPair struct {
+	asset   FavouriteItem
+	related FavouriteItem
+}
+
+// computeSimilarities finds how similar the assets are from the users that favour them, as the cosine similarity
+// of the users of every pair of assets, and adds the similarity of the segments of the audiences. Only the most
+// similar assets of every asset are kept.
+func computeSimilarities(favourites []UserFavourite, audiences []Asset) []AssetSimilarity {
+	byUser := map[uint][]FavouriteItem{}
+	users := map[FavouriteItem]int{}
+	for _, v := range favourites {
+		byUser[v.UserID] = append(byUser[v.UserID], v.FavouriteItem)
+		users[v.FavouriteItem]++
+	}
+	common := map[assetPair]int{}
+	for _, items := range byUser {
+		if len(items) > maxCooccurringFavourites {
+			items = items[len(items)-maxCooccurringFavourites:]
+		}
+		for i := range items {
+			for j := range items {
+				if i != j {
+					common[assetPair{items[i], items[j]}]++
+				}
+			}
+		}
+	}
+	scores := map[assetPair]float64{}
+	for p, n := range common {
+		scores[p] = float64(n) / math.Sqrt(float64(users[p.asset]*users[p.related]))
+	}
+	addAudienceSimilarities(scores, audiences, maxAudienceComparisons)
+
+	byAsset := map[FavouriteItem][]AssetSimilarity{}
+	for p, score := range scores {
+		byAsset[p.asset] = append(byAsset[p.asset], AssetSimilarity{
+			AssetType:   p.asset.AssetType,
+			AssetID:     p.asset.AssetID,
+			RelatedType: p.related.AssetType,
+			RelatedID:   p.related.AssetID,
+			Score:       score,
+		})
+	}
+	assets := []FavouriteItem{}
+	for k := range byAsset {
+		assets = append(assets, k)
+	}
+	sort.Slice(assets, func(i, j int) bool {
+		return lessItem(assets[i], assets[j])
+	})
+	similarities := []AssetSimilarity{}
+	for _, k := range assets {
+		related := byAsset[k]
+		sort.Slice(related, func(i, j int) bool {
+			if related[i].Score != related[j].Score {
+				return related[i].Score > related[j].Score
+			}
+			a := FavouriteItem{AssetType: related[i].RelatedType, AssetID: related[i].RelatedID}
+			b := FavouriteItem{AssetType: related[j].RelatedType, AssetID: related[j].RelatedID}
+			return lessItem(a, b)
+		})
+		if len(related) > similarNeighbours {
+			related = related[:similarNeighbours]
+		}
+		similarities = append(similarities, related...)
+	}
+	return similarities
+}
+
+// segment is an audience with its countries, to find the audiences that can be similar to it cheaply.
+type segment struct {
+	id        uint
+	audience  *Audience
+	countries map[string]bool
+}
+
+// overlaps tells if the audiences share a country, an audience without countries shares all of them.
+func (s segment) overlaps(other segment) bool {
+	if len(s.countries) == 0 || len(other.countries) == 0 {
+		return true
+	}
+	for c := range s.countries {
+		if other.countries[c] {
+			return true
+		}
+	}
+	return false
+}
+
+// addAudienceSimilarities adds the similarity of the segments of the audiences of the same organization to the scores.
+// Only the audiences with common ages and countries are compared, and at most limit pairs of them.
+func addAudienceSimilarities(scores map[assetPair]float64, audiences []Asset, limit int) {
+	byOrg := map[uint][]segment{}
+	orgs := []uint{}
+	for i := range audiences {
+		a, ok := audiences[i].Data.(*Audience)
+		if !ok {
+			continue
+		}
+		orgID := audiences[i].OrgID
+		if _, ok := byOrg[orgID]; !ok {
+			orgs = append(orgs, orgID)
+		}
+		countries := map[string]bool{}
+		for _, c := range audienceCountryCodes(a) {
+			countries[c] = true
+		}
+		byOrg[orgID] = append(byOrg[orgID], segment{id: audiences[i].ID, audience: a, countries: countries})
+	}
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i] < orgs[j] })
+	comparisons := 0
+	for _, orgID := range orgs {
+		segments := byOrg[orgID]
+		// with the audiences sorted by their minimum age, the ones after an audience share its ages
+		// until the first one that is older than its maximum age
+		sort.Slice(segments, func(i, j int) bool {
+			if segments[i].audience.AgeMin != segments[j].audience.AgeMin {
+				return segments[i].audience.AgeMin < segments[j].audience.AgeMin
+			}
+			return segments[i].id < segments[j].id
+		})
+		for i := range segments {
+			for j := i + 1; j < len(segments) && segments[j].audience.AgeMin <= segments[i].audience.AgeMax; j++ {
+				if !segments[i].overlaps(segments[j]) {
+					continue
+				}
+				if comparisons == limit {
+					log.Printf("recommender compared the limit of %d pairs of audiences", limit)
+					return
+				}
+				comparisons++
+				similarity := compareAudiences(nil, []*Audience{segments[i].audience, segments[j].audience}).Similarity
+				if similarity < minAudienceSimilarity {
+					continue
+				}
+				ai := FavouriteItem{AssetType: AudienceAssetType, AssetID: segments[i].id}
+				bi := FavouriteItem{AssetType: AudienceAssetType, AssetID: segments[j].id}
+				scores[assetPair{ai, bi}] += audienceSimilarityWeight * similarity
+				scores[assetPair{bi, ai}] += audienceSimilarityWeight * similarity
+			}
+		}
+	}
+}
+
+func lessItem(a, b FavouriteItem) bool {
+	if a.AssetType != b.AssetType {
+		return a.AssetType < b.AssetType
+	}
+	return a.AssetID < b.AssetID
+}
+
+// RefreshRecommendations computes again the similarities of the assets from all the favourites and the live audiences.
+// It returns the number of the stored similarities.
+func (d *Domain) RefreshRecommendations(ctx context.Context) (int, error) {
+	favourites, err := d.repo.ListAllFavourites(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	audiences, err := d.repo.ListLiveAudiences(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	similarities := computeSimilarities(favourites, audiences)
+	err = d.repo.ReplaceAssetSimilarities(ctx, similarities)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return len(similarities), nil
+}
+
+// RunRecommender refreshes the recommendations every interval until the context is done.
+func (d *Domain) RunRecommender(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := d.RefreshRecommendations(ctx)
+		if err != nil {
+			log.Println("Error recommender: ", err)
+		} else {
+			log.Printf("recommender stored %d similarities", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// recommend ranks the assets that are similar to the given ones by the sum of their similarities, without
+// the given ones, and returns up to limit of them that the user sees. The best ranked candidates are read
+// with a query for every asset type.
+func (d *Domain) recommend(ctx context.Context, user *User, from []FavouriteItem, limit int) ([]RecommendedAsset, error) {
+	recommended := []RecommendedAsset{}
+	if len(from) == 0 {
+		return recommended, nil
+	}
+	similarities, err := d.repo.ListAssetSimilarities(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	given := map[FavouriteItem]bool{}
+	for _, v := range from {
+		given[v] = true
+	}
+	scores := map[FavouriteItem]float64{}
+	for _, v := range similarities {
+		related := FavouriteItem{AssetType: v.RelatedType, AssetID: v.RelatedID}
+		if !given[related] {
+			scores[related] += v.Score
+		}
+	}
+	candidates := []FavouriteItem{}
+	for k := range scores {
+		candidates = append(candidates, k)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if scores[candidates[i]] != scores[candidates[j]] {
+			return scores[candidates[i]] > scores[candidates[j]]
+		}
+		return lessItem(candidates[i], candidates[j])
+	})
+	if len(candidates) > maxRecommendCandidates {
+		candidates = candidates[:maxRecommendCandidates]
+	}
+	ids := map[AssetType][]uint{}
+	for _, v := range candidates {
+		ids[v.AssetType] = append(ids[v.AssetType], v.AssetID)
+	}
+	visible := map[FavouriteItem]*Asset{}
+	for _, at := range []AssetType{InsightAssetType, ChartAssetType, AudienceAssetType} {
+		if len(ids[at]) == 0 {
+			continue
+		}
+		assets, err := d.repo.ListVisibleAssets(ctx, scopeOf(user), at, ids[at])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+		}
+		for i := range assets {
+			visible[FavouriteItem{AssetType: at, AssetID: assets[i].ID}] = &assets[i]
+		}
+	}
+	byType := map[AssetType][]*Asset{}
+	for _, v := range candidates {
+		if len(recommended) == limit {
+			break
+		}
+		asset, ok := visible[v]
+		if !ok {
+			continue
+		}
+		byType[v.AssetType] = append(byType[v.AssetType], asset)
+		recommended = append(recommended, RecommendedAsset{AssetType: v.AssetType, AssetID: v.AssetID, Score: scores[v], Asset: asset})
+	}
+	for at, assets := range byType {
+		err = d.translateAssets(ctx, at, assets)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return recommended, nil
+}
+
+// Recommendations returns the assets that the users who favour the same assets as the user also favour,
+// and the audiences with segments that are similar to the favourite audiences of the user.
+func (d *Domain) Recommendations(ctx context.Context, user *User, query QueryRecommendations) ([]RecommendedAsset, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongQueryInput, err)
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultRecommendations
+	}
+	favourites, err := d.repo.ListFavourites(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return d.recommend(ctx, user, favourites, query.Limit)
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// statusTransitions has the statuses that every status of the lifecycle of an asset can move to.
+var statusTransitions = map[AssetStatus][]AssetStatus{
+	DraftStatus:     {InReviewStatus, ArchivedStatus},
+	InReviewStatus:  {DraftStatus, PublishedStatus},
+	ScheduledStatus: {DraftStatus, ArchivedStatus},
+	PublishedStatus: {ArchivedStatus},
+	ArchivedStatus:  {DraftStatus},
+}
+
+// contentLocked reports whether the content of an asset in the status is reviewed or approved,
+// so that it cannot be edited without moving the asset back to draft and through the review again.
+func contentLocked(status AssetStatus) bool {
+	return status == InReviewStatus || status == ScheduledStatus || status == PublishedStatus
+}
+
+// TransitionAsset moves an asset of the organization of the administrator to another status.
+// Publishing is the approval of an asset in review and it has to be done by another administrator than its author
+// and than the last administrator that changed its content.
+// An approved asset with a publishAt time in the future is scheduled and the scheduler publishes it later.
+func (d *Domain) TransitionAsset(ctx context.Context, user *User, assetID uint, assetType AssetType, transition StatusTransition) (*Asset, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	err := d.validate.Struct(transition)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongStatusInput, err)
+	}
+	asset, err := d.getOwnedAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return nil, err
+	}
+	allowed := false
+	for _, s := range statusTransitions[asset.Status] {
+		allowed = allowed || s == transition.Status
+	}
+	if !allowed {
+		err := fmt.Errorf("an asset cannot move from %s to %s", asset.Status, transition.Status)
+		return nil, fmt.Errorf("%w: %v", ErrWrongStatusInput, err)
+	}
+	approvedBy := asset.ApprovedBy
+	if transition.Status == PublishedStatus {
+		if asset.AuthorID == user.ID || asset.EditorID == user.ID {
+			return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("the author or the last editor cannot approve the asset"))
+		}
+		approvedBy = user.ID
+	}
+	status := transition.Status
+	if status == PublishedStatus && asset.PublishAt != nil && asset.PublishAt.After(time.Now()) {
+		status = ScheduledStatus
+	}
+	err = d.repo.SetAssetStatus(ctx, assetType, assetID, status, approvedBy)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	asset.Status = status
+	asset.ApprovedBy = approvedBy
+	d.publishEvents(ctx, assetEvent(AssetUpdatedEvent, user, asset))
+	return asset, nil
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// getOrganizationTag returns the tag only when it belongs to the active organization of the user.
+func (d *Domain) getOrganizationTag(ctx context.Context, user *User, tagID uint) (*Tag, error) {
+	tag, err := d.repo.GetTag(ctx, user.OrgID, tagID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTagNotFound, err)
+	}
+	return tag, nil
+}
+
+func (d *Domain) CreateTag(ctx context.Context, user *User, tag Tag) (*Tag, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	err := d.validate.Struct(tag)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongTagInput, err)
+	}
+	newTag, err := d.repo.AddTag(ctx, user.OrgID, tag)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return newTag, nil
+}
+
+func (d *Domain) RenameTag(ctx context.Context, user *User, tagID uint, tag Tag) (*Tag, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	err := d.validate.Struct(tag)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongTagInput, err)
+	}
+	_, err = d.getOrganizationTag(ctx, user, tagID)
+	if err != nil {
+		return nil, err
+	}
+	newTag, err := d.repo.UpdateTag(ctx, user.OrgID, tagID, tag)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return newTag, nil
+}
+
+// MergeTags moves the assets of the first tag to the second one and deletes the first tag.
+func (d *Domain) MergeTags(ctx context.Context, user *User, fromTagID, intoTagID uint) error {
+	if user == nil {
+		return ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	if fromTagID == intoTagID {
+		return fmt.Errorf("%w: %v", ErrWrongTagInput, errors.New("a tag cannot be merged into itself"))
+	}
+	_, err := d.getOrganizationTag(ctx, user, fromTagID)
+	if err != nil {
+		return err
+	}
+	_, err = d.getOrganizationTag(ctx, user, intoTagID)
+	if err != nil {
+		return err
+	}
+	err = d.repo.MergeTags(ctx, user.OrgID, fromTagID, intoTagID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return nil
+}
+
+func (d *Domain) DeleteTag(ctx context.Context, user *User, tagID uint) error {
+	if user == nil {
+		return ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	_, err := d.getOrganizationTag(ctx, user, tagID)
+	if err != nil {
+		return err
+	}
+	err = d.repo.DeleteTag(ctx, user.OrgID, tagID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return nil
+}
+
+// SetAssetTags replaces the tags of the asset with the given ones.
+func (d *Domain) SetAssetTags(ctx context.Context, user *User, assetID uint, assetType AssetType, tagIDs []uint) error {
+	if user == nil {
+		return ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	seen := map[uint]bool{}
+	for _, id := range tagIDs {
+		if seen[id] {
+			return fmt.Errorf("%w: %v", ErrWrongTagInput, fmt.Errorf("tag %d exists more than once", id))
+		}
+		seen[id] = true
+	}
+	_, err := d.getOwnedAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return err
+	}
+	for _, id := range tagIDs {
+		_, err = d.getOrganizationTag(ctx, user, id)
+		if err != nil {
+			return err
+		}
+	}
+	err = d.repo.SetAssetTags(ctx, assetType, assetID, tagIDs)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return nil
+}
+
+// ListTags returns the tags of the active organization of the user with the number of tagged assets that the user sees.
+// When assetType is empty the assets of all the types are counted.
+func (d *Domain) ListTags(ctx context.Context, user *User, assetType AssetType) ([]Tag, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	tags, err := d.repo.ListTags(ctx, scopeOf(user), assetType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return tags, nil
+}
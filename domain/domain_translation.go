@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// assetLocale returns the locale of the texts of the asset, the assets added before the translations have none.
+func assetLocale(asset *Asset) string {
+	if asset.Locale == "" {
+		return DefaultLocale
+	}
+	return asset.Locale
+}
+
+// translateAssets replaces the texts of the assets of the type with their translation to the first language
+// of the context that the asset has texts in. The assets keep their own texts when the language comes
+// before any translation, and their locale is set to the language of the returned texts.
+func (d *Domain) translateAssets(ctx context.Context, assetType AssetType, assets []*Asset) error {
+	languages := languagesFrom(ctx)
+	if len(languages) == 0 || len(assets) == 0 {
+		return nil
+	}
+	ids := []uint{}
+	for _, a := range assets {
+		a.Locale = assetLocale(a)
+		if a.Locale != languages[0] {
+			ids = append(ids, a.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	trs, err := d.repo.ListAssetTranslations(ctx, assetType, ids)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	translations := map[uint]map[string]AssetTranslation{}
+	for _, tr := range trs {
+		if translations[tr.AssetID] == nil {
+			translations[tr.AssetID] = map[string]AssetTranslation{}
+		}
+		translations[tr.AssetID][tr.Locale] = tr
+	}
+	for _, a := range assets {
+		for _, l := range languages {
+			if l == a.Locale {
+				break
+			}
+			tr, ok := translations[a.ID][l]
+			if ok {
+				applyTranslation(a, tr)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Domain) translateListedAssets(ctx context.Context, ls *ListedAssets) error {
+	if ls == nil {
+		return nil
+	}
+	assets := []*Asset{}
+	for i := range ls.Assets {
+		assets = append(assets, &ls.Assets[i])
+	}
+	return d.translateAssets(ctx, ls.Type, assets)
+}
+
+// applyTranslation replaces the texts of the asset with the non empty texts of the translation.
+func applyTranslation(asset *Asset, tr AssetTranslation) {
+	set := func(text *string, translated string) {
+		if translated != "" {
+			*text = translated
+		}
+	}
+	switch v := asset.Data.(type) {
+	case *Insight:
+		set(&v.Text, tr.Text)
+		set(&v.Description, tr.Description)
+	case *Chart:
+		set(&v.Title, tr.Title)
+		set(&v.XTitle, tr.XTitle)
+		set(&v.YTitle, tr.YTitle)
+		set(&v.Description, tr.Description)
+	case *Audience:
+		set(&v.Description, tr.Description)
+	}
+	asset.Locale = tr.Locale
+}
+
+// validateTranslation checks that the translation has only the texts of the type of the asset, and at least one of them.
+func validateTranslation(assetType AssetType, tr AssetTranslation) error {
+	var allowed, other []string
+	switch assetType {
+	case InsightAssetType:
+		allowed = []string{tr.Text, tr.Description}
+		other = []string{tr.Title, tr.XTitle, tr.YTitle}
+	case ChartAssetType:
+		allowed = []string{tr.Title, tr.XTitle, tr.YTitle, tr.Description}
+		other = []string{tr.Text}
+	case AudienceAssetType:
+		allowed = []string{tr.Description}
+		other = []string{tr.Text, tr.Title, tr.XTitle, tr.YTitle}
+	default:
+		return errors.New("asset type is not correct")
+	}
+	for _, v := range other {
+		if v != "" {
+			return fmt.Errorf("the texts of %s are not translated", assetType)
+		}
+	}
+	for _, v := range allowed {
+		if v != "" {
+			return nil
+		}
+	}
+	return errors.New("translation has no texts")
+}
+
+// SetAssetTranslation adds or replaces the translation of an asset of the organization of the administrator
+// to a locale other than the locale of the asset. The translations are content of the asset, so they are only
+// changed like the asset and the administrator becomes its last editor.
+func (d *Domain) SetAssetTranslation(ctx context.Context, user *User, assetID uint, assetType AssetType, translation AssetTranslation) (*AssetTranslation, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	err := d.validate.Struct(translation)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongTranslationInput, err)
+	}
+	err = validateTranslation(assetType, translation)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongTranslationInput, err)
+	}
+	asset, err := d.getOwnedAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return nil, err
+	}
+	if contentLocked(asset.Status) {
+		err := fmt.Errorf("an asset cannot be translated while it is %s, move it back to draft first", asset.Status)
+		return nil, fmt.Errorf("%w: %v", ErrWrongStatusInput, err)
+	}
+	if translation.Locale == assetLocale(asset) {
+		err := fmt.Errorf("the texts of the asset are already in %s", translation.Locale)
+		return nil, fmt.Errorf("%w: %v", ErrWrongTranslationInput, err)
+	}
+	translation.ID = 0
+	translation.AssetType = assetType
+	translation.AssetID = assetID
+	tr, err := d.repo.SetAssetTranslation(ctx, translation)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	err = d.repo.SetAssetEditor(ctx, assetType, assetID, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return tr, nil
+}
+
+func (d *Domain) DeleteAssetTranslation(ctx context.Context, user *User, assetID uint, assetType AssetType, locale string) error {
+	if user == nil {
+		return ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	asset, err := d.getOwnedAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return err
+	}
+	if contentLocked(asset.Status) {
+		err := fmt.Errorf("a translation cannot be removed while the asset is %s, move it back to draft first", asset.Status)
+		return fmt.Errorf("%w: %v", ErrWrongStatusInput, err)
+	}
+	found, err := d.repo.RemoveAssetTranslation(ctx, assetType, assetID, locale)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	if !found {
+		return ErrTranslationNotFound
+	}
+	err = d.repo.SetAssetEditor(ctx, assetType, assetID, user.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return nil
+}
+
+func (d *Domain) ListAssetTranslations(ctx context.Context, user *User, assetID uint, assetType AssetType) ([]AssetTranslation, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	_, err := d.getOwnedAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return nil, err
+	}
+	trs, err := d.repo.ListAssetTranslations(ctx, assetType, []uint{assetID})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return trs, nil
+}
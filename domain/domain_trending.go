@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultTrendingHours is the window of the trending assets when the query has none, a week
+	defaultTrendingHours = 7 * 24
+	defaultTrendingLimit = 10
+)
+
+// TrendingAssets ranks the assets that the user sees by the favourites they gained in the window of the query.
+// The assets with the same number of favourites are ranked by their ID.
+func (d *Domain) TrendingAssets(ctx context.Context, user *User, query QueryTrending) ([]TrendingAsset, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongQueryInput, err)
+	}
+	if query.Hours == 0 {
+		query.Hours = defaultTrendingHours
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultTrendingLimit
+	}
+	since := time.Now().Add(-time.Duration(query.Hours) * time.Hour)
+	trending, err := d.repo.ListTrendingAssets(ctx, scopeOf(user), query.Type, since, query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	assets := make([]*Asset, len(trending))
+	for i := range trending {
+		assets[i] = &trending[i].Asset
+	}
+	err = d.translateAssets(ctx, query.Type, assets)
+	if err != nil {
+		return nil, err
+	}
+	return trending, nil
+}
@@ -47,6 +47,23 @@ func (d *Domain) LoginUser(ctx context.Context, cred LoginCredentials) (*User, e
 		return nil, ErrUnauthorized
 	}
 
+	if cred.OrgID != 0 {
+		isMember, err := d.repo.IsOrganizationMember(ctx, cred.OrgID, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+		}
+		if !isMember {
+			return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("user is not a member of the organization"))
+		}
+		// the administrator rights in an organization come from the membership, not from the user
+		isAdmin, err := d.repo.IsOrganizationAdmin(ctx, cred.OrgID, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+		}
+		user.OrgID = cred.OrgID
+		user.IsAdmin = isAdmin
+	}
+
 	user.Password = ""
 	return user, nil
 }
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	// viewBufferSize is the number of views that wait to be stored, the views that do not fit are dropped
+	viewBufferSize = 1000
+	// viewPurgeInterval is how often the views older than the retention are removed
+	viewPurgeInterval   = time.Hour
+	defaultRecentAssets = 20
+)
+
+// recordView keeps the view for the view recorder without waiting, so the reads of the assets are not slowed
+// down. When the buffer is full the view is dropped.
+func (d *Domain) recordView(view AssetView) {
+	select {
+	case d.views <- view:
+	default:
+	}
+}
+
+// FlushAssetViews stores the views that wait in the buffer and returns their number.
+func (d *Domain) FlushAssetViews(ctx context.Context) (int, error) {
+	views := []AssetView{}
+drain:
+	for len(views) < viewBufferSize {
+		select {
+		case v := <-d.views:
+			views = append(views, v)
+		default:
+			break drain
+		}
+	}
+	if len(views) == 0 {
+		return 0, nil
+	}
+	err := d.repo.AddAssetViews(ctx, views)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return len(views), nil
+}
+
+// PurgeAssetViews removes the views older than the retention, the view counts of the assets remain.
+func (d *Domain) PurgeAssetViews(ctx context.Context, now time.Time, retention time.Duration) (int64, error) {
+	n, err := d.repo.PurgeAssetViews(ctx, now.Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return n, nil
+}
+
+// RunViewRecorder stores the views of the assets every interval and removes the views older than the retention
+// every hour, until the context is done. The views that wait are stored before it returns.
+func (d *Domain) RunViewRecorder(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var purgedAt time.Time
+	for {
+		_, err := d.FlushAssetViews(ctx)
+		if err != nil {
+			log.Println("Error view recorder: ", err)
+		}
+		if time.Since(purgedAt) >= viewPurgeInterval {
+			purgedAt = time.Now()
+			n, err := d.PurgeAssetViews(ctx, purgedAt, retention)
+			if err != nil {
+				log.Println("Error view recorder: ", err)
+			} else if n > 0 {
+				log.Printf("view recorder removed %d views", n)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			_, err := d.FlushAssetViews(context.Background())
+			if err != nil {
+				log.Println("Error view recorder: ", err)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RecentAssets returns the assets that the user viewed lately, from the last one, that the user still sees.
+func (d *Domain) RecentAssets(ctx context.Context, user *User, query QueryRecentAssets) ([]RecentAsset, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	err := d.validate.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongQueryInput, err)
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultRecentAssets
+	}
+	views, err := d.repo.ListRecentViews(ctx, user.ID, query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	recent := []RecentAsset{}
+	now := time.Now()
+	for _, v := range views {
+		asset, err := d.repo.GetAsset(ctx, scopeOf(user), v.AssetType, v.AssetID)
+		if err != nil {
+			continue
+		}
+		markExpired(asset, now)
+		err = d.translateAssets(ctx, v.AssetType, []*Asset{asset})
+		if err != nil {
+			return nil, err
+		}
+		v.Asset = asset
+		recent = append(recent, v)
+	}
+	return recent, nil
+}
+
+// AssetViewStats counts the views of an asset of the active organization of the administrator.
+func (d *Domain) AssetViewStats(ctx context.Context, user *User, assetID uint, assetType AssetType) (*AssetViewStats, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	_, err := d.getOwnedAsset(ctx, user, assetID, assetType)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := d.repo.GetAssetViewStats(ctx, assetType, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return stats, nil
+}
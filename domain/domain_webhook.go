@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// webhookSecretSize is the number of random bytes of the generated secrets
+const webhookSecretSize = 32
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretSize)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// getOwnedWebhook returns a webhook of the active organization of the administrator.
+func (d *Domain) getOwnedWebhook(ctx context.Context, user *User, webhookID uint) (*Webhook, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	hook, err := d.repo.GetWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookNotFound, err)
+	}
+	if hook.OrgID != user.OrgID {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookNotFound, errors.New("the webhook belongs to another organization"))
+	}
+	return hook, nil
+}
+
+// AddWebhook registers a webhook for the events of the assets of the active organization of the administrator.
+// The returned webhook has its secret, which is not returned again.
+func (d *Domain) AddWebhook(ctx context.Context, user *User, input WebhookInput) (*Webhook, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	err := d.validate.Struct(input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongWebhookInput, err)
+	}
+	secret := input.Secret
+	if secret == "" {
+		secret, err = newWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+	}
+	hook := Webhook{
+		OrgID:      user.OrgID,
+		URL:        input.URL,
+		Events:     input.Events,
+		AssetTypes: input.AssetTypes,
+		Active:     input.Active == nil || *input.Active,
+		Secret:     secret,
+	}
+	newHook, err := d.repo.AddWebhook(ctx, hook)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	newHook.Secret = secret
+	return newHook, nil
+}
+
+// UpdateWebhook replaces the URL and the filters of a webhook, and its secret and activity when they are given.
+func (d *Domain) UpdateWebhook(ctx context.Context, user *User, webhookID uint, input WebhookInput) (*Webhook, error) {
+	hook, err := d.getOwnedWebhook(ctx, user, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	err = d.validate.Struct(input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongWebhookInput, err)
+	}
+	hook.URL = input.URL
+	hook.Events = input.Events
+	hook.AssetTypes = input.AssetTypes
+	if input.Secret != "" {
+		hook.Secret = input.Secret
+	}
+	if input.Active != nil {
+		hook.Active = *input.Active
+	}
+	newHook, err := d.repo.UpdateWebhook(ctx, *hook)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	newHook.Secret = ""
+	return newHook, nil
+}
+
+// DeleteWebhook removes a webhook with its deliveries.
+func (d *Domain) DeleteWebhook(ctx context.Context, user *User, webhookID uint) error {
+	_, err := d.getOwnedWebhook(ctx, user, webhookID)
+	if err != nil {
+		return err
+	}
+	err = d.repo.RemoveWebhook(ctx, webhookID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return nil
+}
+
+// ListWebhooks lists the webhooks of the active organization of the administrator without their secrets.
+func (d *Domain) ListWebhooks(ctx context.Context, user *User) ([]Webhook, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	hooks, err := d.repo.ListWebhooks(ctx, user.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	for i := range hooks {
+		hooks[i].Secret = ""
+	}
+	return hooks, nil
+}
+
+// deliverNow adds the delivery and attempts it at once, also to an inactive webhook to test it before activating it.
+// The delivery is added without a next attempt, so the dispatcher does not send it too while it is attempted here.
+// A failed attempt is retried like the other deliveries.
+func (d *Domain) deliverNow(ctx context.Context, hook *Webhook, delivery WebhookDelivery) (*WebhookDelivery, error) {
+	now := time.Now()
+	manual := *hook
+	manual.Active = true
+	delivery.Status = PendingDelivery
+	delivery.NextAttemptAt = nil
+	added, err := d.repo.AddWebhookDeliveries(ctx, []WebhookDelivery{delivery})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	delivery = added[0]
+	err = d.attemptDelivery(ctx, &manual, &delivery, now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return &delivery, nil
+}
+
+// PingWebhook sends a ping event to a webhook at once, to test its receiver.
+func (d *Domain) PingWebhook(ctx context.Context, user *User, webhookID uint) (*WebhookDelivery, error) {
+	hook, err := d.getOwnedWebhook(ctx, user, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(WebhookEvent{Event: PingEvent, OrgID: hook.OrgID, UserID: user.ID, OccurredAt: time.Now()})
+	if err != nil {
+		return nil, err
+	}
+	return d.deliverNow(ctx, hook, WebhookDelivery{WebhookID: hook.ID, Event: PingEvent, Payload: string(payload)})
+}
+
+// ListWebhookDeliveries lists the delivery log of a webhook.
+func (d *Domain) ListWebhookDeliveries(ctx context.Context, user *User, webhookID uint, query QueryDeliveries) (*ListedDeliveries, error) {
+	_, err := d.getOwnedWebhook(ctx, user, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	err = d.validate.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongQueryInput, err)
+	}
+	ls, err := d.repo.ListWebhookDeliveries(ctx, webhookID, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return ls, nil
+}
+
+// RedeliverWebhook sends the payload of a delivery of any status again at once, as a new delivery.
+func (d *Domain) RedeliverWebhook(ctx context.Context, user *User, webhookID, deliveryID uint) (*WebhookDelivery, error) {
+	hook, err := d.getOwnedWebhook(ctx, user, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := d.repo.GetWebhookDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDeliveryNotFound, err)
+	}
+	return d.deliverNow(ctx, hook, WebhookDelivery{
+		WebhookID:    hook.ID,
+		Event:        delivery.Event,
+		Payload:      delivery.Payload,
+		RedeliveryOf: delivery.ID,
+	})
+}
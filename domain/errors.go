@@ -3,11 +3,45 @@ package domain
 import "errors"
 
 var (
-	ErrWrongAssetInput = errors.New("wrong input for asset")
-	ErrWrongQueryInput = errors.New("wrong input for query")
-	ErrWrongUserInput  = errors.New("wrong input for user")
-	ErrWrongLoginInput = errors.New("wrong input for login")
-	ErrUserNotFound    = errors.New("user not found")
+	ErrWrongAssetInput   = errors.New("wrong input for asset")
+	ErrWrongQueryInput   = errors.New("wrong input for query")
+	ErrWrongUserInput    = errors.New("wrong input for user")
+	ErrWrongLoginInput   = errors.New("wrong input for login")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrAssetNotFound     = errors.New("asset not found")
+	ErrWrongNoteInput    = errors.New("wrong input for note")
+	ErrFavouriteNotFound = errors.New("favourite not found")
+	ErrWrongFavourInput  = errors.New("wrong input for favourites")
+
+	ErrWrongOrganizationInput = errors.New("wrong input for organization")
+	ErrOrganizationNotFound   = errors.New("organization not found")
+	ErrWrongGroupInput        = errors.New("wrong input for group")
+	ErrGroupNotFound          = errors.New("group not found")
+	ErrWrongGrantInput        = errors.New("wrong input for grant")
+
+	ErrWrongCollectionInput = errors.New("wrong input for collection")
+	ErrCollectionNotFound   = errors.New("collection not found")
+
+	ErrWrongTagInput = errors.New("wrong input for tag")
+	ErrTagNotFound   = errors.New("tag not found")
+
+	ErrWrongLinkInput = errors.New("wrong input for asset link")
+
+	ErrWrongStatusInput = errors.New("wrong input for asset status")
+
+	ErrWrongTranslationInput = errors.New("wrong input for translation")
+	ErrTranslationNotFound   = errors.New("translation not found")
+
+	ErrWrongImportInput = errors.New("wrong input for import")
+
+	ErrWrongCommentInput = errors.New("wrong input for comment")
+	ErrCommentNotFound   = errors.New("comment not found")
+
+	ErrWrongFeedbackInput = errors.New("wrong input for rating or flag")
+
+	ErrWrongWebhookInput = errors.New("wrong input for webhook")
+	ErrWebhookNotFound   = errors.New("webhook not found")
+	ErrDeliveryNotFound  = errors.New("webhook delivery not found")
 
 	ErrUnauthorized = errors.New("unauthorized")
 
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"math"
+)
+
+// exportPageSize is the number of assets that an export reads from the DB at once.
+const exportPageSize = 500
+
+// ExportAssets passes to write, page by page, the assets of the listing of the query, or of the favourites
+// of the user when favQuery is set. The pages follow each other from the LastID of the query until there
+// are no more assets or the Limit of the query is reached, when it is set.
+func (d *Domain) ExportAssets(ctx context.Context, user *User, query QueryAssets, favQuery *QueryFavouriteAssets, write func(assets []Asset) error) error {
+	if user == nil {
+		return ErrUnauthorized
+	}
+	remaining := query.Limit
+	if query.IsDesc && query.LastID == 0 {
+		query.LastID = math.MaxInt64
+	}
+	for {
+		query.Limit = exportPageSize
+		if remaining > 0 && remaining < exportPageSize {
+			query.Limit = remaining
+		}
+		ls, err := d.ListAssets(ctx, user, query, favQuery)
+		if err != nil {
+			return err
+		}
+		if ls == nil || len(ls.Assets) == 0 {
+			return nil
+		}
+		err = write(ls.Assets)
+		if err != nil {
+			return err
+		}
+		if remaining > 0 {
+			remaining -= len(ls.Assets)
+			if remaining <= 0 {
+				return nil
+			}
+		}
+		if len(ls.Assets) < query.Limit || ls.LastID == query.LastID {
+			return nil
+		}
+		query.LastID = ls.LastID
+	}
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func exportMockDB(count uint) *MockDB {
+	mdb := &MockDB{}
+	mdb.listAssets = func(ctx context.Context, scope AssetScope, query QueryAssets) (*ListedAssets, error) {
+		ls := &ListedAssets{Limit: query.Limit, Type: query.Type, Assets: []Asset{}}
+		for id := query.LastID + 1; id <= count && len(ls.Assets) < query.Limit; id++ {
+			ls.Assets = append(ls.Assets, Asset{ID: id, Data: &Insight{Text: "a", Description: "b"}})
+		}
+		if len(ls.Assets) > 0 {
+			ls.FirstID = ls.Assets[0].ID
+			ls.LastID = ls.Assets[len(ls.Assets)-1].ID
+		}
+		return ls, nil
+	}
+	return mdb
+}
+
+func TestExportAssetsSuccess(t *testing.T) {
+	dom := NewDomain(exportMockDB(1201))
+	ctx := context.Background()
+	usr := &User{ID: 1, Username: "manos"}
+
+	pages := []int{}
+	var lastID uint
+	err := dom.ExportAssets(ctx, usr, QueryAssets{Type: InsightAssetType}, nil, func(assets []Asset) error {
+		pages = append(pages, len(assets))
+		lastID = assets[len(assets)-1].ID
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{500, 500, 201}, pages)
+	assert.Equal(t, uint(1201), lastID)
+
+	pages = []int{}
+	err = dom.ExportAssets(ctx, usr, QueryAssets{Type: InsightAssetType, LastID: 100, Limit: 600}, nil, func(assets []Asset) error {
+		pages = append(pages, len(assets))
+		lastID = assets[len(assets)-1].ID
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{500, 100}, pages)
+	assert.Equal(t, uint(700), lastID)
+}
+
+func TestExportAssetsFailure(t *testing.T) {
+	dom := NewDomain(exportMockDB(10))
+	ctx := context.Background()
+	err := dom.ExportAssets(ctx, &User{ID: 1}, QueryAssets{}, nil, func(assets []Asset) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrWrongQueryInput)
+	err = dom.ExportAssets(ctx, nil, QueryAssets{Type: InsightAssetType}, nil, func(assets []Asset) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
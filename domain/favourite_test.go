@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFavouriteAssetsSuccess(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.favouriteAssets = func(ctx context.Context, scope AssetScope, userID uint, items []FavouriteItem, isFavourite bool) ([]FavouriteResult, error) {
+		results := []FavouriteResult{}
+		for _, v := range items {
+			results = append(results, FavouriteResult{AssetType: v.AssetType, AssetID: v.AssetID, OK: isFavourite})
+		}
+		return results, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{ID: 1, Username: "manos"}
+	results, err := dom.FavouriteAssets(ctx, usr, FavouriteBatch{Favourite: true, Items: []FavouriteItem{
+		{AssetType: ChartAssetType, AssetID: 1},
+		{AssetType: InsightAssetType, AssetID: 1},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(results))
+	assert.True(t, results[1].OK)
+}
+
+func TestFavouriteAssetsWrongInputFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	usr := &User{ID: 1, Username: "manos"}
+	wrong := []FavouriteBatch{
+		{Favourite: true},
+		{Favourite: true, Items: []FavouriteItem{{AssetType: ChartAssetType}}},
+		{Favourite: true, Items: []FavouriteItem{{AssetType: "users", AssetID: 1}}},
+		{Favourite: true, Items: []FavouriteItem{{AssetType: ChartAssetType, AssetID: 1}, {AssetType: ChartAssetType, AssetID: 1}}},
+		{Favourite: true, Items: make([]FavouriteItem, 101)},
+	}
+	for _, v := range wrong {
+		_, err := dom.FavouriteAssets(ctx, usr, v)
+		assert.ErrorIs(t, err, ErrWrongFavourInput)
+	}
+	_, err := dom.FavouriteAssets(ctx, nil, FavouriteBatch{})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestFavouriteAsset(t *testing.T) {
+	mdb := &MockDB{}
+	favourites := map[uint]bool{}
+	mdb.favouriteAsset = func(ctx context.Context, scope AssetScope, userID, assetID uint, at AssetType, isFavourite bool) (bool, error) {
+		if assetID != 1 {
+			return false, fmt.Errorf("%w: %v", ErrAssetNotFound, errors.New("record not found"))
+		}
+		changed := favourites[assetID] != isFavourite
+		favourites[assetID] = isFavourite
+		return changed, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{ID: 1, Username: "manos"}
+	changed, err := dom.FavouriteAsset(ctx, usr, 1, ChartAssetType, true)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	changed, err = dom.FavouriteAsset(ctx, usr, 1, ChartAssetType, true)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	changed, err = dom.FavouriteAsset(ctx, usr, 1, ChartAssetType, false)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	_, err = dom.FavouriteAsset(ctx, usr, 2, ChartAssetType, true)
+	assert.ErrorIs(t, err, ErrAssetNotFound)
+	assert.NotErrorIs(t, err, ErrInternalDBFailure)
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateAndFlagAsset(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		if assetID == 404 {
+			return nil, errors.New("record not found")
+		}
+		return &Asset{ID: assetID, OrgID: 1, Data: &Insight{Text: "text"}}, nil
+	}
+	mdb.rateAsset = func(ctx context.Context, userID uint, at AssetType, assetID uint, rating int) (*AssetFeedback, error) {
+		return &AssetFeedback{RatingCount: 1, RatingAverage: float64(rating)}, nil
+	}
+	var stored AssetFlag
+	mdb.flagAsset = func(ctx context.Context, flag AssetFlag) (*AssetFlag, error) {
+		stored = flag
+		flag.ID = 1
+		return &flag, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{ID: 2, Username: "manos"}
+
+	feedback, err := dom.RateAsset(ctx, usr, 1, InsightAssetType, RatingInput{Rating: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, feedback.RatingAverage)
+	for _, rating := range []int{0, 6} {
+		_, err = dom.RateAsset(ctx, usr, 1, InsightAssetType, RatingInput{Rating: rating})
+		assert.ErrorIs(t, err, ErrWrongFeedbackInput)
+	}
+	_, err = dom.RateAsset(ctx, usr, 404, InsightAssetType, RatingInput{Rating: 4})
+	assert.ErrorIs(t, err, ErrAssetNotFound)
+	_, err = dom.RateAsset(ctx, nil, 1, InsightAssetType, RatingInput{Rating: 4})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	flag, err := dom.FlagAsset(ctx, usr, 1, InsightAssetType, FlagInput{Reason: OutdatedFlag, Comment: "from 2019"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), flag.ID)
+	assert.Equal(t, uint(2), stored.UserID)
+	assert.Equal(t, InsightAssetType, stored.AssetType)
+	_, err = dom.FlagAsset(ctx, usr, 1, InsightAssetType, FlagInput{Reason: "boring"})
+	assert.ErrorIs(t, err, ErrWrongFeedbackInput)
+	_, err = dom.FlagAsset(ctx, usr, 404, InsightAssetType, FlagInput{Reason: IncorrectFlag})
+	assert.ErrorIs(t, err, ErrAssetNotFound)
+}
+
+func TestFlaggedAssets(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return &Asset{ID: assetID, OrgID: 1, Data: &Insight{Text: "text"}}, nil
+	}
+	var listedOrg uint
+	var listedLimit int
+	mdb.listFlaggedAssets = func(ctx context.Context, orgID uint, at AssetType, limit int) ([]FlaggedAsset, error) {
+		listedOrg = orgID
+		listedLimit = limit
+		return []FlaggedAsset{{AssetType: InsightAssetType, AssetID: 3, OpenFlags: 2}}, nil
+	}
+	mdb.listOpenFlags = func(ctx context.Context, at AssetType, assetID uint) ([]AssetFlag, error) {
+		return []AssetFlag{{ID: 1, AssetID: assetID, Reason: OutdatedFlag}, {ID: 2, AssetID: assetID, Reason: IncorrectFlag}}, nil
+	}
+	var resolvedReason FlagReason
+	mdb.resolveFlags = func(ctx context.Context, at AssetType, assetID uint, reason FlagReason, resolvedBy uint) (int64, error) {
+		resolvedReason = reason
+		return 1, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	admin := &User{ID: 1, IsAdmin: true, OrgID: 1}
+
+	flagged, err := dom.FlaggedAssets(ctx, admin, QueryFlaggedAssets{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), listedOrg)
+	assert.Equal(t, defaultFlaggedLimit, listedLimit)
+	assert.Equal(t, 2, len(flagged[0].Flags))
+	_, err = dom.FlaggedAssets(ctx, admin, QueryFlaggedAssets{Type: "maps"})
+	assert.ErrorIs(t, err, ErrWrongQueryInput)
+	_, err = dom.FlaggedAssets(ctx, &User{ID: 2}, QueryFlaggedAssets{})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	n, err := dom.ResolveFlags(ctx, admin, 3, InsightAssetType, FlagResolution{Reason: OutdatedFlag})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, OutdatedFlag, resolvedReason)
+	// the assets of another organization are not resolved by its administrators
+	_, err = dom.ResolveFlags(ctx, &User{ID: 4, IsAdmin: true, OrgID: 2}, 3, InsightAssetType, FlagResolution{})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	_, err = dom.ResolveFlags(ctx, admin, 3, InsightAssetType, FlagResolution{Reason: "boring"})
+	assert.ErrorIs(t, err, ErrWrongFeedbackInput)
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAssetWrongVisibilityFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+	}
+	_, err := dom.AddAsset(ctx, usr, InputAsset{
+		Data:       CorrectInputTestAssetData[0],
+		Visibility: Visibility("secret"),
+	})
+	assert.ErrorIs(t, err, ErrWrongAssetInput)
+}
+
+func TestAddAssetDefaultVisibilitySuccess(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.addAsset = func(ctx context.Context, scope AssetScope, asset InputAsset) (*Asset, error) {
+		return &Asset{ID: 1, Visibility: asset.Visibility, Data: asset.Data}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+	}
+	asset, err := dom.AddAsset(ctx, usr, InputAsset{Data: CorrectInputTestAssetData[0]})
+	assert.NoError(t, err)
+	assert.Equal(t, OrganizationVisibility, asset.Visibility)
+}
+
+func TestGrantAssetWrongInputFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+	}
+	_, err := dom.GrantAsset(ctx, usr, 1, InsightAssetType, AssetGrant{})
+	assert.ErrorIs(t, err, ErrWrongGrantInput)
+	_, err = dom.GrantAsset(ctx, usr, 1, InsightAssetType, AssetGrant{UserID: 2, GroupID: 3})
+	assert.ErrorIs(t, err, ErrWrongGrantInput)
+}
+
+func TestGrantAssetUnauthorizedFailure(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return &Asset{ID: assetID, OrgID: 2, Visibility: PublicVisibility}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	_, err := dom.GrantAsset(ctx, &User{ID: 1, IsAdmin: false}, 1, InsightAssetType, AssetGrant{UserID: 2})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	// public assets of other organizations can not be granted
+	_, err = dom.GrantAsset(ctx, &User{ID: 1, IsAdmin: true, OrgID: 1}, 1, InsightAssetType, AssetGrant{UserID: 2})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestGrantAssetNotFoundFailure(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return nil, errors.New("record not found")
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	_, err := dom.GrantAsset(ctx, &User{ID: 1, IsAdmin: true}, 1, InsightAssetType, AssetGrant{UserID: 2})
+	assert.ErrorIs(t, err, ErrAssetNotFound)
+}
+
+func TestGrantAssetSuccess(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return &Asset{ID: assetID, OrgID: 1, Visibility: PrivateVisibility}, nil
+	}
+	mdb.addAssetGrant = func(ctx context.Context, at AssetType, assetID uint, grant AssetGrant) (*AssetGrant, error) {
+		grant.ID = 1
+		return &grant, nil
+	}
+	mdb.isOrganizationMember = func(ctx context.Context, orgID, userID uint) (bool, error) {
+		return orgID == 1, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	grant, err := dom.GrantAsset(ctx, &User{ID: 1, IsAdmin: true, OrgID: 1}, 1, InsightAssetType, AssetGrant{UserID: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), grant.ID)
+	assert.Equal(t, uint(2), grant.UserID)
+}
+
+func TestGrantToUserOfOtherOrganizationFailure(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return &Asset{ID: assetID, OrgID: 1, Visibility: PrivateVisibility}, nil
+	}
+	mdb.getGroup = func(ctx context.Context, groupID uint) (*Group, error) {
+		return &Group{ID: groupID, OrgID: 1}, nil
+	}
+	// the user 3 is not a member of the organization
+	mdb.isOrganizationMember = func(ctx context.Context, orgID, userID uint) (bool, error) {
+		return userID != 3, nil
+	}
+	stored := false
+	mdb.addAssetGrant = func(ctx context.Context, at AssetType, assetID uint, grant AssetGrant) (*AssetGrant, error) {
+		stored = true
+		return &grant, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	admin := &User{ID: 1, IsAdmin: true, OrgID: 1}
+	_, err := dom.GrantAsset(ctx, admin, 1, InsightAssetType, AssetGrant{UserID: 3})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.False(t, stored)
+	err = dom.AddGroupMember(ctx, admin, 1, 3)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	err = dom.AddGroupMember(ctx, admin, 1, 2)
+	assert.NoError(t, err)
+}
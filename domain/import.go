@@ -0,0 +1,268 @@
+package domain
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+const (
+	maxImportRows = 10000
+	// maxImportLine is the longest line of a JSON Lines file
+	maxImportLine = 1 << 20
+)
+
+// importRow is a row of an import file with the asset data that it was parsed to, or the error of the parsing.
+type importRow struct {
+	line int
+	data interface{}
+	err  error
+}
+
+func newAssetData(assetType AssetType) (interface{}, error) {
+	switch assetType {
+	case InsightAssetType:
+		return &Insight{}, nil
+	case ChartAssetType:
+		return &Chart{}, nil
+	case AudienceAssetType:
+		return &Audience{}, nil
+	}
+	return nil, errors.New("asset type is not correct")
+}
+
+// ImportAssets adds the assets of a CSV or JSON Lines file to the organization of the administrator.
+// Every row is validated like a new asset and the report has the outcome of each of them. A dry run
+// only validates the rows. Otherwise the rows are added in chunks of ChunkSize, or all together when it is 0,
+// and a chunk is added only when all of its rows are valid.
+func (d *Domain) ImportAssets(ctx context.Context, user *User, assetType AssetType, r io.Reader, opts ImportOptions) (*ImportReport, error) {
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, errors.New("only administrators are authorized"))
+	}
+	err := d.validate.Struct(opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongImportInput, err)
+	}
+	var rows []importRow
+	switch opts.Format {
+	case CSVImportFormat:
+		rows, err = readCSVRows(r, assetType)
+	case JSONLImportFormat:
+		rows, err = readJSONLRows(r, assetType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongImportInput, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrWrongImportInput, errors.New("the file has no rows"))
+	}
+
+	report := &ImportReport{AssetType: assetType, DryRun: opts.DryRun, Total: len(rows)}
+	assets := make([]InputAsset, len(rows))
+	for i, row := range rows {
+		result := ImportRowResult{Row: row.line, Status: ValidImportRow}
+		err := row.err
+		if err == nil {
+			assets[i] = InputAsset{Data: row.data, Visibility: opts.Visibility, Locale: opts.Locale}
+			err = d.validateAsset(&assets[i])
+		}
+		if err != nil {
+			result.Status = FailedImportRow
+			result.Error = err.Error()
+		} else {
+			prepareNewAsset(&assets[i])
+			report.Valid++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+	if opts.DryRun {
+		report.Failed = report.Total - report.Valid
+		return report, nil
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = len(rows)
+	}
+	for start := 0; start < len(rows); start += chunkSize {
+		end := start + chunkSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		chunk := report.Rows[start:end]
+		valid := true
+		for _, v := range chunk {
+			valid = valid && v.Status == ValidImportRow
+		}
+		if !valid {
+			for i := range chunk {
+				if chunk[i].Status == ValidImportRow {
+					chunk[i].Status = SkippedImportRow
+				}
+			}
+			continue
+		}
+		added, err := d.repo.AddAssets(ctx, scopeOf(user), assets[start:end])
+		if err == nil {
+			events := []WebhookEvent{}
+			for _, v := range added {
+				events = append(events, assetEvent(AssetCreatedEvent, user, v))
+			}
+			d.publishEvents(ctx, events...)
+		}
+		for i := range chunk {
+			if err != nil {
+				chunk[i].Status = FailedImportRow
+				chunk[i].Error = fmt.Errorf("%w: %v", ErrInternalDBFailure, err).Error()
+				continue
+			}
+			chunk[i].Status = ImportedImportRow
+			chunk[i].AssetID = added[i].ID
+		}
+	}
+	for _, v := range report.Rows {
+		switch v.Status {
+		case ImportedImportRow:
+			report.Imported++
+		case FailedImportRow:
+			report.Failed++
+		case SkippedImportRow:
+			report.Skipped++
+		}
+	}
+	return report, nil
+}
+
+// readJSONLRows reads a JSON object of the asset data from every non empty line.
+func readJSONLRows(r io.Reader, assetType AssetType) ([]importRow, error) {
+	rows := []importRow{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxImportLine)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(rows) == maxImportRows {
+			return nil, fmt.Errorf("the file has more than %d rows", maxImportRows)
+		}
+		data, err := newAssetData(assetType)
+		if err != nil {
+			return nil, err
+		}
+		dec := json.NewDecoder(bytes.NewReader(text))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(data)
+		rows = append(rows, importRow{line: line, data: data, err: err})
+	}
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// readCSVRows reads the asset data from the rows of a CSV file that has the JSON fields of the asset type
+// as header. Text fields are taken as they are, lists of texts can be separated with "|" and the other
+// fields are JSON values.
+func readCSVRows(r io.Reader, assetType AssetType) ([]importRow, error) {
+	data, err := newAssetData(assetType)
+	if err != nil {
+		return nil, err
+	}
+	fields := jsonFields(reflect.TypeOf(data).Elem())
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("header: %v", err)
+	}
+	columns := []reflect.Type{}
+	for _, name := range header {
+		t, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("column %s is not a field of %s", name, assetType)
+		}
+		columns = append(columns, t)
+	}
+
+	rows := []importRow{}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			rows = append(rows, importRow{line: perr.StartLine, err: perr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == maxImportRows {
+			return nil, fmt.Errorf("the file has more than %d rows", maxImportRows)
+		}
+		line, _ := cr.FieldPos(0)
+		data, _ := newAssetData(assetType)
+		err = decodeCSVRecord(header, columns, record, data)
+		rows = append(rows, importRow{line: line, data: data, err: err})
+	}
+	return rows, nil
+}
+
+// jsonFields returns the types of the fields of the struct by their JSON name.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+func decodeCSVRecord(header []string, columns []reflect.Type, record []string, data interface{}) error {
+	object := map[string]json.RawMessage{}
+	for i, cell := range record {
+		if cell == "" {
+			continue
+		}
+		t := columns[i]
+		switch {
+		case t.Kind() == reflect.String:
+			object[header[i]], _ = json.Marshal(cell)
+		case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String && !strings.HasPrefix(cell, "["):
+			object[header[i]], _ = json.Marshal(strings.Split(cell, "|"))
+		default:
+			if !json.Valid([]byte(cell)) {
+				return fmt.Errorf("column %s is not a JSON value", header[i])
+			}
+			object[header[i]] = json.RawMessage(cell)
+		}
+	}
+	b, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(b, data)
+	if err != nil {
+		return fmt.Errorf("wrong value: %v", err)
+	}
+	return nil
+}
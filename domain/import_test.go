@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func importMockDB(added *[]InputAsset) *MockDB {
+	mdb := &MockDB{}
+	mdb.addAssets = func(ctx context.Context, scope AssetScope, assets []InputAsset) ([]*Asset, error) {
+		newAssets := []*Asset{}
+		for _, a := range assets {
+			*added = append(*added, a)
+			newAssets = append(newAssets, &Asset{ID: uint(len(*added)), Status: a.Status, Data: a.Data})
+		}
+		return newAssets, nil
+	}
+	return mdb
+}
+
+func TestImportCSVAssetsSuccess(t *testing.T) {
+	added := []InputAsset{}
+	dom := NewDomain(importMockDB(&added))
+	ctx := context.Background()
+	usr := &User{ID: 1, Username: "manos", IsAdmin: true}
+	file := `ageMin,ageMax,countries,genders,hoursSpentRange,numberOfPurchases,description
+18,24,GR|France,male,"{""min"":1,""max"":3}",2,young gamers
+25,"34","[""DE""]",female,"{""min"":2,""max"":5}",1,adults
+`
+	report, err := dom.ImportAssets(ctx, usr, AudienceAssetType, strings.NewReader(file), ImportOptions{Format: CSVImportFormat})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Imported)
+	assert.Equal(t, 2, len(added))
+	assert.Equal(t, 2, report.Rows[0].Row)
+	assert.Equal(t, uint(2), report.Rows[1].AssetID)
+	au := added[0].Data.(*Audience)
+	assert.Equal(t, []string{"GR", "FR"}, au.Countries)
+	assert.Equal(t, 3, au.HoursSpentRange.Max)
+	assert.Equal(t, DraftStatus, added[0].Status)
+	assert.Equal(t, OrganizationVisibility, added[1].Visibility)
+}
+
+func TestImportAllOrNothingFailure(t *testing.T) {
+	added := []InputAsset{}
+	dom := NewDomain(importMockDB(&added))
+	ctx := context.Background()
+	usr := &User{ID: 1, Username: "manos", IsAdmin: true}
+	file := `{"text":"a","description":"b"}
+
+{"text":"c"}
+{"text":"d","description":"e","color":"red"}
+not json
+{"text":"f","description":"g"}
+`
+	report, err := dom.ImportAssets(ctx, usr, InsightAssetType, strings.NewReader(file), ImportOptions{Format: JSONLImportFormat})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(added))
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, 3, report.Failed)
+	assert.Equal(t, 2, report.Skipped)
+	assert.Equal(t, 3, report.Rows[1].Row)
+	assert.Equal(t, FailedImportRow, report.Rows[1].Status)
+	assert.NotEmpty(t, report.Rows[2].Error)
+
+	report, err = dom.ImportAssets(ctx, usr, InsightAssetType, strings.NewReader(file), ImportOptions{Format: JSONLImportFormat, ChunkSize: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(added))
+	assert.Equal(t, 2, report.Imported)
+	assert.Equal(t, 3, report.Failed)
+	assert.Equal(t, ImportedImportRow, report.Rows[4].Status)
+
+	report, err = dom.ImportAssets(ctx, usr, InsightAssetType, strings.NewReader(file), ImportOptions{Format: JSONLImportFormat, DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(added))
+	assert.Equal(t, 2, report.Valid)
+	assert.Equal(t, ValidImportRow, report.Rows[0].Status)
+}
+
+func TestImportWrongFileFailure(t *testing.T) {
+	added := []InputAsset{}
+	dom := NewDomain(importMockDB(&added))
+	ctx := context.Background()
+	admin := &User{ID: 1, Username: "manos", IsAdmin: true}
+	_, err := dom.ImportAssets(ctx, admin, InsightAssetType, strings.NewReader("text,color\na,b\n"), ImportOptions{Format: CSVImportFormat})
+	assert.ErrorIs(t, err, ErrWrongImportInput)
+	_, err = dom.ImportAssets(ctx, admin, InsightAssetType, strings.NewReader(""), ImportOptions{Format: JSONLImportFormat})
+	assert.ErrorIs(t, err, ErrWrongImportInput)
+	_, err = dom.ImportAssets(ctx, admin, InsightAssetType, strings.NewReader("text\na\n"), ImportOptions{Format: "xml"})
+	assert.ErrorIs(t, err, ErrWrongImportInput)
+	user := &User{ID: 2, Username: "maria"}
+	_, err = dom.ImportAssets(ctx, user, InsightAssetType, strings.NewReader("text\na\n"), ImportOptions{Format: CSVImportFormat})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkAssetsWrongRelationFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+	}
+	_, err := dom.LinkAssets(ctx, usr, 1, InsightAssetType, AssetLink{Relation: SupportedByRelation, ToType: AudienceAssetType, ToID: 1})
+	assert.ErrorIs(t, err, ErrWrongLinkInput)
+	_, err = dom.LinkAssets(ctx, usr, 1, ChartAssetType, AssetLink{Relation: AboutRelation, ToType: AudienceAssetType, ToID: 1})
+	assert.ErrorIs(t, err, ErrWrongLinkInput)
+	_, err = dom.LinkAssets(ctx, usr, 1, InsightAssetType, AssetLink{Relation: RelationType("likes"), ToType: ChartAssetType, ToID: 1})
+	assert.ErrorIs(t, err, ErrWrongLinkInput)
+}
+
+func TestLinkAssetsSuccess(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return &Asset{ID: assetID, Visibility: OrganizationVisibility}, nil
+	}
+	mdb.addAssetLink = func(ctx context.Context, link AssetLink) (*AssetLink, error) {
+		link.ID = 1
+		return &link, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+	}
+	link, err := dom.LinkAssets(ctx, usr, 3, InsightAssetType, AssetLink{Relation: SupportedByRelation, ToType: ChartAssetType, ToID: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, InsightAssetType, link.FromType)
+	assert.Equal(t, uint(3), link.FromID)
+}
+
+func TestGetAssetHidesInvisibleLinks(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		if at == AudienceAssetType {
+			return nil, errors.New("record not found")
+		}
+		return &Asset{ID: assetID, Visibility: OrganizationVisibility}, nil
+	}
+	mdb.listAssetLinks = func(ctx context.Context, at AssetType, assetID uint) ([]AssetLink, error) {
+		return []AssetLink{
+			{ID: 1, Relation: SupportedByRelation, FromType: InsightAssetType, FromID: assetID, ToType: ChartAssetType, ToID: 2},
+			{ID: 2, Relation: AboutRelation, FromType: InsightAssetType, FromID: assetID, ToType: AudienceAssetType, ToID: 3},
+		}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+	}
+	asset, err := dom.GetAsset(ctx, usr, 1, InsightAssetType)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(asset.Links))
+	assert.Equal(t, ChartAssetType, asset.Links[0].ToType)
+}
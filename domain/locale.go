@@ -0,0 +1,29 @@
+package domain
+
+import "context"
+
+type languagesKey struct{}
+
+// WithLanguages returns a context with the languages that the user prefers, most preferred first.
+// The assets that are fetched with it have their texts in the first of them that they are translated to.
+func WithLanguages(ctx context.Context, languages []string) context.Context {
+	return context.WithValue(ctx, languagesKey{}, languages)
+}
+
+func languagesFrom(ctx context.Context) []string {
+	languages, _ := ctx.Value(languagesKey{}).([]string)
+	return languages
+}
+
+// validLocale checks that the locale is a lowercase two letter code.
+func validLocale(locale string) bool {
+	if len(locale) != 2 {
+		return false
+	}
+	for _, r := range locale {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
@@ -1,35 +1,118 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
 type MockDB struct {
-	addAsset    func(ctx context.Context, asset InputAsset) (*Asset, error)
-	updateAsset func(ctx context.Context, assetID uint, asset InputAsset) (*Asset, error)
-	userExists  func(ctx context.Context, username string) (bool, error)
-	addUser     func(ctx context.Context, user User) (*User, error)
-	findUser    func(ctx context.Context, username string) (*User, error)
+	addAsset              func(ctx context.Context, scope AssetScope, asset InputAsset) (*Asset, error)
+	updateAsset           func(ctx context.Context, scope AssetScope, assetID uint, asset InputAsset) (*Asset, error)
+	getAsset              func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error)
+	listVisibleAssets     func(ctx context.Context, scope AssetScope, at AssetType, assetIDs []uint) ([]Asset, error)
+	userExists            func(ctx context.Context, username string) (bool, error)
+	addUser               func(ctx context.Context, user User) (*User, error)
+	findUser              func(ctx context.Context, username string) (*User, error)
+	addOrganization       func(ctx context.Context, org Organization) (*Organization, error)
+	isOrganizationMember  func(ctx context.Context, orgID, userID uint) (bool, error)
+	isOrganizationAdmin   func(ctx context.Context, orgID, userID uint) (bool, error)
+	addOrganizationMember func(ctx context.Context, orgID, userID uint, isAdmin bool) error
+	getGroup              func(ctx context.Context, groupID uint) (*Group, error)
+	addAssetGrant         func(ctx context.Context, at AssetType, assetID uint, grant AssetGrant) (*AssetGrant, error)
+	getCollection         func(ctx context.Context, userID, collectionID uint) (*Collection, error)
+	addCollectionItem     func(ctx context.Context, collectionID uint, item CollectionItem) (*CollectionItem, error)
+	setFavouriteNote      func(ctx context.Context, userID, assetID uint, at AssetType, note FavouriteNote) (bool, error)
+	getTag                func(ctx context.Context, orgID, tagID uint) (*Tag, error)
+	mergeTags             func(ctx context.Context, orgID, fromTagID, intoTagID uint) error
+	addAssetLink          func(ctx context.Context, link AssetLink) (*AssetLink, error)
+	listAssetLinks        func(ctx context.Context, at AssetType, assetID uint) ([]AssetLink, error)
+	setAssetStatus        func(ctx context.Context, at AssetType, assetID uint, status AssetStatus, approvedBy uint) error
+	setAssetEditor        func(ctx context.Context, at AssetType, assetID uint, editorID uint) error
+	removeTranslation     func(ctx context.Context, at AssetType, assetID uint, locale string) (bool, error)
+	publishScheduled      func(ctx context.Context, now time.Time) (int64, error)
+	archiveExpired        func(ctx context.Context, now time.Time) (int64, error)
+	listFavouriteAssets   func(ctx context.Context, scope AssetScope, userID uint, onlyFav bool, query QueryAssets) (*ListedAssets, error)
+	setAssetTranslation   func(ctx context.Context, translation AssetTranslation) (*AssetTranslation, error)
+	listTranslations      func(ctx context.Context, at AssetType, assetIDs []uint) ([]AssetTranslation, error)
+	addAssets             func(ctx context.Context, scope AssetScope, assets []InputAsset) ([]*Asset, error)
+	listAssets            func(ctx context.Context, scope AssetScope, query QueryAssets) (*ListedAssets, error)
+	favouriteAsset        func(ctx context.Context, scope AssetScope, userID, assetID uint, at AssetType, isFavourite bool) (bool, error)
+	listTrendingAssets    func(ctx context.Context, scope AssetScope, at AssetType, since time.Time, limit int) ([]TrendingAsset, error)
+	listFavourites        func(ctx context.Context, userID uint) ([]FavouriteItem, error)
+	listAllFavourites     func(ctx context.Context) ([]UserFavourite, error)
+	listLiveAudiences     func(ctx context.Context) ([]Asset, error)
+	replaceSimilarities   func(ctx context.Context, similarities []AssetSimilarity) error
+	listSimilarities      func(ctx context.Context, items []FavouriteItem) ([]AssetSimilarity, error)
+	addAssetViews         func(ctx context.Context, views []AssetView) error
+	listRecentViews       func(ctx context.Context, userID uint, limit int) ([]RecentAsset, error)
+	addComment            func(ctx context.Context, comment Comment, mentionIDs []uint) (*Comment, error)
+	getComment            func(ctx context.Context, at AssetType, assetID, commentID uint) (*Comment, error)
+	updateComment         func(ctx context.Context, commentID uint, text string, mentionIDs []uint) (*Comment, error)
+	listComments          func(ctx context.Context, at AssetType, assetID uint, query QueryComments) (*ListedComments, error)
+	listMentions          func(ctx context.Context, userID uint, query QueryComments) (*ListedComments, error)
+	rateAsset             func(ctx context.Context, userID uint, at AssetType, assetID uint, rating int) (*AssetFeedback, error)
+	flagAsset             func(ctx context.Context, flag AssetFlag) (*AssetFlag, error)
+	listFlaggedAssets     func(ctx context.Context, orgID uint, at AssetType, limit int) ([]FlaggedAsset, error)
+	listOpenFlags         func(ctx context.Context, at AssetType, assetID uint) ([]AssetFlag, error)
+	resolveFlags          func(ctx context.Context, at AssetType, assetID uint, reason FlagReason, resolvedBy uint) (int64, error)
+	addWebhook            func(ctx context.Context, hook Webhook) (*Webhook, error)
+	getWebhook            func(ctx context.Context, webhookID uint) (*Webhook, error)
+	updateWebhook         func(ctx context.Context, hook Webhook) (*Webhook, error)
+	listWebhooks          func(ctx context.Context, orgID uint) ([]Webhook, error)
+	addWebhookDeliveries  func(ctx context.Context, deliveries []WebhookDelivery) ([]WebhookDelivery, error)
+	getWebhookDelivery    func(ctx context.Context, webhookID, deliveryID uint) (*WebhookDelivery, error)
+	updateWebhookDelivery func(ctx context.Context, delivery WebhookDelivery) error
+	listDueDeliveries     func(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error)
+	favouriteAssets       func(ctx context.Context, scope AssetScope, userID uint, items []FavouriteItem, isFavourite bool) ([]FavouriteResult, error)
 }
 
-func (d *MockDB) AddAsset(ctx context.Context, asset InputAsset) (*Asset, error) {
-	return d.addAsset(ctx, asset)
+func (d *MockDB) AddAsset(ctx context.Context, scope AssetScope, asset InputAsset) (*Asset, error) {
+	return d.addAsset(ctx, scope, asset)
 }
-func (d *MockDB) DeleteAsset(ctx context.Context, at AssetType, assetID uint) error {
+func (d *MockDB) DeleteAsset(ctx context.Context, scope AssetScope, at AssetType, assetID uint) error {
 	return nil
 }
-func (d *MockDB) UpdateAsset(ctx context.Context, assetID uint, asset InputAsset) (*Asset, error) {
-	return d.updateAsset(ctx, assetID, asset)
+func (d *MockDB) UpdateAsset(ctx context.Context, scope AssetScope, assetID uint, asset InputAsset) (*Asset, error) {
+	return d.updateAsset(ctx, scope, assetID, asset)
 }
-func (d *MockDB) GetAsset(ctx context.Context, at AssetType, assetID uint) (*Asset, error) {
-	return nil, nil
+func (d *MockDB) GetAsset(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+	if d.getAsset == nil {
+		return nil, errors.New("record not found")
+	}
+	return d.getAsset(ctx, scope, at, assetID)
 }
-func (d *MockDB) ListAssets(ctx context.Context, query QueryAssets) (*ListedAssets, error) {
-	return nil, nil
+func (d *MockDB) ListVisibleAssets(ctx context.Context, scope AssetScope, at AssetType, assetIDs []uint) ([]Asset, error) {
+	if d.listVisibleAssets == nil {
+		return []Asset{}, nil
+	}
+	return d.listVisibleAssets(ctx, scope, at, assetIDs)
 }
-func (d *MockDB) FavouriteAsset(ctx context.Context, userID, assetID uint, at AssetType, isFavourite bool) (uint, error) {
-	return 0, nil
+func (d *MockDB) ListAssets(ctx context.Context, scope AssetScope, query QueryAssets) (*ListedAssets, error) {
+	if d.listAssets == nil {
+		return nil, nil
+	}
+	return d.listAssets(ctx, scope, query)
 }
-func (d *MockDB) ListFavouriteAssets(ctx context.Context, userID uint, onlyFav bool, query QueryAssets) (*ListedAssets, error) {
-	return nil, nil
+func (d *MockDB) FavouriteAsset(ctx context.Context, scope AssetScope, userID, assetID uint, at AssetType, isFavourite bool) (bool, error) {
+	if d.favouriteAsset == nil {
+		return false, nil
+	}
+	return d.favouriteAsset(ctx, scope, userID, assetID, at, isFavourite)
+}
+func (d *MockDB) ListFavouriteAssets(ctx context.Context, scope AssetScope, userID uint, onlyFav bool, query QueryAssets) (*ListedAssets, error) {
+	if d.listFavouriteAssets == nil {
+		return nil, nil
+	}
+	return d.listFavouriteAssets(ctx, scope, userID, onlyFav, query)
+}
+
+func (d *MockDB) ListTrendingAssets(ctx context.Context, scope AssetScope, at AssetType, since time.Time, limit int) ([]TrendingAsset, error) {
+	return d.listTrendingAssets(ctx, scope, at, since, limit)
+}
+
+func (d *MockDB) SetFavouriteNote(ctx context.Context, userID, assetID uint, at AssetType, note FavouriteNote) (bool, error) {
+	return d.setFavouriteNote(ctx, userID, assetID, at, note)
 }
 
 func (d *MockDB) RemoveFavouriteAssetFromEveryone(ctx context.Context, assetID uint, at AssetType) error {
@@ -47,3 +130,282 @@ func (d *MockDB) UserExists(ctx context.Context, username string) (bool, error)
 func (d *MockDB) GetUser(ctx context.Context, userID uint) (*User, error) {
 	return nil, nil
 }
+func (d *MockDB) AddOrganization(ctx context.Context, org Organization) (*Organization, error) {
+	return d.addOrganization(ctx, org)
+}
+func (d *MockDB) GetOrganization(ctx context.Context, orgID uint) (*Organization, error) {
+	return nil, nil
+}
+func (d *MockDB) AddOrganizationMember(ctx context.Context, orgID, userID uint, isAdmin bool) error {
+	if d.addOrganizationMember == nil {
+		return nil
+	}
+	return d.addOrganizationMember(ctx, orgID, userID, isAdmin)
+}
+func (d *MockDB) IsOrganizationMember(ctx context.Context, orgID, userID uint) (bool, error) {
+	return d.isOrganizationMember(ctx, orgID, userID)
+}
+func (d *MockDB) IsOrganizationAdmin(ctx context.Context, orgID, userID uint) (bool, error) {
+	if d.isOrganizationAdmin == nil {
+		return false, nil
+	}
+	return d.isOrganizationAdmin(ctx, orgID, userID)
+}
+func (d *MockDB) ListUserOrganizations(ctx context.Context, userID uint) ([]Organization, error) {
+	return nil, nil
+}
+func (d *MockDB) AddGroup(ctx context.Context, group Group) (*Group, error) {
+	return nil, nil
+}
+func (d *MockDB) GetGroup(ctx context.Context, groupID uint) (*Group, error) {
+	if d.getGroup == nil {
+		return nil, nil
+	}
+	return d.getGroup(ctx, groupID)
+}
+func (d *MockDB) AddGroupMember(ctx context.Context, groupID, userID uint) error {
+	return nil
+}
+func (d *MockDB) AddAssetGrant(ctx context.Context, at AssetType, assetID uint, grant AssetGrant) (*AssetGrant, error) {
+	return d.addAssetGrant(ctx, at, assetID, grant)
+}
+func (d *MockDB) RemoveAssetGrant(ctx context.Context, at AssetType, assetID, grantID uint) error {
+	return nil
+}
+func (d *MockDB) ListAssetGrants(ctx context.Context, at AssetType, assetID uint) ([]AssetGrant, error) {
+	return nil, nil
+}
+func (d *MockDB) RemoveAssetGrants(ctx context.Context, at AssetType, assetID uint) error {
+	return nil
+}
+func (d *MockDB) AddCollection(ctx context.Context, userID uint, collection Collection) (*Collection, error) {
+	return nil, nil
+}
+func (d *MockDB) GetCollection(ctx context.Context, userID, collectionID uint) (*Collection, error) {
+	return d.getCollection(ctx, userID, collectionID)
+}
+func (d *MockDB) UpdateCollection(ctx context.Context, userID, collectionID uint, collection Collection) (*Collection, error) {
+	return nil, nil
+}
+func (d *MockDB) DeleteCollection(ctx context.Context, userID, collectionID uint) error {
+	return nil
+}
+func (d *MockDB) ListCollections(ctx context.Context, userID uint) ([]Collection, error) {
+	return nil, nil
+}
+func (d *MockDB) AddCollectionItem(ctx context.Context, collectionID uint, item CollectionItem) (*CollectionItem, error) {
+	return d.addCollectionItem(ctx, collectionID, item)
+}
+func (d *MockDB) RemoveCollectionItem(ctx context.Context, collectionID, itemID uint) error {
+	return nil
+}
+func (d *MockDB) ReorderCollectionItems(ctx context.Context, collectionID uint, itemIDs []uint) error {
+	return nil
+}
+func (d *MockDB) ListCollectionItems(ctx context.Context, collectionID uint, query QueryCollectionItems) (*ListedCollectionItems, error) {
+	return nil, nil
+}
+func (d *MockDB) RemoveAssetFromCollections(ctx context.Context, at AssetType, assetID uint) error {
+	return nil
+}
+func (d *MockDB) AddTag(ctx context.Context, orgID uint, tag Tag) (*Tag, error) {
+	return nil, nil
+}
+func (d *MockDB) GetTag(ctx context.Context, orgID, tagID uint) (*Tag, error) {
+	return d.getTag(ctx, orgID, tagID)
+}
+func (d *MockDB) UpdateTag(ctx context.Context, orgID, tagID uint, tag Tag) (*Tag, error) {
+	return nil, nil
+}
+func (d *MockDB) MergeTags(ctx context.Context, orgID, fromTagID, intoTagID uint) error {
+	return d.mergeTags(ctx, orgID, fromTagID, intoTagID)
+}
+func (d *MockDB) DeleteTag(ctx context.Context, orgID, tagID uint) error {
+	return nil
+}
+func (d *MockDB) SetAssetTags(ctx context.Context, at AssetType, assetID uint, tagIDs []uint) error {
+	return nil
+}
+func (d *MockDB) ListTags(ctx context.Context, scope AssetScope, at AssetType) ([]Tag, error) {
+	return nil, nil
+}
+func (d *MockDB) RemoveAssetTags(ctx context.Context, at AssetType, assetID uint) error {
+	return nil
+}
+func (d *MockDB) AddAssetLink(ctx context.Context, link AssetLink) (*AssetLink, error) {
+	return d.addAssetLink(ctx, link)
+}
+func (d *MockDB) RemoveAssetLink(ctx context.Context, at AssetType, assetID, linkID uint) error {
+	return nil
+}
+func (d *MockDB) ListAssetLinks(ctx context.Context, at AssetType, assetID uint) ([]AssetLink, error) {
+	if d.listAssetLinks == nil {
+		return nil, nil
+	}
+	return d.listAssetLinks(ctx, at, assetID)
+}
+func (d *MockDB) RemoveAssetLinks(ctx context.Context, at AssetType, assetID uint) error {
+	return nil
+}
+func (d *MockDB) SetAssetStatus(ctx context.Context, at AssetType, assetID uint, status AssetStatus, approvedBy uint) error {
+	if d.setAssetStatus == nil {
+		return nil
+	}
+	return d.setAssetStatus(ctx, at, assetID, status, approvedBy)
+}
+func (d *MockDB) SetAssetEditor(ctx context.Context, at AssetType, assetID uint, editorID uint) error {
+	if d.setAssetEditor == nil {
+		return nil
+	}
+	return d.setAssetEditor(ctx, at, assetID, editorID)
+}
+func (d *MockDB) PublishScheduledAssets(ctx context.Context, now time.Time) (int64, error) {
+	return d.publishScheduled(ctx, now)
+}
+func (d *MockDB) ArchiveExpiredAssets(ctx context.Context, now time.Time) (int64, error) {
+	return d.archiveExpired(ctx, now)
+}
+func (d *MockDB) SetAssetTranslation(ctx context.Context, translation AssetTranslation) (*AssetTranslation, error) {
+	return d.setAssetTranslation(ctx, translation)
+}
+func (d *MockDB) RemoveAssetTranslation(ctx context.Context, at AssetType, assetID uint, locale string) (bool, error) {
+	if d.removeTranslation == nil {
+		return true, nil
+	}
+	return d.removeTranslation(ctx, at, assetID, locale)
+}
+func (d *MockDB) ListAssetTranslations(ctx context.Context, at AssetType, assetIDs []uint) ([]AssetTranslation, error) {
+	if d.listTranslations == nil {
+		return nil, nil
+	}
+	return d.listTranslations(ctx, at, assetIDs)
+}
+func (d *MockDB) RemoveAssetTranslations(ctx context.Context, at AssetType, assetID uint) error {
+	return nil
+}
+func (d *MockDB) AddAssets(ctx context.Context, scope AssetScope, assets []InputAsset) ([]*Asset, error) {
+	return d.addAssets(ctx, scope, assets)
+}
+func (d *MockDB) FavouriteAssets(ctx context.Context, scope AssetScope, userID uint, items []FavouriteItem, isFavourite bool) ([]FavouriteResult, error) {
+	return d.favouriteAssets(ctx, scope, userID, items, isFavourite)
+}
+func (d *MockDB) ListFavourites(ctx context.Context, userID uint) ([]FavouriteItem, error) {
+	return d.listFavourites(ctx, userID)
+}
+func (d *MockDB) ListAllFavourites(ctx context.Context) ([]UserFavourite, error) {
+	return d.listAllFavourites(ctx)
+}
+func (d *MockDB) ListLiveAudiences(ctx context.Context) ([]Asset, error) {
+	if d.listLiveAudiences == nil {
+		return nil, nil
+	}
+	return d.listLiveAudiences(ctx)
+}
+func (d *MockDB) ReplaceAssetSimilarities(ctx context.Context, similarities []AssetSimilarity) error {
+	return d.replaceSimilarities(ctx, similarities)
+}
+func (d *MockDB) ListAssetSimilarities(ctx context.Context, items []FavouriteItem) ([]AssetSimilarity, error) {
+	if d.listSimilarities == nil {
+		return nil, nil
+	}
+	return d.listSimilarities(ctx, items)
+}
+func (d *MockDB) RemoveAssetSimilarities(ctx context.Context, at AssetType, assetID uint) error {
+	return nil
+}
+func (d *MockDB) AddAssetViews(ctx context.Context, views []AssetView) error {
+	return d.addAssetViews(ctx, views)
+}
+func (d *MockDB) ListRecentViews(ctx context.Context, userID uint, limit int) ([]RecentAsset, error) {
+	return d.listRecentViews(ctx, userID, limit)
+}
+func (d *MockDB) GetAssetViewStats(ctx context.Context, at AssetType, assetID uint) (*AssetViewStats, error) {
+	return &AssetViewStats{AssetType: at, AssetID: assetID}, nil
+}
+func (d *MockDB) PurgeAssetViews(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+func (d *MockDB) RemoveAssetViews(ctx context.Context, at AssetType, assetID uint) error {
+	return nil
+}
+func (d *MockDB) AddComment(ctx context.Context, comment Comment, mentionIDs []uint) (*Comment, error) {
+	return d.addComment(ctx, comment, mentionIDs)
+}
+func (d *MockDB) GetComment(ctx context.Context, at AssetType, assetID, commentID uint) (*Comment, error) {
+	return d.getComment(ctx, at, assetID, commentID)
+}
+func (d *MockDB) UpdateComment(ctx context.Context, commentID uint, text string, mentionIDs []uint) (*Comment, error) {
+	return d.updateComment(ctx, commentID, text, mentionIDs)
+}
+func (d *MockDB) RemoveComment(ctx context.Context, commentID uint) error {
+	return nil
+}
+func (d *MockDB) SetCommentHidden(ctx context.Context, commentID uint, hidden bool) error {
+	return nil
+}
+func (d *MockDB) ListComments(ctx context.Context, at AssetType, assetID uint, query QueryComments) (*ListedComments, error) {
+	return d.listComments(ctx, at, assetID, query)
+}
+func (d *MockDB) ListMentions(ctx context.Context, userID uint, query QueryComments) (*ListedComments, error) {
+	return d.listMentions(ctx, userID, query)
+}
+func (d *MockDB) RemoveAssetComments(ctx context.Context, at AssetType, assetID uint) error {
+	return nil
+}
+func (d *MockDB) RateAsset(ctx context.Context, userID uint, at AssetType, assetID uint, rating int) (*AssetFeedback, error) {
+	return d.rateAsset(ctx, userID, at, assetID, rating)
+}
+func (d *MockDB) UnrateAsset(ctx context.Context, userID uint, at AssetType, assetID uint) (*AssetFeedback, error) {
+	return &AssetFeedback{}, nil
+}
+func (d *MockDB) FlagAsset(ctx context.Context, flag AssetFlag) (*AssetFlag, error) {
+	return d.flagAsset(ctx, flag)
+}
+func (d *MockDB) ListFlaggedAssets(ctx context.Context, orgID uint, at AssetType, limit int) ([]FlaggedAsset, error) {
+	return d.listFlaggedAssets(ctx, orgID, at, limit)
+}
+func (d *MockDB) ListOpenFlags(ctx context.Context, at AssetType, assetID uint) ([]AssetFlag, error) {
+	if d.listOpenFlags == nil {
+		return []AssetFlag{}, nil
+	}
+	return d.listOpenFlags(ctx, at, assetID)
+}
+func (d *MockDB) ResolveFlags(ctx context.Context, at AssetType, assetID uint, reason FlagReason, resolvedBy uint) (int64, error) {
+	return d.resolveFlags(ctx, at, assetID, reason, resolvedBy)
+}
+func (d *MockDB) RemoveAssetFeedback(ctx context.Context, at AssetType, assetID uint) error {
+	return nil
+}
+func (d *MockDB) AddWebhook(ctx context.Context, hook Webhook) (*Webhook, error) {
+	return d.addWebhook(ctx, hook)
+}
+func (d *MockDB) GetWebhook(ctx context.Context, webhookID uint) (*Webhook, error) {
+	return d.getWebhook(ctx, webhookID)
+}
+func (d *MockDB) UpdateWebhook(ctx context.Context, hook Webhook) (*Webhook, error) {
+	return d.updateWebhook(ctx, hook)
+}
+func (d *MockDB) RemoveWebhook(ctx context.Context, webhookID uint) error {
+	return nil
+}
+func (d *MockDB) ListWebhooks(ctx context.Context, orgID uint) ([]Webhook, error) {
+	if d.listWebhooks == nil {
+		return []Webhook{}, nil
+	}
+	return d.listWebhooks(ctx, orgID)
+}
+func (d *MockDB) AddWebhookDeliveries(ctx context.Context, deliveries []WebhookDelivery) ([]WebhookDelivery, error) {
+	return d.addWebhookDeliveries(ctx, deliveries)
+}
+func (d *MockDB) GetWebhookDelivery(ctx context.Context, webhookID, deliveryID uint) (*WebhookDelivery, error) {
+	return d.getWebhookDelivery(ctx, webhookID, deliveryID)
+}
+func (d *MockDB) UpdateWebhookDelivery(ctx context.Context, delivery WebhookDelivery) error {
+	return d.updateWebhookDelivery(ctx, delivery)
+}
+func (d *MockDB) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error) {
+	return d.listDueDeliveries(ctx, now, limit)
+}
+func (d *MockDB) ListWebhookDeliveries(ctx context.Context, webhookID uint, query QueryDeliveries) (*ListedDeliveries, error) {
+	return &ListedDeliveries{Limit: query.Limit, Deliveries: []WebhookDelivery{}}, nil
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetFavouriteNoteWrongInputFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+	}
+	_, err := dom.SetFavouriteNote(ctx, usr, 1, ChartAssetType, FavouriteNote{Text: strings.Repeat("a", 10001)})
+	assert.ErrorIs(t, err, ErrWrongNoteInput)
+	_, err = dom.SetFavouriteNote(ctx, nil, 1, ChartAssetType, FavouriteNote{Text: "why"})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestSetFavouriteNoteNotFavouriteFailure(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.setFavouriteNote = func(ctx context.Context, userID, assetID uint, at AssetType, note FavouriteNote) (bool, error) {
+		return false, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+	}
+	_, err := dom.SetFavouriteNote(ctx, usr, 1, ChartAssetType, FavouriteNote{Text: "why"})
+	assert.ErrorIs(t, err, ErrFavouriteNotFound)
+}
+
+func TestSetFavouriteNoteSuccess(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.setFavouriteNote = func(ctx context.Context, userID, assetID uint, at AssetType, note FavouriteNote) (bool, error) {
+		return true, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+	}
+	note, err := dom.SetFavouriteNote(ctx, usr, 1, ChartAssetType, FavouriteNote{Text: "**useful** for the Q3 report"})
+	assert.NoError(t, err)
+	assert.Equal(t, "**useful** for the Q3 report", note.Text)
+	assert.False(t, note.UpdatedAt.IsZero())
+}
@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateOrganizationUnauthorizedFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  false,
+	}
+	_, err := dom.CreateOrganization(ctx, usr, Organization{Name: "GWI"})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	_, err = dom.CreateOrganization(ctx, nil, Organization{Name: "GWI"})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestCreateOrganizationWrongInputFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+	}
+	_, err := dom.CreateOrganization(ctx, usr, Organization{Name: ""})
+	assert.ErrorIs(t, err, ErrWrongOrganizationInput)
+}
+
+func TestCreateOrganizationSuccess(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.addOrganization = func(ctx context.Context, org Organization) (*Organization, error) {
+		org.ID = 1
+		return &org, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+	}
+	var admins []uint
+	mdb.addOrganizationMember = func(ctx context.Context, orgID, userID uint, isAdmin bool) error {
+		assert.Equal(t, uint(1), orgID)
+		assert.True(t, isAdmin)
+		admins = append(admins, userID)
+		return nil
+	}
+	org, err := dom.CreateOrganization(ctx, usr, Organization{Name: "GWI"})
+	assert.NoError(t, err)
+	assert.NotNil(t, org)
+	assert.Equal(t, uint(1), org.ID)
+	assert.Equal(t, "GWI", org.Name)
+	assert.Equal(t, []uint{1}, admins)
+}
+
+func TestAddOrganizationMemberOfOtherOrganizationFailure(t *testing.T) {
+	mdb := &MockDB{}
+	// The user is an administrator of organization 1 only.
+	mdb.isOrganizationAdmin = func(ctx context.Context, orgID, userID uint) (bool, error) {
+		return orgID == 1 && userID == 1, nil
+	}
+	mdb.isOrganizationMember = func(ctx context.Context, orgID, userID uint) (bool, error) {
+		return false, nil
+	}
+	added := false
+	mdb.addOrganizationMember = func(ctx context.Context, orgID, userID uint, isAdmin bool) error {
+		added = true
+		return nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+		OrgID:    1,
+	}
+	err := dom.AddOrganizationMember(ctx, usr, 2, OrganizationMember{UserID: 1, IsAdmin: true})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.False(t, added)
+
+	err = dom.AddOrganizationMember(ctx, usr, 1, OrganizationMember{UserID: 2})
+	assert.NoError(t, err)
+	assert.True(t, added)
+}
+
+func TestLoginUserNotMemberOfOrganizationFailure(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.findUser = func(ctx context.Context, username string) (*User, error) {
+		hs, _ := hashPassword("secret")
+		return &User{Username: "manos", Password: hs, ID: 1}, nil
+	}
+	mdb.isOrganizationMember = func(ctx context.Context, orgID, userID uint) (bool, error) {
+		return false, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	_, err := dom.LoginUser(ctx, LoginCredentials{
+		Username: "manos",
+		Password: "secret",
+		OrgID:    2,
+	})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestLoginUserWithOrganizationSuccess(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.findUser = func(ctx context.Context, username string) (*User, error) {
+		hs, _ := hashPassword("secret")
+		return &User{Username: "manos", Password: hs, ID: 1}, nil
+	}
+	mdb.isOrganizationMember = func(ctx context.Context, orgID, userID uint) (bool, error) {
+		return orgID == 2 && userID == 1, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	user, err := dom.LoginUser(ctx, LoginCredentials{
+		Username: "manos",
+		Password: "secret",
+		OrgID:    2,
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, user)
+	assert.Equal(t, uint(2), user.OrgID)
+}
+
+func TestAddAssetUsesOrganizationScope(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.addAsset = func(ctx context.Context, scope AssetScope, asset InputAsset) (*Asset, error) {
+		assert.Equal(t, uint(3), scope.OrgID)
+		return &Asset{ID: 1, Data: asset.Data}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+		OrgID:    3,
+	}
+	_, err := dom.AddAsset(ctx, usr, InputAsset{Data: CorrectInputTestAssetData[0]})
+	assert.NoError(t, err)
+}
+
+func TestLoginUserTakesAdminRightsFromMembership(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.findUser = func(ctx context.Context, username string) (*User, error) {
+		hs, _ := hashPassword("secret")
+		// manos is a global administrator and nikos is not
+		return &User{Username: username, Password: hs, ID: map[string]uint{"manos": 1, "nikos": 2}[username], IsAdmin: username == "manos"}, nil
+	}
+	mdb.isOrganizationMember = func(ctx context.Context, orgID, userID uint) (bool, error) {
+		return true, nil
+	}
+	// manos is a plain member of the organization 2 and nikos is its administrator
+	mdb.isOrganizationAdmin = func(ctx context.Context, orgID, userID uint) (bool, error) {
+		return orgID == 2 && userID == 2, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	user, err := dom.LoginUser(ctx, LoginCredentials{Username: "manos", Password: "secret", OrgID: 2})
+	assert.NoError(t, err)
+	assert.False(t, user.IsAdmin)
+	_, err = dom.AddAsset(ctx, user, InputAsset{Data: CorrectInputTestAssetData[0]})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	user, err = dom.LoginUser(ctx, LoginCredentials{Username: "nikos", Password: "secret", OrgID: 2})
+	assert.NoError(t, err)
+	assert.True(t, user.IsAdmin)
+
+	// the global rights are kept in the default organization
+	user, err = dom.LoginUser(ctx, LoginCredentials{Username: "manos", Password: "secret"})
+	assert.NoError(t, err)
+	assert.True(t, user.IsAdmin)
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeSimilarities(t *testing.T) {
+	chart := FavouriteItem{AssetType: ChartAssetType, AssetID: 1}
+	insight := FavouriteItem{AssetType: InsightAssetType, AssetID: 1}
+	other := FavouriteItem{AssetType: InsightAssetType, AssetID: 2}
+	favourites := []UserFavourite{
+		{UserID: 1, FavouriteItem: chart},
+		{UserID: 1, FavouriteItem: insight},
+		{UserID: 2, FavouriteItem: chart},
+		{UserID: 2, FavouriteItem: insight},
+		{UserID: 3, FavouriteItem: chart},
+		{UserID: 3, FavouriteItem: other},
+	}
+	audience := Audience{AgeMin: 20, AgeMax: 30, Gender: FemaleGenderType, Country: "GR", HoursSpent: 3, NumberOfPurchases: 3}
+	similar := audience
+	similar.AgeMax = 35
+	different := Audience{AgeMin: 50, AgeMax: 60, Gender: MaleGenderType, Country: "SE", HoursSpent: 10, NumberOfPurchases: 10}
+	audiences := []Asset{{ID: 1, Data: &audience}, {ID: 2, Data: &similar}, {ID: 3, Data: &different}}
+
+	similarities := computeSimilarities(favourites, audiences)
+	scores := map[assetPair]float64{}
+	for _, v := range similarities {
+		scores[assetPair{FavouriteItem{AssetType: v.AssetType, AssetID: v.AssetID}, FavouriteItem{AssetType: v.RelatedType, AssetID: v.RelatedID}}] = v.Score
+	}
+	// the chart is favoured by 3 users and the insight by 2 of them
+	assert.InDelta(t, 2/2.449489742783178, scores[assetPair{chart, insight}], 0.0001)
+	assert.Equal(t, scores[assetPair{chart, insight}], scores[assetPair{insight, chart}])
+	assert.InDelta(t, 1/1.7320508075688772, scores[assetPair{chart, other}], 0.0001)
+	_, ok := scores[assetPair{insight, other}]
+	assert.False(t, ok)
+
+	first := FavouriteItem{AssetType: AudienceAssetType, AssetID: 1}
+	second := FavouriteItem{AssetType: AudienceAssetType, AssetID: 2}
+	third := FavouriteItem{AssetType: AudienceAssetType, AssetID: 3}
+	assert.Greater(t, scores[assetPair{first, second}], audienceSimilarityWeight*minAudienceSimilarity)
+	_, ok = scores[assetPair{first, third}]
+	assert.False(t, ok)
+}
+
+func TestRecommendations(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.listFavourites = func(ctx context.Context, userID uint) ([]FavouriteItem, error) {
+		return []FavouriteItem{{AssetType: ChartAssetType, AssetID: 1}, {AssetType: ChartAssetType, AssetID: 2}}, nil
+	}
+	mdb.listSimilarities = func(ctx context.Context, items []FavouriteItem) ([]AssetSimilarity, error) {
+		return []AssetSimilarity{
+			{AssetType: ChartAssetType, AssetID: 1, RelatedType: ChartAssetType, RelatedID: 2, Score: 1},
+			{AssetType: ChartAssetType, AssetID: 1, RelatedType: InsightAssetType, RelatedID: 1, Score: 0.5},
+			{AssetType: ChartAssetType, AssetID: 2, RelatedType: InsightAssetType, RelatedID: 1, Score: 0.5},
+			{AssetType: ChartAssetType, AssetID: 1, RelatedType: InsightAssetType, RelatedID: 2, Score: 0.8},
+			{AssetType: ChartAssetType, AssetID: 2, RelatedType: InsightAssetType, RelatedID: 3, Score: 0.7},
+		}, nil
+	}
+	queries := 0
+	mdb.listVisibleAssets = func(ctx context.Context, scope AssetScope, at AssetType, assetIDs []uint) ([]Asset, error) {
+		queries++
+		assets := []Asset{}
+		for _, id := range assetIDs {
+			// the third insight is not visible to the user
+			if id != 3 {
+				assets = append(assets, Asset{ID: id, Data: &Insight{Text: "text"}})
+			}
+		}
+		return assets, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{ID: 1, Username: "manos"}
+	recommended, err := dom.Recommendations(ctx, usr, QueryRecommendations{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(recommended))
+	assert.Equal(t, uint(1), recommended[0].AssetID)
+	assert.Equal(t, 1.0, recommended[0].Score)
+	assert.Equal(t, uint(2), recommended[1].AssetID)
+	assert.NotNil(t, recommended[1].Asset)
+	// the candidates are read with a single query for the insights
+	assert.Equal(t, 1, queries)
+
+	recommended, err = dom.Recommendations(ctx, usr, QueryRecommendations{Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(recommended))
+
+	_, err = dom.Recommendations(ctx, usr, QueryRecommendations{Limit: 51})
+	assert.ErrorIs(t, err, ErrWrongQueryInput)
+	_, err = dom.Recommendations(ctx, nil, QueryRecommendations{})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestRefreshRecommendations(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.listAllFavourites = func(ctx context.Context) ([]UserFavourite, error) {
+		return []UserFavourite{
+			{UserID: 1, FavouriteItem: FavouriteItem{AssetType: ChartAssetType, AssetID: 1}},
+			{UserID: 1, FavouriteItem: FavouriteItem{AssetType: InsightAssetType, AssetID: 1}},
+		}, nil
+	}
+	var stored []AssetSimilarity
+	mdb.replaceSimilarities = func(ctx context.Context, similarities []AssetSimilarity) error {
+		stored = similarities
+		return nil
+	}
+	dom := NewDomain(mdb)
+	n, err := dom.RefreshRecommendations(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 2, len(stored))
+	assert.Equal(t, ChartAssetType, stored[0].AssetType)
+	assert.Equal(t, InsightAssetType, stored[0].RelatedType)
+}
+
+func TestAudienceSimilaritiesArePrefiltered(t *testing.T) {
+	audience := Audience{AgeMin: 20, AgeMax: 30, Gender: FemaleGenderType, Country: "GR", HoursSpent: 3, NumberOfPurchases: 3}
+	elsewhere := audience
+	elsewhere.Country = "SE"
+	audiences := []Asset{
+		{ID: 1, OrgID: 1, Data: &audience},
+		{ID: 2, OrgID: 1, Data: &audience},
+		// the same segment in another organization or in another country is not related
+		{ID: 3, OrgID: 2, Data: &audience},
+		{ID: 4, OrgID: 1, Data: &elsewhere},
+	}
+	scores := map[assetPair]float64{}
+	addAudienceSimilarities(scores, audiences, maxAudienceComparisons)
+	first := FavouriteItem{AssetType: AudienceAssetType, AssetID: 1}
+	second := FavouriteItem{AssetType: AudienceAssetType, AssetID: 2}
+	assert.Equal(t, 2, len(scores))
+	assert.InDelta(t, audienceSimilarityWeight, scores[assetPair{first, second}], 0.0001)
+	assert.InDelta(t, audienceSimilarityWeight, scores[assetPair{second, first}], 0.0001)
+
+	// the comparisons stop at the limit
+	many := []Asset{}
+	for i := uint(1); i <= 10; i++ {
+		many = append(many, Asset{ID: i, OrgID: 1, Data: &audience})
+	}
+	scores = map[assetPair]float64{}
+	addAudienceSimilarities(scores, many, 5)
+	assert.Equal(t, 10, len(scores))
+}
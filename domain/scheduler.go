@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+func markExpired(asset *Asset, now time.Time) {
+	asset.Expired = asset.ExpireAt != nil && !asset.ExpireAt.After(now)
+}
+
+func markListedExpired(ls *ListedAssets, now time.Time) {
+	if ls == nil {
+		return
+	}
+	for i := range ls.Assets {
+		markExpired(&ls.Assets[i], now)
+	}
+}
+
+// RunScheduledTransitions publishes the scheduled assets with a publishAt time that has come
+// and archives the published assets with an expireAt time that has passed.
+func (d *Domain) RunScheduledTransitions(ctx context.Context, now time.Time) (*ScheduledTransitions, error) {
+	published, err := d.repo.PublishScheduledAssets(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	archived, err := d.repo.ArchiveExpiredAssets(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	return &ScheduledTransitions{Published: published, Archived: archived}, nil
+}
+
+// RunScheduler runs the scheduled transitions of the assets every interval until the context is done.
+func (d *Domain) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		st, err := d.RunScheduledTransitions(ctx, time.Now())
+		if err != nil {
+			log.Println("Error scheduler: ", err)
+		} else if st.Published > 0 || st.Archived > 0 {
+			log.Printf("scheduler published %d and archived %d assets", st.Published, st.Archived)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func statusMockDB(asset *Asset) *MockDB {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		a := *asset
+		return &a, nil
+	}
+	mdb.setAssetStatus = func(ctx context.Context, at AssetType, assetID uint, status AssetStatus, approvedBy uint) error {
+		asset.Status = status
+		asset.ApprovedBy = approvedBy
+		return nil
+	}
+	return mdb
+}
+
+func TestAssetLifecycleSuccess(t *testing.T) {
+	asset := &Asset{ID: 1, Status: DraftStatus, AuthorID: 1, Data: &Insight{Text: "a", Description: "b"}}
+	dom := NewDomain(statusMockDB(asset))
+	ctx := context.Background()
+	author := &User{ID: 1, Username: "manos", IsAdmin: true}
+	reviewer := &User{ID: 2, Username: "maria", IsAdmin: true}
+
+	a, err := dom.TransitionAsset(ctx, author, 1, InsightAssetType, StatusTransition{Status: InReviewStatus})
+	assert.NoError(t, err)
+	assert.Equal(t, InReviewStatus, a.Status)
+	a, err = dom.TransitionAsset(ctx, reviewer, 1, InsightAssetType, StatusTransition{Status: PublishedStatus})
+	assert.NoError(t, err)
+	assert.Equal(t, PublishedStatus, a.Status)
+	assert.Equal(t, uint(2), a.ApprovedBy)
+	a, err = dom.TransitionAsset(ctx, author, 1, InsightAssetType, StatusTransition{Status: ArchivedStatus})
+	assert.NoError(t, err)
+	assert.Equal(t, ArchivedStatus, a.Status)
+}
+
+func TestAuthorApprovesAssetFailure(t *testing.T) {
+	asset := &Asset{ID: 1, Status: InReviewStatus, AuthorID: 1, Data: &Insight{Text: "a", Description: "b"}}
+	dom := NewDomain(statusMockDB(asset))
+	ctx := context.Background()
+	author := &User{ID: 1, Username: "manos", IsAdmin: true}
+	_, err := dom.TransitionAsset(ctx, author, 1, InsightAssetType, StatusTransition{Status: PublishedStatus})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.Equal(t, InReviewStatus, asset.Status)
+}
+
+func TestEditorApprovesAssetFailure(t *testing.T) {
+	asset := &Asset{ID: 1, Status: DraftStatus, AuthorID: 1, Data: &Insight{Text: "a", Description: "b"}}
+	mdb := statusMockDB(asset)
+	mdb.updateAsset = func(ctx context.Context, scope AssetScope, assetID uint, input InputAsset) (*Asset, error) {
+		asset.EditorID = scope.UserID
+		asset.Data = input.Data
+		return asset, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	editor := &User{ID: 2, Username: "maria", IsAdmin: true}
+	reviewer := &User{ID: 3, Username: "nikos", IsAdmin: true}
+
+	// maria edits the draft of manos and cannot approve it alone
+	_, err := dom.UpdateAsset(ctx, editor, 1, InputAsset{Data: &Insight{Text: "c", Description: "d"}})
+	assert.NoError(t, err)
+	_, err = dom.TransitionAsset(ctx, editor, 1, InsightAssetType, StatusTransition{Status: InReviewStatus})
+	assert.NoError(t, err)
+	_, err = dom.TransitionAsset(ctx, editor, 1, InsightAssetType, StatusTransition{Status: PublishedStatus})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.Equal(t, InReviewStatus, asset.Status)
+
+	a, err := dom.TransitionAsset(ctx, reviewer, 1, InsightAssetType, StatusTransition{Status: PublishedStatus})
+	assert.NoError(t, err)
+	assert.Equal(t, PublishedStatus, a.Status)
+}
+
+func TestWrongStatusTransitionFailure(t *testing.T) {
+	asset := &Asset{ID: 1, Status: DraftStatus, AuthorID: 1, Data: &Insight{Text: "a", Description: "b"}}
+	dom := NewDomain(statusMockDB(asset))
+	ctx := context.Background()
+	reviewer := &User{ID: 2, Username: "maria", IsAdmin: true}
+	_, err := dom.TransitionAsset(ctx, reviewer, 1, InsightAssetType, StatusTransition{Status: PublishedStatus})
+	assert.ErrorIs(t, err, ErrWrongStatusInput)
+	_, err = dom.TransitionAsset(ctx, reviewer, 1, InsightAssetType, StatusTransition{Status: AssetStatus("deleted")})
+	assert.ErrorIs(t, err, ErrWrongStatusInput)
+	_, err = dom.TransitionAsset(ctx, reviewer, 1, InsightAssetType, StatusTransition{})
+	assert.ErrorIs(t, err, ErrWrongStatusInput)
+	user := &User{ID: 3, Username: "nikos"}
+	_, err = dom.TransitionAsset(ctx, user, 1, InsightAssetType, StatusTransition{Status: InReviewStatus})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestAddAssetIsDraft(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.addAsset = func(ctx context.Context, scope AssetScope, asset InputAsset) (*Asset, error) {
+		return &Asset{ID: 1, Status: asset.Status, Data: asset.Data}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{ID: 1, Username: "manos", IsAdmin: true}
+	asset, err := dom.AddAsset(ctx, usr, InputAsset{Data: &Insight{Text: "a", Description: "b"}, Status: PublishedStatus})
+	assert.NoError(t, err)
+	assert.Equal(t, DraftStatus, asset.Status)
+}
+
+func TestApproveScheduledAsset(t *testing.T) {
+	publishAt := time.Now().Add(time.Hour)
+	asset := &Asset{ID: 1, Status: InReviewStatus, AuthorID: 1, PublishAt: &publishAt, Data: &Insight{Text: "a", Description: "b"}}
+	dom := NewDomain(statusMockDB(asset))
+	ctx := context.Background()
+	reviewer := &User{ID: 2, Username: "maria", IsAdmin: true}
+	a, err := dom.TransitionAsset(ctx, reviewer, 1, InsightAssetType, StatusTransition{Status: PublishedStatus})
+	assert.NoError(t, err)
+	assert.Equal(t, ScheduledStatus, a.Status)
+	assert.Equal(t, ScheduledStatus, asset.Status)
+}
+
+func TestRunScheduledTransitions(t *testing.T) {
+	now := time.Now()
+	mdb := &MockDB{}
+	mdb.publishScheduled = func(ctx context.Context, at time.Time) (int64, error) {
+		assert.Equal(t, now, at)
+		return 2, nil
+	}
+	mdb.archiveExpired = func(ctx context.Context, at time.Time) (int64, error) {
+		return 1, nil
+	}
+	dom := NewDomain(mdb)
+	st, err := dom.RunScheduledTransitions(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, &ScheduledTransitions{Published: 2, Archived: 1}, st)
+}
+
+func TestAssetExpiresBeforePublishFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	usr := &User{ID: 1, Username: "manos", IsAdmin: true}
+	publishAt := time.Now().Add(time.Hour)
+	expireAt := time.Now()
+	_, err := dom.AddAsset(ctx, usr, InputAsset{
+		Data:      &Insight{Text: "a", Description: "b"},
+		PublishAt: &publishAt,
+		ExpireAt:  &expireAt,
+	})
+	assert.ErrorIs(t, err, ErrWrongAssetInput)
+}
+
+func TestListFavouritesMarksExpired(t *testing.T) {
+	expireAt := time.Now().Add(-time.Hour)
+	mdb := &MockDB{}
+	mdb.listFavouriteAssets = func(ctx context.Context, scope AssetScope, userID uint, onlyFav bool, query QueryAssets) (*ListedAssets, error) {
+		return &ListedAssets{Assets: []Asset{{ID: 1, ExpireAt: &expireAt}, {ID: 2}}}, nil
+	}
+	dom := NewDomain(mdb)
+	usr := &User{ID: 1, Username: "manos"}
+	la, err := dom.ListAssets(context.Background(), usr, QueryAssets{Limit: 10, Type: InsightAssetType}, &QueryFavouriteAssets{FromUserID: 1, OnlyFav: true})
+	assert.NoError(t, err)
+	assert.True(t, la.Assets[0].Expired)
+	assert.False(t, la.Assets[1].Expired)
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTagWrongInputFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+	}
+	_, err := dom.CreateTag(ctx, usr, Tag{Name: ""})
+	assert.ErrorIs(t, err, ErrWrongTagInput)
+	usr.IsAdmin = false
+	_, err = dom.CreateTag(ctx, usr, Tag{Name: "retail"})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestMergeTagsIntoItselfFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+	}
+	err := dom.MergeTags(ctx, usr, 1, 1)
+	assert.ErrorIs(t, err, ErrWrongTagInput)
+}
+
+func TestMergeTagsOfOtherOrganizationFailure(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getTag = func(ctx context.Context, orgID, tagID uint) (*Tag, error) {
+		if tagID == 2 {
+			return nil, errors.New("record not found")
+		}
+		return &Tag{ID: tagID, Name: "retail"}, nil
+	}
+	mdb.mergeTags = func(ctx context.Context, orgID, fromTagID, intoTagID uint) error {
+		t.Fatal("tags of other organizations must not be merged")
+		return nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+		OrgID:    1,
+	}
+	err := dom.MergeTags(ctx, usr, 1, 2)
+	assert.ErrorIs(t, err, ErrTagNotFound)
+}
+
+func TestSetAssetTagsDuplicateFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+		IsAdmin:  true,
+	}
+	err := dom.SetAssetTags(ctx, usr, 1, ChartAssetType, []uint{1, 2, 1})
+	assert.ErrorIs(t, err, ErrWrongTagInput)
+}
+
+func TestListAssetsTooManyTagsFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	usr := &User{
+		ID:       1,
+		Username: "manos",
+	}
+	tags := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}
+	_, err := dom.ListAssets(ctx, usr, QueryAssets{Limit: 10, Type: ChartAssetType, Tags: tags}, nil)
+	assert.ErrorIs(t, err, ErrWrongQueryInput)
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func translationMockDB() *MockDB {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return &Asset{ID: assetID, OrgID: 1, Locale: "en", Data: &Insight{Text: "Young people like sports", Description: "Sports survey"}}, nil
+	}
+	mdb.listTranslations = func(ctx context.Context, at AssetType, assetIDs []uint) ([]AssetTranslation, error) {
+		return []AssetTranslation{
+			{AssetType: at, AssetID: 1, Locale: "el", Text: "Οι νέοι αγαπούν τα σπορ"},
+			{AssetType: at, AssetID: 1, Locale: "fr", Text: "Les jeunes aiment le sport", Description: "Enquête sportive"},
+		}, nil
+	}
+	mdb.setAssetTranslation = func(ctx context.Context, translation AssetTranslation) (*AssetTranslation, error) {
+		translation.ID = 1
+		return &translation, nil
+	}
+	return mdb
+}
+
+func TestGetTranslatedAssetSuccess(t *testing.T) {
+	dom := NewDomain(translationMockDB())
+	usr := &User{ID: 1, OrgID: 1, Username: "manos"}
+
+	ctx := WithLanguages(context.Background(), []string{"de", "el", "fr"})
+	asset, err := dom.GetAsset(ctx, usr, 1, InsightAssetType)
+	assert.NoError(t, err)
+	assert.Equal(t, "el", asset.Locale)
+	assert.Equal(t, "Οι νέοι αγαπούν τα σπορ", asset.Data.(*Insight).Text)
+	assert.Equal(t, "Sports survey", asset.Data.(*Insight).Description)
+
+	ctx = WithLanguages(context.Background(), []string{"en", "fr"})
+	asset, err = dom.GetAsset(ctx, usr, 1, InsightAssetType)
+	assert.NoError(t, err)
+	assert.Equal(t, "en", asset.Locale)
+	assert.Equal(t, "Young people like sports", asset.Data.(*Insight).Text)
+
+	asset, err = dom.GetAsset(context.Background(), usr, 1, InsightAssetType)
+	assert.NoError(t, err)
+	assert.Equal(t, "Young people like sports", asset.Data.(*Insight).Text)
+}
+
+func TestSetAssetTranslationSuccess(t *testing.T) {
+	dom := NewDomain(translationMockDB())
+	ctx := context.Background()
+	usr := &User{ID: 1, OrgID: 1, Username: "manos", IsAdmin: true}
+	tr, err := dom.SetAssetTranslation(ctx, usr, 1, InsightAssetType, AssetTranslation{Locale: "de", Text: "Junge Leute mögen Sport"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), tr.AssetID)
+	assert.Equal(t, InsightAssetType, tr.AssetType)
+}
+
+func TestSetAssetTranslationFailure(t *testing.T) {
+	dom := NewDomain(translationMockDB())
+	ctx := context.Background()
+	admin := &User{ID: 1, OrgID: 1, Username: "manos", IsAdmin: true}
+	wrong := []AssetTranslation{
+		{Locale: "de"},
+		{Locale: "DE", Text: "Sport"},
+		{Locale: "deu", Text: "Sport"},
+		{Locale: "de", Title: "Sport"},
+		{Locale: "en", Text: "Sport"},
+	}
+	for _, tr := range wrong {
+		_, err := dom.SetAssetTranslation(ctx, admin, 1, InsightAssetType, tr)
+		assert.ErrorIs(t, err, ErrWrongTranslationInput)
+	}
+	other := &User{ID: 2, OrgID: 2, Username: "maria", IsAdmin: true}
+	_, err := dom.SetAssetTranslation(ctx, other, 1, InsightAssetType, AssetTranslation{Locale: "de", Text: "Sport"})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	user := &User{ID: 3, OrgID: 1, Username: "nikos"}
+	_, err = dom.SetAssetTranslation(ctx, user, 1, InsightAssetType, AssetTranslation{Locale: "de", Text: "Sport"})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestTranslateReviewedAssetFailure(t *testing.T) {
+	mdb := translationMockDB()
+	status := PublishedStatus
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return &Asset{ID: assetID, OrgID: 1, Locale: "en", Status: status, Data: &Insight{Text: "Young people like sports"}}, nil
+	}
+	changed := false
+	mdb.setAssetTranslation = func(ctx context.Context, translation AssetTranslation) (*AssetTranslation, error) {
+		changed = true
+		return &translation, nil
+	}
+	mdb.removeTranslation = func(ctx context.Context, at AssetType, assetID uint, locale string) (bool, error) {
+		changed = true
+		return true, nil
+	}
+	var editor uint
+	mdb.setAssetEditor = func(ctx context.Context, at AssetType, assetID uint, editorID uint) error {
+		editor = editorID
+		return nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	admin := &User{ID: 2, OrgID: 1, Username: "maria", IsAdmin: true}
+	for _, status = range []AssetStatus{InReviewStatus, ScheduledStatus, PublishedStatus} {
+		_, err := dom.SetAssetTranslation(ctx, admin, 1, InsightAssetType, AssetTranslation{Locale: "de", Text: "Junge Leute mögen Sport"})
+		assert.ErrorIs(t, err, ErrWrongStatusInput)
+		err = dom.DeleteAssetTranslation(ctx, admin, 1, InsightAssetType, "de")
+		assert.ErrorIs(t, err, ErrWrongStatusInput)
+	}
+	assert.False(t, changed)
+
+	// the translator of a draft is its last editor
+	status = DraftStatus
+	_, err := dom.SetAssetTranslation(ctx, admin, 1, InsightAssetType, AssetTranslation{Locale: "de", Text: "Junge Leute mögen Sport"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), editor)
+}
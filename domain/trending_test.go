@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrendingAssetsSuccess(t *testing.T) {
+	mdb := &MockDB{}
+	var gotSince time.Time
+	var gotLimit int
+	mdb.listTrendingAssets = func(ctx context.Context, scope AssetScope, at AssetType, since time.Time, limit int) ([]TrendingAsset, error) {
+		gotSince = since
+		gotLimit = limit
+		return []TrendingAsset{
+			{Asset: Asset{ID: 2, FavouriteCount: 5, Data: &Insight{Text: "text"}}, Gained: 3},
+			{Asset: Asset{ID: 1, FavouriteCount: 1, Data: &Insight{Text: "text"}}, Gained: 1},
+		}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{ID: 1, Username: "manos"}
+	trending, err := dom.TrendingAssets(ctx, usr, QueryTrending{Type: InsightAssetType})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(trending))
+	assert.Equal(t, uint(2), trending[0].ID)
+	assert.Equal(t, int64(3), trending[0].Gained)
+	assert.Equal(t, defaultTrendingLimit, gotLimit)
+	assert.WithinDuration(t, time.Now().Add(-7*24*time.Hour), gotSince, time.Minute)
+
+	_, err = dom.TrendingAssets(ctx, usr, QueryTrending{Type: InsightAssetType, Hours: 24, Limit: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, gotLimit)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), gotSince, time.Minute)
+}
+
+func TestTrendingAssetsFailure(t *testing.T) {
+	dom := NewDomain(&MockDB{})
+	ctx := context.Background()
+	_, err := dom.TrendingAssets(ctx, nil, QueryTrending{Type: InsightAssetType})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	usr := &User{ID: 1, Username: "manos"}
+	wrong := []QueryTrending{
+		{},
+		{Type: "users"},
+		{Type: ChartAssetType, Hours: 721},
+		{Type: ChartAssetType, Limit: 101},
+		{Type: ChartAssetType, Hours: -1},
+	}
+	for _, v := range wrong {
+		_, err := dom.TrendingAssets(ctx, usr, v)
+		assert.ErrorIs(t, err, ErrWrongQueryInput)
+	}
+}
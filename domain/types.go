@@ -2,6 +2,9 @@ package domain
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -9,6 +12,10 @@ import (
 type Domain struct {
 	validate *validator.Validate
 	repo     IDBRepository
+	// views buffers the views of the assets until the view recorder stores them
+	views chan AssetView
+	// webhookClient posts the deliveries of the webhooks
+	webhookClient *http.Client
 }
 
 type XYData struct {
@@ -16,12 +23,100 @@ type XYData struct {
 	Y []float64 `json:"y"`
 }
 
+type ChartKind string
+
+const (
+	LineChartKind    = ChartKind("line")
+	BarChartKind     = ChartKind("bar")
+	PieChartKind     = ChartKind("pie")
+	ScatterChartKind = ChartKind("scatter")
+)
+
+type AxisType string
+
+const (
+	NumericAxisType  = AxisType("numeric")
+	CategoryAxisType = AxisType("category")
+	TimeAxisType     = AxisType("time")
+)
+
+// Series is a named series of a chart. Depending on the X axis type of the chart,
+// the values of the X axis are given by X, Categories or Times.
+type Series struct {
+	Name       string      `validate:"required" json:"name"`
+	X          []float64   `json:"x,omitempty"`
+	Categories []string    `json:"categories,omitempty"`
+	Times      []time.Time `json:"times,omitempty"`
+	Y          []float64   `json:"y"`
+}
+
+// Chart keeps its series in Series. Data is the single numeric series of the first charts
+// and is still accepted when Series is empty.
 type Chart struct {
-	Title       string `validate:"required" json:"title"`
-	XTitle      string `validate:"required" json:"xTitle"`
-	YTitle      string `validate:"required" json:"yTitle"`
-	Description string `validate:"required" json:"description"`
-	Data        XYData `json:"data"`
+	Title       string    `validate:"required" json:"title"`
+	XTitle      string    `validate:"required" json:"xTitle"`
+	YTitle      string    `validate:"required" json:"yTitle"`
+	Description string    `validate:"required" json:"description"`
+	Kind        ChartKind `json:"kind,omitempty"`
+	XAxis       AxisType  `json:"xAxis,omitempty"`
+	Data        XYData    `json:"data"`
+	Series      []Series  `validate:"dive" json:"series,omitempty"`
+}
+
+// SetDefaults sets the kind and the X axis type of the charts that were created without them.
+func (c *Chart) SetDefaults() {
+	if c.Kind == "" {
+		c.Kind = LineChartKind
+	}
+	if c.XAxis == "" {
+		c.XAxis = NumericAxisType
+	}
+}
+
+// AllSeries returns the series of the chart, or the single series of Data when there are none.
+func (c *Chart) AllSeries() []Series {
+	if len(c.Series) > 0 {
+		return c.Series
+	}
+	return []Series{{Name: c.YTitle, X: c.Data.X, Y: c.Data.Y}}
+}
+
+// ChartAnalyticsQuery selects the transformations of the series of a chart analysis.
+// MovingAverage is the window of the moving average, Resample the number of buckets of equal
+// X width to average the points in, and MaxPoints the maximum number of points of any returned series.
+type ChartAnalyticsQuery struct {
+	MovingAverage int `validate:"gte=0,lte=10000" json:"movingAverage" query:"movingAverage"`
+	Resample      int `validate:"gte=0,lte=10000" json:"resample" query:"resample"`
+	MaxPoints     int `validate:"omitempty,gte=3,lte=100000" json:"maxPoints" query:"maxPoints"`
+}
+
+// LinearFit is the least squares line y = Slope*x + Intercept. For time axes x is in seconds.
+type LinearFit struct {
+	Slope     float64 `json:"slope"`
+	Intercept float64 `json:"intercept"`
+	R2        float64 `json:"r2"`
+}
+
+type SeriesAnalytics struct {
+	Name          string     `json:"name"`
+	Count         int        `json:"count"`
+	Min           float64    `json:"min"`
+	Max           float64    `json:"max"`
+	Mean          float64    `json:"mean"`
+	Median        float64    `json:"median"`
+	Regression    *LinearFit `json:"regression,omitempty"`
+	Correlation   *float64   `json:"correlation,omitempty"`
+	MovingAverage *Series    `json:"movingAverage,omitempty"`
+	Resampled     *Series    `json:"resampled,omitempty"`
+	Downsampled   *Series    `json:"downsampled,omitempty"`
+}
+
+type ChartAnalytics struct {
+	ChartID uint              `json:"chartID"`
+	Version uint              `json:"version"`
+	Kind    ChartKind         `json:"kind"`
+	XAxis   AxisType          `json:"xAxis"`
+	Series  []SeriesAnalytics `json:"series"`
 }
 
 type Insight struct {
@@ -29,21 +124,105 @@ type Insight struct {
 	Description string `validate:"required" json:"description"`
 }
 
+// IntRange is an inclusive range of integers.
+type IntRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// Audience is a segment of users. The single Gender, Country, HoursSpent and NumberOfPurchases
+// of the first audiences are still accepted instead of the lists and the ranges that replaced them.
+// Countries are given with their names or codes and are stored as ISO 3166 alpha-2 codes, and regions are
+// the regions or subregions of gountries. CountryNames are the localized names of the countries in the responses.
 type Audience struct {
-	AgeMax            int        `validate:"required,gte=1,lte=102" json:"ageMax"`
-	AgeMin            int        `validate:"required,gte=1,lte=102" json:"ageMin"`
-	Gender            GenderType `validate:"required" json:"gender"`
-	Country           string     `validate:"required" json:"country"`
-	HoursSpent        int        `validate:"required,gte=1,lte=24" json:"hoursSpent"`
-	NumberOfPurchases int        `validate:"required,gte=1,lte=100" json:"numberOfPurchases"`
-	Description       string     `validate:"required" json:"description"`
+	AgeMax                 int          `validate:"required,gte=1,lte=102" json:"ageMax"`
+	AgeMin                 int          `validate:"required,gte=1,lte=102" json:"ageMin"`
+	Gender                 GenderType   `json:"gender,omitempty"`
+	Genders                []GenderType `json:"genders,omitempty"`
+	Country                string       `json:"country,omitempty"`
+	Countries              []string     `json:"countries,omitempty"`
+	CountryNames           []string     `json:"countryNames,omitempty"`
+	Regions                []string     `json:"regions,omitempty"`
+	HoursSpent             int          `validate:"omitempty,gte=1,lte=24" json:"hoursSpent,omitempty"`
+	HoursSpentRange        *IntRange    `json:"hoursSpentRange,omitempty"`
+	NumberOfPurchases      int          `validate:"omitempty,gte=1,lte=100" json:"numberOfPurchases,omitempty"`
+	NumberOfPurchasesRange *IntRange    `json:"numberOfPurchasesRange,omitempty"`
+	Interests              []string     `validate:"max=50,dive,required,max=50" json:"interests,omitempty"`
+	Devices                []DeviceType `json:"devices,omitempty"`
+	Description            string       `validate:"required" json:"description"`
+}
+
+// AllGenders returns the genders of the audience, or its single gender when there is no list.
+func (a *Audience) AllGenders() []GenderType {
+	if len(a.Genders) > 0 || a.Gender == "" {
+		return a.Genders
+	}
+	return []GenderType{a.Gender}
+}
+
+// AllCountries returns the countries of the audience, or its single country when there is no list.
+func (a *Audience) AllCountries() []string {
+	if len(a.Countries) > 0 || a.Country == "" {
+		return a.Countries
+	}
+	return []string{a.Country}
+}
+
+// HoursSpentBounds returns the range of the hours spent, which is a single value for the first audiences.
+func (a *Audience) HoursSpentBounds() IntRange {
+	if a.HoursSpentRange != nil {
+		return *a.HoursSpentRange
+	}
+	return IntRange{Min: a.HoursSpent, Max: a.HoursSpent}
+}
+
+// NumberOfPurchasesBounds returns the range of the purchases, which is a single value for the first audiences.
+func (a *Audience) NumberOfPurchasesBounds() IntRange {
+	if a.NumberOfPurchasesRange != nil {
+		return *a.NumberOfPurchasesRange
+	}
+	return IntRange{Min: a.NumberOfPurchases, Max: a.NumberOfPurchases}
+}
+
+// AudienceDimensionDiff compares a dimension of the audiences. Lists have the values that all the audiences
+// share and the values of each audience that the others miss, and ranges have their overlap.
+// Similarity is the size of the shared values or range over the size of all of them, from 0 to 1.
+type AudienceDimensionDiff struct {
+	Dimension   string     `json:"dimension"`
+	Similarity  float64    `json:"similarity"`
+	Common      []string   `json:"common,omitempty"`
+	Only        [][]string `json:"only,omitempty"`
+	CommonRange *IntRange  `json:"commonRange,omitempty"`
+	Ranges      []IntRange `json:"ranges,omitempty"`
+}
+
+// AudienceComparison is the overlap of audiences. Intersection is the segment of the users that are in
+// all the audiences and is missing when there are no such users. Similarity is the mean of the similarities
+// of the dimensions.
+type AudienceComparison struct {
+	AudienceIDs  []uint                  `json:"audienceIDs"`
+	Intersection *Audience               `json:"intersection,omitempty"`
+	Dimensions   []AudienceDimensionDiff `json:"dimensions"`
+	Similarity   float64                 `json:"similarity"`
 }
 
 type GenderType string
 
 const (
-	MaleGenderType   = GenderType("male")
-	FemaleGenderType = GenderType("female")
+	MaleGenderType        = GenderType("male")
+	FemaleGenderType      = GenderType("female")
+	NonBinaryGenderType   = GenderType("nonBinary")
+	OtherGenderType       = GenderType("other")
+	UndisclosedGenderType = GenderType("undisclosed")
+)
+
+type DeviceType string
+
+const (
+	MobileDeviceType  = DeviceType("mobile")
+	DesktopDeviceType = DeviceType("desktop")
+	TabletDeviceType  = DeviceType("tablet")
+	TVDeviceType      = DeviceType("tv")
 )
 
 type AssetType string
@@ -54,10 +233,97 @@ const (
 	ChartAssetType    = AssetType("charts")
 )
 
+type Visibility string
+
+const (
+	PublicVisibility       = Visibility("public")
+	OrganizationVisibility = Visibility("organization")
+	PrivateVisibility      = Visibility("private")
+)
+
+// AssetStatus is the step of the lifecycle of an asset. Only the published assets are seen by the users,
+// the rest only by the administrators of their organization. Scheduled assets are approved and wait for
+// their publishAt time.
+type AssetStatus string
+
+const (
+	DraftStatus     = AssetStatus("draft")
+	InReviewStatus  = AssetStatus("inReview")
+	ScheduledStatus = AssetStatus("scheduled")
+	PublishedStatus = AssetStatus("published")
+	ArchivedStatus  = AssetStatus("archived")
+)
+
+// ScheduledTransitions counts the assets that a run of the scheduler published and archived.
+type ScheduledTransitions struct {
+	Published int64 `json:"published"`
+	Archived  int64 `json:"archived"`
+}
+
+// StatusTransition moves an asset to another status of its lifecycle.
+type StatusTransition struct {
+	Status AssetStatus `validate:"required" json:"status"`
+}
+
+// Asset is an insight, a chart or an audience. Expired is set on the assets after their expireAt time,
+// which users only see in their favourites.
 type Asset struct {
-	ID          uint        `json:"id"`
-	IsFavourite *bool       `json:"isFavourite,omitempty"`
-	Data        interface{} `json:"data"`
+	ID         uint        `json:"id"`
+	OrgID      uint        `json:"orgID"`
+	Visibility Visibility  `json:"visibility"`
+	Version    uint        `json:"version"`
+	Status     AssetStatus `json:"status"`
+	Locale     string      `json:"locale,omitempty"`
+	AuthorID   uint        `json:"authorID,omitempty"`
+	EditorID   uint        `json:"editorID,omitempty"`
+	ApprovedBy uint        `json:"approvedBy,omitempty"`
+	PublishAt  *time.Time  `json:"publishAt,omitempty"`
+	ExpireAt   *time.Time  `json:"expireAt,omitempty"`
+	Expired    bool        `json:"expired,omitempty"`
+	// FavouriteCount is the number of users that favour the asset
+	FavouriteCount uint `json:"favouriteCount"`
+	// Feedback has the ratings of the asset and its open flags
+	Feedback    AssetFeedback  `json:"feedback"`
+	IsFavourite *bool          `json:"isFavourite,omitempty"`
+	Note        *FavouriteNote `json:"note,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Links       []AssetLink    `json:"links,omitempty"`
+	// Related are the assets that the users who favour the asset also favour, only on the asset detail
+	Related []RecommendedAsset `json:"related,omitempty"`
+	Data    interface{}        `json:"data"`
+}
+
+type RelationType string
+
+const (
+	// SupportedByRelation links an insight to the chart that backs it
+	SupportedByRelation = RelationType("supportedBy")
+	// AboutRelation links an insight to the audience it talks about
+	AboutRelation = RelationType("about")
+)
+
+// AssetLink is a typed link from an asset to another one.
+// When an asset is fetched its links are returned in both directions.
+type AssetLink struct {
+	ID       uint         `json:"id"`
+	Relation RelationType `validate:"required" json:"relation"`
+	FromType AssetType    `json:"fromType"`
+	FromID   uint         `json:"fromID"`
+	ToType   AssetType    `validate:"required" json:"toType"`
+	ToID     uint         `validate:"required" json:"toID"`
+}
+
+// Tag is managed by the administrators of an organization to group assets of any type.
+type Tag struct {
+	ID    uint   `json:"id"`
+	Name  string `validate:"required,max=100" json:"name"`
+	Count int64  `json:"count"`
+}
+
+// FavouriteNote is a private markdown text that a user keeps on a favourite asset.
+type FavouriteNote struct {
+	Text      string    `validate:"max=10000" json:"text"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
 
 func (a *Asset) GetData() interface{} {
@@ -66,6 +332,81 @@ func (a *Asset) GetData() interface{} {
 
 type InputAsset struct {
 	Data interface{}
+	// Visibility is kept unchanged on updates when it is empty
+	Visibility Visibility
+	// Status is set by the domain on new assets and is not changed by updates
+	Status AssetStatus
+	// PublishAt and ExpireAt are kept unchanged on updates when they are nil
+	PublishAt *time.Time
+	ExpireAt  *time.Time
+	// Locale is the language of the texts, it is kept unchanged on updates when it is empty
+	Locale string
+}
+
+// DefaultLocale is the language of the texts of the assets that are added without a locale.
+const DefaultLocale = "en"
+
+type ImportFormat string
+
+const (
+	CSVImportFormat   = ImportFormat("csv")
+	JSONLImportFormat = ImportFormat("jsonl")
+)
+
+// ImportOptions tells how to import a file of assets. With a ChunkSize of 0 either all the rows are
+// imported or none of them, otherwise every chunk of rows is imported on its own.
+// Visibility and Locale are set on every imported asset.
+type ImportOptions struct {
+	Format     ImportFormat `validate:"oneof=csv jsonl" json:"format" query:"format"`
+	DryRun     bool         `json:"dryRun" query:"dryRun"`
+	ChunkSize  int          `validate:"gte=0,lte=1000" json:"chunkSize" query:"chunkSize"`
+	Visibility Visibility   `json:"visibility" query:"visibility"`
+	Locale     string       `json:"locale" query:"locale"`
+}
+
+type ImportRowStatus string
+
+const (
+	// ValidImportRow is a row that passed the validation of a dry run
+	ValidImportRow    = ImportRowStatus("valid")
+	ImportedImportRow = ImportRowStatus("imported")
+	FailedImportRow   = ImportRowStatus("failed")
+	// SkippedImportRow is a valid row that was not imported because of another row of its chunk
+	SkippedImportRow = ImportRowStatus("skipped")
+)
+
+// ImportRowResult is the outcome of a row of an import, Row is its line in the file.
+type ImportRowResult struct {
+	Row     int             `json:"row"`
+	Status  ImportRowStatus `json:"status"`
+	AssetID uint            `json:"assetID,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+type ImportReport struct {
+	AssetType AssetType         `json:"assetType"`
+	DryRun    bool              `json:"dryRun"`
+	Total     int               `json:"total"`
+	Valid     int               `json:"valid"`
+	Imported  int               `json:"imported"`
+	Failed    int               `json:"failed"`
+	Skipped   int               `json:"skipped"`
+	Rows      []ImportRowResult `json:"rows"`
+}
+
+// AssetTranslation has the texts of an asset in another language than its locale. Insights translate their
+// text and description, charts their titles and description, and audiences their description.
+// The empty texts are taken from the locale of the asset. Locales are ISO 639-1 codes.
+type AssetTranslation struct {
+	ID          uint      `json:"id"`
+	AssetType   AssetType `json:"assetType"`
+	AssetID     uint      `json:"assetID"`
+	Locale      string    `validate:"required,len=2,lowercase,alpha" json:"locale"`
+	Text        string    `validate:"max=200" json:"text,omitempty"`
+	Description string    `validate:"max=200" json:"description,omitempty"`
+	Title       string    `validate:"max=200" json:"title,omitempty"`
+	XTitle      string    `validate:"max=200" json:"xTitle,omitempty"`
+	YTitle      string    `validate:"max=200" json:"yTitle,omitempty"`
 }
 
 func (ia *InputAsset) GetData() interface{} {
@@ -77,11 +418,303 @@ type QueryFavouriteAssets struct {
 	OnlyFav    bool `json:"onlyFavourite"`
 }
 
+// FavouriteItem is an asset of a batch of favourites.
+type FavouriteItem struct {
+	AssetType AssetType `validate:"required" json:"assetType"`
+	AssetID   uint      `validate:"required" json:"id"`
+}
+
+// FavouriteBatch favours all its items, or unfavours them when Favourite is false.
+type FavouriteBatch struct {
+	Favourite bool            `json:"favourite"`
+	Items     []FavouriteItem `validate:"required,min=1,max=100,dive" json:"items"`
+}
+
+// FavouriteResult is the outcome of an item of a batch of favourites.
+type FavouriteResult struct {
+	AssetType AssetType `json:"assetType"`
+	AssetID   uint      `json:"id"`
+	OK        bool      `json:"ok"`
+	// Changed is false when the asset was already favoured, or already not favoured
+	Changed bool   `json:"changed"`
+	Error   string `json:"error,omitempty"`
+}
+
 type QueryAssets struct {
 	Limit  int       `validate:"required,gte=1" json:"limit"`
 	LastID uint      `validate:"gte=0" json:"lastID"`
 	Type   AssetType `validate:"required" json:"type"`
 	IsDesc bool      `json:"isDesc"`
+	// NoteSearch keeps only the favourites with notes that contain the text
+	NoteSearch string `validate:"max=200" json:"noteSearch,omitempty"`
+	// Tags keeps only the assets that have all the tags
+	Tags []string `validate:"max=10" json:"tags,omitempty"`
+}
+
+// QueryTrending ranks the assets of a type by the favourites they gained in the last hours.
+type QueryTrending struct {
+	Type AssetType `validate:"required,oneof=insights charts audiences" json:"type"`
+	// Hours is the window of the ranking, the last week by default
+	Hours int `validate:"gte=0,lte=720" json:"hours" query:"hours"`
+	Limit int `validate:"gte=0,lte=100" json:"limit" query:"limit"`
+}
+
+// TrendingAsset is an asset with the favourites it gained in the window of the ranking.
+type TrendingAsset struct {
+	Asset
+	Gained int64 `json:"gained"`
+}
+
+// AssetFeedback aggregates the ratings of the users on an asset and the flags that are not resolved yet.
+type AssetFeedback struct {
+	RatingCount uint `json:"ratingCount"`
+	// RatingAverage is 0 for the assets without ratings
+	RatingAverage  float64 `json:"ratingAverage"`
+	OutdatedFlags  uint    `json:"outdatedFlags"`
+	IncorrectFlags uint    `json:"incorrectFlags"`
+}
+
+// RatingInput is the usefulness of an asset for a user, from 1 to 5.
+type RatingInput struct {
+	Rating int `validate:"required,gte=1,lte=5" json:"rating"`
+}
+
+type FlagReason string
+
+const (
+	OutdatedFlag  = FlagReason("outdated")
+	IncorrectFlag = FlagReason("incorrect")
+)
+
+// FlagInput flags an asset as outdated or incorrect, with an optional comment for the administrators.
+type FlagInput struct {
+	Reason  FlagReason `validate:"required,oneof=outdated incorrect" json:"reason"`
+	Comment string     `validate:"max=500" json:"comment,omitempty"`
+}
+
+// AssetFlag is a flag of a user on an asset, it is open until an administrator resolves it.
+type AssetFlag struct {
+	ID         uint       `json:"id"`
+	AssetType  AssetType  `json:"assetType"`
+	AssetID    uint       `json:"assetID"`
+	UserID     uint       `json:"userID"`
+	Reason     FlagReason `json:"reason"`
+	Comment    string     `json:"comment,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+	ResolvedBy uint       `json:"resolvedBy,omitempty"`
+}
+
+// FlagResolution resolves the open flags of an asset with the reason, or all of them without reason.
+type FlagResolution struct {
+	Reason FlagReason `validate:"omitempty,oneof=outdated incorrect" json:"reason,omitempty"`
+}
+
+// QueryFlaggedAssets lists the assets with open flags of the organization of the administrator,
+// of a type or of any type without type.
+type QueryFlaggedAssets struct {
+	Type  AssetType `validate:"omitempty,oneof=insights charts audiences" json:"type" query:"type"`
+	Limit int       `validate:"gte=0,lte=100" json:"limit" query:"limit"`
+}
+
+// FlaggedAsset is an asset in the queue of the administrators with its open flags.
+type FlaggedAsset struct {
+	AssetType   AssetType   `json:"assetType"`
+	AssetID     uint        `json:"assetID"`
+	OpenFlags   int64       `json:"openFlags"`
+	FirstFlagAt time.Time   `json:"firstFlagAt"`
+	Flags       []AssetFlag `json:"flags"`
+	Asset       *Asset      `json:"asset"`
+}
+
+type WebhookEventType string
+
+const (
+	AssetCreatedEvent    = WebhookEventType("asset.created")
+	AssetUpdatedEvent    = WebhookEventType("asset.updated")
+	AssetDeletedEvent    = WebhookEventType("asset.deleted")
+	AssetFavouritedEvent = WebhookEventType("asset.favourited")
+	// PingEvent is only sent by the ping of a webhook, to test its receiver
+	PingEvent = WebhookEventType("ping")
+)
+
+// Webhook receives the events of the assets of an organization, of the event types and the asset types
+// of its filters, or all of them when a filter is empty. The secret signs the payloads and is only
+// returned when the webhook is registered.
+type Webhook struct {
+	ID         uint               `json:"id"`
+	OrgID      uint               `json:"orgID"`
+	URL        string             `json:"url"`
+	Events     []WebhookEventType `json:"events"`
+	AssetTypes []AssetType        `json:"assetTypes"`
+	Active     bool               `json:"active"`
+	Secret     string             `json:"secret,omitempty"`
+	CreatedAt  time.Time          `json:"createdAt"`
+}
+
+// WebhookInput registers or updates a webhook. A random secret is generated for new webhooks without one,
+// and the secret and the activity of a webhook are kept on updates when they are not given.
+type WebhookInput struct {
+	URL        string             `validate:"required,url,max=500" json:"url"`
+	Events     []WebhookEventType `validate:"max=4,dive,oneof=asset.created asset.updated asset.deleted asset.favourited" json:"events"`
+	AssetTypes []AssetType        `validate:"max=3,dive,oneof=insights charts audiences" json:"assetTypes"`
+	Secret     string             `validate:"omitempty,min=16,max=200" json:"secret,omitempty"`
+	Active     *bool              `json:"active,omitempty"`
+}
+
+// WebhookEvent is the payload of a delivery. The deleted assets are sent as they were before their deletion,
+// and the favourites have the user that favoured the asset without the asset.
+type WebhookEvent struct {
+	Event      WebhookEventType `json:"event"`
+	OrgID      uint             `json:"orgID"`
+	AssetType  AssetType        `json:"assetType,omitempty"`
+	AssetID    uint             `json:"assetID,omitempty"`
+	UserID     uint             `json:"userID,omitempty"`
+	OccurredAt time.Time        `json:"occurredAt"`
+	Asset      *Asset           `json:"asset,omitempty"`
+}
+
+type DeliveryStatus string
+
+const (
+	PendingDelivery   = DeliveryStatus("pending")
+	DeliveredDelivery = DeliveryStatus("delivered")
+	FailedDelivery    = DeliveryStatus("failed")
+)
+
+// WebhookDelivery is the delivery of an event to a webhook. A pending delivery is attempted again at
+// its nextAttemptAt time, until it is delivered or fails after its last attempt.
+type WebhookDelivery struct {
+	ID             uint             `json:"id"`
+	WebhookID      uint             `json:"webhookID"`
+	Event          WebhookEventType `json:"event"`
+	Payload        string           `json:"payload"`
+	Status         DeliveryStatus   `json:"status"`
+	Attempts       int              `json:"attempts"`
+	NextAttemptAt  *time.Time       `json:"nextAttemptAt,omitempty"`
+	LastStatusCode int              `json:"lastStatusCode,omitempty"`
+	LastError      string           `json:"lastError,omitempty"`
+	DeliveredAt    *time.Time       `json:"deliveredAt,omitempty"`
+	// RedeliveryOf is the delivery that was delivered again manually
+	RedeliveryOf uint      `json:"redeliveryOf,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// QueryDeliveries lists the deliveries of a webhook from the oldest one.
+type QueryDeliveries struct {
+	Limit  int  `validate:"required,gte=1,lte=100" json:"limit" query:"limit"`
+	LastID uint `validate:"gte=0" json:"lastID" query:"lastID"`
+}
+
+type ListedDeliveries struct {
+	Limit      int               `json:"limit"`
+	FirstID    uint              `json:"firstID"`
+	LastID     uint              `json:"lastID"`
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+// UserFavourite is an asset that a user favours.
+type UserFavourite struct {
+	UserID uint
+	FavouriteItem
+}
+
+// AssetSimilarity is how similar the related asset is to the asset, from the users that favour both
+// of them and, for two audiences, from their segments.
+type AssetSimilarity struct {
+	AssetType   AssetType
+	AssetID     uint
+	RelatedType AssetType
+	RelatedID   uint
+	Score       float64
+}
+
+// RecommendedAsset is an asset of any type with the score of its recommendation.
+type RecommendedAsset struct {
+	AssetType AssetType `json:"assetType"`
+	AssetID   uint      `json:"assetID"`
+	Score     float64   `json:"score"`
+	Asset     *Asset    `json:"asset"`
+}
+
+type QueryRecommendations struct {
+	Limit int `validate:"gte=0,lte=50" json:"limit" query:"limit"`
+}
+
+// AssetView is a view of an asset by a user.
+type AssetView struct {
+	UserID    uint
+	AssetType AssetType
+	AssetID   uint
+	ViewedAt  time.Time
+}
+
+// RecentAsset is an asset that the user viewed, with the time of the last view.
+type RecentAsset struct {
+	AssetType AssetType `json:"assetType"`
+	AssetID   uint      `json:"assetID"`
+	ViewedAt  time.Time `json:"viewedAt"`
+	Asset     *Asset    `json:"asset"`
+}
+
+type QueryRecentAssets struct {
+	Limit int `validate:"gte=0,lte=100" json:"limit" query:"limit"`
+}
+
+// AssetViewStats counts the views of an asset. The views are counted for ever, while the recent views and
+// their viewers are counted from the views that are kept for the retention period.
+type AssetViewStats struct {
+	AssetType   AssetType `json:"assetType"`
+	AssetID     uint      `json:"assetID"`
+	Views       uint64    `json:"views"`
+	RecentViews int64     `json:"recentViews"`
+	Viewers     int64     `json:"viewers"`
+}
+
+// Comment is a comment of a user on an asset, the replies have the ID of the comment they reply to.
+// Deleted comments stay in their threads without their text, and the hidden comments are only shown to
+// the administrators.
+type Comment struct {
+	ID        uint       `json:"id"`
+	AssetType AssetType  `json:"assetType"`
+	AssetID   uint       `json:"assetID"`
+	ParentID  uint       `json:"parentID,omitempty"`
+	AuthorID  uint       `json:"authorID"`
+	Author    string     `json:"author"`
+	Text      string     `json:"text"`
+	Mentions  []string   `json:"mentions,omitempty"`
+	Replies   int64      `json:"replies"`
+	CreatedAt time.Time  `json:"createdAt"`
+	EditedAt  *time.Time `json:"editedAt,omitempty"`
+	Deleted   bool       `json:"deleted,omitempty"`
+	Hidden    bool       `json:"hidden,omitempty"`
+}
+
+// CommentInput is the text of a new or edited comment. The users are mentioned with @ and their username.
+type CommentInput struct {
+	Text string `validate:"required,max=2000" json:"text"`
+	// ParentID is the comment that a new comment replies to, it is ignored on edits
+	ParentID uint `json:"parentID,omitempty"`
+}
+
+// CommentModeration hides or shows again a comment.
+type CommentModeration struct {
+	Hidden bool `json:"hidden"`
+}
+
+// QueryComments lists the comments of a thread from the oldest one. The thread of an asset has the comments
+// without parent, and the thread of a comment has its replies.
+type QueryComments struct {
+	Limit    int  `validate:"required,gte=1,lte=100" json:"limit" query:"limit"`
+	LastID   uint `validate:"gte=0" json:"lastID" query:"lastID"`
+	ParentID uint `json:"parentID" query:"parentID"`
+}
+
+type ListedComments struct {
+	Limit    int       `json:"limit"`
+	FirstID  uint      `json:"firstID"`
+	LastID   uint      `json:"lastID"`
+	Comments []Comment `json:"comments"`
 }
 
 type ListedAssets struct {
@@ -92,16 +725,80 @@ type ListedAssets struct {
 	Assets  []Asset   `json:"assets"`
 }
 
+// Collection is a named folder of a user that mixes assets of any type in a user-defined order.
+type Collection struct {
+	ID   uint   `json:"id"`
+	Name string `validate:"required" json:"name"`
+}
+
+type CollectionItem struct {
+	ID        uint      `json:"id"`
+	AssetType AssetType `validate:"required" json:"assetType"`
+	AssetID   uint      `validate:"required" json:"assetID"`
+	Position  int       `json:"position"`
+	Asset     *Asset    `json:"asset,omitempty"`
+}
+
+type QueryCollectionItems struct {
+	Limit        int `validate:"required,gte=1" json:"limit" query:"limit"`
+	LastPosition int `validate:"gte=0" json:"lastPosition" query:"lastPosition"`
+}
+
+type ListedCollectionItems struct {
+	Limit         int              `json:"limit"`
+	FirstPosition int              `json:"firstPosition"`
+	LastPosition  int              `json:"lastPosition"`
+	Items         []CollectionItem `json:"items"`
+}
+
 type User struct {
 	ID       uint
 	Username string `validate:"required"`
 	Password string `validate:"required"`
 	IsAdmin  bool
+	OrgID    uint
 }
 
 type LoginCredentials struct {
 	Username string `validate:"required"`
 	Password string `validate:"required"`
+	OrgID    uint
+}
+
+type Organization struct {
+	ID   uint   `json:"id"`
+	Name string `validate:"required" json:"name"`
+}
+
+// OrganizationMember is a user to add to an organization.
+// IsAdmin gives the user administrator rights in that organization only,
+// such as adding more members to it.
+type OrganizationMember struct {
+	UserID  uint `json:"userID"`
+	IsAdmin bool `json:"isAdmin"`
+}
+
+// AssetScope restricts the repository calls to the assets of a single tenant.
+// The organization with ID 0 is the default tenant for users without organization.
+// Reads also reach the public assets of other tenants and the private assets
+// that have been granted to the user, while writes only reach the assets of the tenant.
+type AssetScope struct {
+	OrgID   uint
+	UserID  uint
+	IsAdmin bool
+}
+
+type Group struct {
+	ID    uint   `json:"id"`
+	OrgID uint   `json:"orgID"`
+	Name  string `validate:"required" json:"name"`
+}
+
+// AssetGrant gives access to a private asset either to a user or to a group.
+type AssetGrant struct {
+	ID      uint `json:"id"`
+	UserID  uint `json:"userID"`
+	GroupID uint `json:"groupID"`
 }
 
 type IAsset interface {
@@ -114,22 +811,164 @@ type IDomain interface {
 	DeleteAsset(ctx context.Context, user *User, assetID uint, assetType AssetType) error
 	UpdateAsset(ctx context.Context, user *User, assetID uint, asset InputAsset) (*Asset, error)
 	ListAssets(ctx context.Context, user *User, query QueryAssets, favQuery *QueryFavouriteAssets) (*ListedAssets, error)
-	FavouriteAsset(ctx context.Context, uuser *User, assetID uint, assetType AssetType, isFavourite bool) error
+	FavouriteAsset(ctx context.Context, uuser *User, assetID uint, assetType AssetType, isFavourite bool) (bool, error)
+	SetFavouriteNote(ctx context.Context, user *User, assetID uint, assetType AssetType, note FavouriteNote) (*FavouriteNote, error)
 	CreateUser(ctx context.Context, user User) (*User, error)
 	LoginUser(ctx context.Context, cred LoginCredentials) (*User, error)
+	CreateOrganization(ctx context.Context, user *User, org Organization) (*Organization, error)
+	AddOrganizationMember(ctx context.Context, user *User, orgID uint, member OrganizationMember) error
+	ListUserOrganizations(ctx context.Context, user *User) ([]Organization, error)
+	CreateGroup(ctx context.Context, user *User, group Group) (*Group, error)
+	AddGroupMember(ctx context.Context, user *User, groupID, userID uint) error
+	GrantAsset(ctx context.Context, user *User, assetID uint, assetType AssetType, grant AssetGrant) (*AssetGrant, error)
+	RevokeAssetGrant(ctx context.Context, user *User, assetID uint, assetType AssetType, grantID uint) error
+	ListAssetGrants(ctx context.Context, user *User, assetID uint, assetType AssetType) ([]AssetGrant, error)
+	CreateCollection(ctx context.Context, user *User, collection Collection) (*Collection, error)
+	RenameCollection(ctx context.Context, user *User, collectionID uint, collection Collection) (*Collection, error)
+	DeleteCollection(ctx context.Context, user *User, collectionID uint) error
+	ListCollections(ctx context.Context, user *User) ([]Collection, error)
+	AddCollectionItem(ctx context.Context, user *User, collectionID uint, item CollectionItem) (*CollectionItem, error)
+	RemoveCollectionItem(ctx context.Context, user *User, collectionID, itemID uint) error
+	ReorderCollection(ctx context.Context, user *User, collectionID uint, itemIDs []uint) error
+	ListCollectionItems(ctx context.Context, user *User, collectionID uint, query QueryCollectionItems) (*ListedCollectionItems, error)
+	CreateTag(ctx context.Context, user *User, tag Tag) (*Tag, error)
+	RenameTag(ctx context.Context, user *User, tagID uint, tag Tag) (*Tag, error)
+	MergeTags(ctx context.Context, user *User, fromTagID, intoTagID uint) error
+	DeleteTag(ctx context.Context, user *User, tagID uint) error
+	SetAssetTags(ctx context.Context, user *User, assetID uint, assetType AssetType, tagIDs []uint) error
+	ListTags(ctx context.Context, user *User, assetType AssetType) ([]Tag, error)
+	LinkAssets(ctx context.Context, user *User, assetID uint, assetType AssetType, link AssetLink) (*AssetLink, error)
+	UnlinkAssets(ctx context.Context, user *User, assetID uint, assetType AssetType, linkID uint) error
+	AnalyzeChart(ctx context.Context, user *User, chartID uint, query ChartAnalyticsQuery) (*ChartAnalytics, error)
+	GetChartToRender(ctx context.Context, user *User, chartID uint) (*Asset, error)
+	CompareAudiences(ctx context.Context, user *User, audienceIDs []uint) (*AudienceComparison, error)
+	TransitionAsset(ctx context.Context, user *User, assetID uint, assetType AssetType, transition StatusTransition) (*Asset, error)
+	RunScheduledTransitions(ctx context.Context, now time.Time) (*ScheduledTransitions, error)
+	SetAssetTranslation(ctx context.Context, user *User, assetID uint, assetType AssetType, translation AssetTranslation) (*AssetTranslation, error)
+	DeleteAssetTranslation(ctx context.Context, user *User, assetID uint, assetType AssetType, locale string) error
+	ListAssetTranslations(ctx context.Context, user *User, assetID uint, assetType AssetType) ([]AssetTranslation, error)
+	ImportAssets(ctx context.Context, user *User, assetType AssetType, r io.Reader, opts ImportOptions) (*ImportReport, error)
+	ExportAssets(ctx context.Context, user *User, query QueryAssets, favQuery *QueryFavouriteAssets, write func(assets []Asset) error) error
+	FavouriteAssets(ctx context.Context, user *User, batch FavouriteBatch) ([]FavouriteResult, error)
+	TrendingAssets(ctx context.Context, user *User, query QueryTrending) ([]TrendingAsset, error)
+	Recommendations(ctx context.Context, user *User, query QueryRecommendations) ([]RecommendedAsset, error)
+	RecentAssets(ctx context.Context, user *User, query QueryRecentAssets) ([]RecentAsset, error)
+	AssetViewStats(ctx context.Context, user *User, assetID uint, assetType AssetType) (*AssetViewStats, error)
+	AddComment(ctx context.Context, user *User, assetID uint, assetType AssetType, input CommentInput) (*Comment, error)
+	EditComment(ctx context.Context, user *User, assetID uint, assetType AssetType, commentID uint, input CommentInput) (*Comment, error)
+	DeleteComment(ctx context.Context, user *User, assetID uint, assetType AssetType, commentID uint) error
+	ModerateComment(ctx context.Context, user *User, assetID uint, assetType AssetType, commentID uint, moderation CommentModeration) (*Comment, error)
+	ListComments(ctx context.Context, user *User, assetID uint, assetType AssetType, query QueryComments) (*ListedComments, error)
+	ListMentions(ctx context.Context, user *User, query QueryComments) (*ListedComments, error)
+	RateAsset(ctx context.Context, user *User, assetID uint, assetType AssetType, input RatingInput) (*AssetFeedback, error)
+	UnrateAsset(ctx context.Context, user *User, assetID uint, assetType AssetType) (*AssetFeedback, error)
+	FlagAsset(ctx context.Context, user *User, assetID uint, assetType AssetType, input FlagInput) (*AssetFlag, error)
+	FlaggedAssets(ctx context.Context, user *User, query QueryFlaggedAssets) ([]FlaggedAsset, error)
+	ResolveFlags(ctx context.Context, user *User, assetID uint, assetType AssetType, resolution FlagResolution) (int64, error)
+	AddWebhook(ctx context.Context, user *User, input WebhookInput) (*Webhook, error)
+	UpdateWebhook(ctx context.Context, user *User, webhookID uint, input WebhookInput) (*Webhook, error)
+	DeleteWebhook(ctx context.Context, user *User, webhookID uint) error
+	ListWebhooks(ctx context.Context, user *User) ([]Webhook, error)
+	PingWebhook(ctx context.Context, user *User, webhookID uint) (*WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, user *User, webhookID uint, query QueryDeliveries) (*ListedDeliveries, error)
+	RedeliverWebhook(ctx context.Context, user *User, webhookID, deliveryID uint) (*WebhookDelivery, error)
 }
 
 type IDBRepository interface {
-	AddAsset(ctx context.Context, asset InputAsset) (*Asset, error)
-	DeleteAsset(ctx context.Context, at AssetType, assetID uint) error
-	UpdateAsset(ctx context.Context, assetID uint, asset InputAsset) (*Asset, error)
-	GetAsset(ctx context.Context, at AssetType, assetID uint) (*Asset, error)
-	ListAssets(ctx context.Context, query QueryAssets) (*ListedAssets, error)
+	AddAsset(ctx context.Context, scope AssetScope, asset InputAsset) (*Asset, error)
+	DeleteAsset(ctx context.Context, scope AssetScope, at AssetType, assetID uint) error
+	UpdateAsset(ctx context.Context, scope AssetScope, assetID uint, asset InputAsset) (*Asset, error)
+	GetAsset(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error)
+	ListVisibleAssets(ctx context.Context, scope AssetScope, at AssetType, assetIDs []uint) ([]Asset, error)
+	ListAssets(ctx context.Context, scope AssetScope, query QueryAssets) (*ListedAssets, error)
 	RemoveFavouriteAssetFromEveryone(ctx context.Context, assetID uint, at AssetType) error
-	FavouriteAsset(ctx context.Context, userID, assetID uint, at AssetType, isFavourite bool) (uint, error)
-	ListFavouriteAssets(ctx context.Context, userID uint, onlyFav bool, query QueryAssets) (*ListedAssets, error)
+	FavouriteAsset(ctx context.Context, scope AssetScope, userID, assetID uint, at AssetType, isFavourite bool) (bool, error)
+	ListFavouriteAssets(ctx context.Context, scope AssetScope, userID uint, onlyFav bool, query QueryAssets) (*ListedAssets, error)
+	ListTrendingAssets(ctx context.Context, scope AssetScope, at AssetType, since time.Time, limit int) ([]TrendingAsset, error)
+	SetFavouriteNote(ctx context.Context, userID, assetID uint, at AssetType, note FavouriteNote) (bool, error)
 	AddUser(ctx context.Context, user User) (*User, error)
 	FindUser(ctx context.Context, username string) (*User, error)
 	UserExists(ctx context.Context, username string) (bool, error)
 	GetUser(ctx context.Context, userID uint) (*User, error)
+	AddOrganization(ctx context.Context, org Organization) (*Organization, error)
+	GetOrganization(ctx context.Context, orgID uint) (*Organization, error)
+	AddOrganizationMember(ctx context.Context, orgID, userID uint, isAdmin bool) error
+	IsOrganizationMember(ctx context.Context, orgID, userID uint) (bool, error)
+	IsOrganizationAdmin(ctx context.Context, orgID, userID uint) (bool, error)
+	ListUserOrganizations(ctx context.Context, userID uint) ([]Organization, error)
+	AddGroup(ctx context.Context, group Group) (*Group, error)
+	GetGroup(ctx context.Context, groupID uint) (*Group, error)
+	AddGroupMember(ctx context.Context, groupID, userID uint) error
+	AddAssetGrant(ctx context.Context, at AssetType, assetID uint, grant AssetGrant) (*AssetGrant, error)
+	RemoveAssetGrant(ctx context.Context, at AssetType, assetID, grantID uint) error
+	ListAssetGrants(ctx context.Context, at AssetType, assetID uint) ([]AssetGrant, error)
+	RemoveAssetGrants(ctx context.Context, at AssetType, assetID uint) error
+	AddCollection(ctx context.Context, userID uint, collection Collection) (*Collection, error)
+	GetCollection(ctx context.Context, userID, collectionID uint) (*Collection, error)
+	UpdateCollection(ctx context.Context, userID, collectionID uint, collection Collection) (*Collection, error)
+	DeleteCollection(ctx context.Context, userID, collectionID uint) error
+	ListCollections(ctx context.Context, userID uint) ([]Collection, error)
+	AddCollectionItem(ctx context.Context, collectionID uint, item CollectionItem) (*CollectionItem, error)
+	RemoveCollectionItem(ctx context.Context, collectionID, itemID uint) error
+	ReorderCollectionItems(ctx context.Context, collectionID uint, itemIDs []uint) error
+	ListCollectionItems(ctx context.Context, collectionID uint, query QueryCollectionItems) (*ListedCollectionItems, error)
+	RemoveAssetFromCollections(ctx context.Context, at AssetType, assetID uint) error
+	AddTag(ctx context.Context, orgID uint, tag Tag) (*Tag, error)
+	GetTag(ctx context.Context, orgID, tagID uint) (*Tag, error)
+	UpdateTag(ctx context.Context, orgID, tagID uint, tag Tag) (*Tag, error)
+	MergeTags(ctx context.Context, orgID, fromTagID, intoTagID uint) error
+	DeleteTag(ctx context.Context, orgID, tagID uint) error
+	SetAssetTags(ctx context.Context, at AssetType, assetID uint, tagIDs []uint) error
+	ListTags(ctx context.Context, scope AssetScope, at AssetType) ([]Tag, error)
+	RemoveAssetTags(ctx context.Context, at AssetType, assetID uint) error
+	AddAssetLink(ctx context.Context, link AssetLink) (*AssetLink, error)
+	RemoveAssetLink(ctx context.Context, at AssetType, assetID, linkID uint) error
+	ListAssetLinks(ctx context.Context, at AssetType, assetID uint) ([]AssetLink, error)
+	RemoveAssetLinks(ctx context.Context, at AssetType, assetID uint) error
+	SetAssetStatus(ctx context.Context, at AssetType, assetID uint, status AssetStatus, approvedBy uint) error
+	SetAssetEditor(ctx context.Context, at AssetType, assetID uint, editorID uint) error
+	PublishScheduledAssets(ctx context.Context, now time.Time) (int64, error)
+	ArchiveExpiredAssets(ctx context.Context, now time.Time) (int64, error)
+	SetAssetTranslation(ctx context.Context, translation AssetTranslation) (*AssetTranslation, error)
+	RemoveAssetTranslation(ctx context.Context, at AssetType, assetID uint, locale string) (bool, error)
+	ListAssetTranslations(ctx context.Context, at AssetType, assetIDs []uint) ([]AssetTranslation, error)
+	RemoveAssetTranslations(ctx context.Context, at AssetType, assetID uint) error
+	AddAssets(ctx context.Context, scope AssetScope, assets []InputAsset) ([]*Asset, error)
+	FavouriteAssets(ctx context.Context, scope AssetScope, userID uint, items []FavouriteItem, isFavourite bool) ([]FavouriteResult, error)
+	ListFavourites(ctx context.Context, userID uint) ([]FavouriteItem, error)
+	ListAllFavourites(ctx context.Context) ([]UserFavourite, error)
+	ListLiveAudiences(ctx context.Context) ([]Asset, error)
+	ReplaceAssetSimilarities(ctx context.Context, similarities []AssetSimilarity) error
+	ListAssetSimilarities(ctx context.Context, items []FavouriteItem) ([]AssetSimilarity, error)
+	RemoveAssetSimilarities(ctx context.Context, at AssetType, assetID uint) error
+	AddAssetViews(ctx context.Context, views []AssetView) error
+	ListRecentViews(ctx context.Context, userID uint, limit int) ([]RecentAsset, error)
+	GetAssetViewStats(ctx context.Context, at AssetType, assetID uint) (*AssetViewStats, error)
+	PurgeAssetViews(ctx context.Context, before time.Time) (int64, error)
+	RemoveAssetViews(ctx context.Context, at AssetType, assetID uint) error
+	AddComment(ctx context.Context, comment Comment, mentionIDs []uint) (*Comment, error)
+	GetComment(ctx context.Context, at AssetType, assetID, commentID uint) (*Comment, error)
+	UpdateComment(ctx context.Context, commentID uint, text string, mentionIDs []uint) (*Comment, error)
+	RemoveComment(ctx context.Context, commentID uint) error
+	SetCommentHidden(ctx context.Context, commentID uint, hidden bool) error
+	ListComments(ctx context.Context, at AssetType, assetID uint, query QueryComments) (*ListedComments, error)
+	ListMentions(ctx context.Context, userID uint, query QueryComments) (*ListedComments, error)
+	RemoveAssetComments(ctx context.Context, at AssetType, assetID uint) error
+	RateAsset(ctx context.Context, userID uint, at AssetType, assetID uint, rating int) (*AssetFeedback, error)
+	UnrateAsset(ctx context.Context, userID uint, at AssetType, assetID uint) (*AssetFeedback, error)
+	FlagAsset(ctx context.Context, flag AssetFlag) (*AssetFlag, error)
+	ListFlaggedAssets(ctx context.Context, orgID uint, at AssetType, limit int) ([]FlaggedAsset, error)
+	ListOpenFlags(ctx context.Context, at AssetType, assetID uint) ([]AssetFlag, error)
+	ResolveFlags(ctx context.Context, at AssetType, assetID uint, reason FlagReason, resolvedBy uint) (int64, error)
+	RemoveAssetFeedback(ctx context.Context, at AssetType, assetID uint) error
+	AddWebhook(ctx context.Context, hook Webhook) (*Webhook, error)
+	GetWebhook(ctx context.Context, webhookID uint) (*Webhook, error)
+	UpdateWebhook(ctx context.Context, hook Webhook) (*Webhook, error)
+	RemoveWebhook(ctx context.Context, webhookID uint) error
+	ListWebhooks(ctx context.Context, orgID uint) ([]Webhook, error)
+	AddWebhookDeliveries(ctx context.Context, deliveries []WebhookDelivery) ([]WebhookDelivery, error)
+	GetWebhookDelivery(ctx context.Context, webhookID, deliveryID uint) (*WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery WebhookDelivery) error
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, webhookID uint, query QueryDeliveries) (*ListedDeliveries, error)
 }
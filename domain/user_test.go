@@ -122,6 +122,6 @@ func TestAuthenticationFailures(t *testing.T) {
 	assert.ErrorIs(t, err, ErrUnauthorized)
 	_, err = dom.ListAssets(ctx, nil, QueryAssets{}, nil)
 	assert.ErrorIs(t, err, ErrUnauthorized)
-	err = dom.FavouriteAsset(ctx, nil, 1, AudienceAssetType, false)
+	_, err = dom.FavouriteAsset(ctx, nil, 1, AudienceAssetType, false)
 	assert.ErrorIs(t, err, ErrUnauthorized)
 }
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAssetViews(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return &Asset{ID: assetID, Data: &Insight{Text: "text"}}, nil
+	}
+	stored := []AssetView{}
+	mdb.addAssetViews = func(ctx context.Context, views []AssetView) error {
+		stored = append(stored, views...)
+		return nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{ID: 1, Username: "manos"}
+	for _, id := range []uint{1, 2, 1} {
+		_, err := dom.GetAsset(ctx, usr, id, InsightAssetType)
+		assert.NoError(t, err)
+	}
+	n, err := dom.FlushAssetViews(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, 3, len(stored))
+	assert.Equal(t, uint(2), stored[1].AssetID)
+	assert.Equal(t, uint(1), stored[1].UserID)
+
+	// nothing is stored when no asset was viewed
+	n, err = dom.FlushAssetViews(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	// the views that do not fit in the buffer are dropped without blocking
+	for i := 0; i < viewBufferSize+10; i++ {
+		dom.recordView(AssetView{UserID: 1, AssetType: InsightAssetType, AssetID: 1})
+	}
+	n, err = dom.FlushAssetViews(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, viewBufferSize, n)
+}
+
+func TestRecentAssets(t *testing.T) {
+	mdb := &MockDB{}
+	now := time.Now()
+	mdb.listRecentViews = func(ctx context.Context, userID uint, limit int) ([]RecentAsset, error) {
+		return []RecentAsset{
+			{AssetType: ChartAssetType, AssetID: 2, ViewedAt: now},
+			{AssetType: InsightAssetType, AssetID: 3, ViewedAt: now.Add(-time.Minute)},
+			{AssetType: InsightAssetType, AssetID: 1, ViewedAt: now.Add(-time.Hour)},
+		}, nil
+	}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		// the third insight is not visible anymore
+		if assetID == 3 {
+			return nil, errors.New("record not found")
+		}
+		return &Asset{ID: assetID, Data: &Insight{Text: "text"}}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	usr := &User{ID: 1, Username: "manos"}
+	recent, err := dom.RecentAssets(ctx, usr, QueryRecentAssets{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(recent))
+	assert.Equal(t, ChartAssetType, recent[0].AssetType)
+	assert.NotNil(t, recent[0].Asset)
+	assert.Equal(t, uint(1), recent[1].AssetID)
+
+	_, err = dom.RecentAssets(ctx, usr, QueryRecentAssets{Limit: 101})
+	assert.ErrorIs(t, err, ErrWrongQueryInput)
+	_, err = dom.RecentAssets(ctx, nil, QueryRecentAssets{})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestAssetViewStats(t *testing.T) {
+	mdb := &MockDB{}
+	mdb.getAsset = func(ctx context.Context, scope AssetScope, at AssetType, assetID uint) (*Asset, error) {
+		return &Asset{ID: assetID, OrgID: 1, Data: &Insight{Text: "text"}}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	_, err := dom.AssetViewStats(ctx, &User{ID: 1, OrgID: 1}, 1, InsightAssetType)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	_, err = dom.AssetViewStats(ctx, &User{ID: 1, OrgID: 2, IsAdmin: true}, 1, InsightAssetType)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	stats, err := dom.AssetViewStats(ctx, &User{ID: 1, OrgID: 1, IsAdmin: true}, 1, InsightAssetType)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), stats.AssetID)
+}
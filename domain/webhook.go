@@ -0,0 +1,295 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader has the HMAC-SHA256 of the timestamp and the payload, signed with the secret of the webhook
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+
+	// webhookTimeout is how long a receiver has to answer a delivery
+	webhookTimeout = 10 * time.Second
+	// maxWebhookAttempts is the number of attempts of a delivery before it fails
+	maxWebhookAttempts = 8
+	// webhookRetryBase is the wait after the first failed attempt, it doubles after every attempt
+	webhookRetryBase = time.Minute
+	webhookRetryMax  = time.Hour
+	// webhookBatchSize is the number of due deliveries that are sent at once
+	webhookBatchSize = 100
+	// webhookSenders is the number of deliveries that are sent in parallel
+	webhookSenders = 5
+	// maxDeliveryError is the length of the errors that are kept in the delivery log
+	maxDeliveryError = 500
+)
+
+// SignWebhookPayload signs the timestamp and the payload of a delivery with the secret of its webhook.
+// The signature is "sha256=" followed by the hex HMAC-SHA256 of the timestamp, a dot and the payload.
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature tells if the signature of a delivery was made with the secret, for the receivers.
+func VerifyWebhookSignature(secret string, timestamp int64, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(SignWebhookPayload(secret, timestamp, payload)), []byte(signature))
+}
+
+// webhookBackoff is the wait before the next attempt of a delivery after its failed attempts.
+func webhookBackoff(attempts int) time.Duration {
+	wait := webhookRetryBase
+	for i := 1; i < attempts && wait < webhookRetryMax; i++ {
+		wait *= 2
+	}
+	if wait > webhookRetryMax {
+		wait = webhookRetryMax
+	}
+	return wait
+}
+
+func assetTypeOf(asset *Asset) AssetType {
+	switch asset.Data.(type) {
+	case *Insight:
+		return InsightAssetType
+	case *Chart:
+		return ChartAssetType
+	case *Audience:
+		return AudienceAssetType
+	}
+	return ""
+}
+
+func (w *Webhook) receives(event WebhookEvent) bool {
+	if !w.Active {
+		return false
+	}
+	matches := len(w.Events) == 0
+	for _, v := range w.Events {
+		matches = matches || v == event.Event
+	}
+	if !matches {
+		return false
+	}
+	matches = len(w.AssetTypes) == 0
+	for _, v := range w.AssetTypes {
+		matches = matches || v == event.AssetType
+	}
+	return matches
+}
+
+// publishEvents queues a delivery of every event for every active webhook of its organization that receives it.
+// The changes of the assets do not fail with the webhooks, so the errors are only logged.
+func (d *Domain) publishEvents(ctx context.Context, events ...WebhookEvent) {
+	hooks := map[uint][]Webhook{}
+	deliveries := []WebhookDelivery{}
+	now := time.Now()
+	for _, event := range events {
+		orgHooks, ok := hooks[event.OrgID]
+		if !ok {
+			var err error
+			orgHooks, err = d.repo.ListWebhooks(ctx, event.OrgID)
+			if err != nil {
+				log.Println("Error webhooks: ", err)
+				continue
+			}
+			hooks[event.OrgID] = orgHooks
+		}
+		event.OccurredAt = now
+		payload, err := json.Marshal(event)
+		if err != nil {
+			log.Println("Error webhooks: ", err)
+			continue
+		}
+		for _, v := range orgHooks {
+			if !v.receives(event) {
+				continue
+			}
+			deliveries = append(deliveries, WebhookDelivery{
+				WebhookID:     v.ID,
+				Event:         event.Event,
+				Payload:       string(payload),
+				Status:        PendingDelivery,
+				NextAttemptAt: &now,
+			})
+		}
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+	_, err := d.repo.AddWebhookDeliveries(ctx, deliveries)
+	if err != nil {
+		log.Println("Error webhooks: ", err)
+	}
+}
+
+// assetEvent is an event of the asset by the user, with the asset unless it is favourited.
+func assetEvent(eventType WebhookEventType, user *User, asset *Asset) WebhookEvent {
+	event := WebhookEvent{
+		Event:     eventType,
+		OrgID:     asset.OrgID,
+		AssetType: assetTypeOf(asset),
+		AssetID:   asset.ID,
+		UserID:    user.ID,
+	}
+	if eventType != AssetFavouritedEvent {
+		event.Asset = asset
+	}
+	return event
+}
+
+// publishFavourite publishes that the user favoured an asset that the user sees.
+func (d *Domain) publishFavourite(ctx context.Context, user *User, assetID uint, assetType AssetType) {
+	asset, err := d.repo.GetAsset(ctx, scopeOf(user), assetType, assetID)
+	if err != nil {
+		log.Println("Error webhooks: ", err)
+		return
+	}
+	d.publishEvents(ctx, assetEvent(AssetFavouritedEvent, user, asset))
+}
+
+// sendDelivery posts the payload of the delivery to the webhook and returns the status code of the answer.
+// The answers without a 2xx status fail.
+func (d *Domain) sendDelivery(ctx context.Context, hook *Webhook, delivery *WebhookDelivery, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, string(delivery.Event))
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(hook.Secret, timestamp, []byte(delivery.Payload)))
+	resp, err := d.webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("the receiver answered %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// attemptDelivery sends the delivery once and stores its outcome: it is delivered, or it is attempted again
+// after the backoff of its attempts, or it fails after its last attempt or when its webhook is not active.
+func (d *Domain) attemptDelivery(ctx context.Context, hook *Webhook, delivery *WebhookDelivery, now time.Time) error {
+	var code int
+	err := errors.New("the webhook is not active")
+	if hook.Active {
+		code, err = d.sendDelivery(ctx, hook, delivery, now)
+	}
+	delivery.Attempts++
+	delivery.LastStatusCode = code
+	delivery.LastError = ""
+	delivery.NextAttemptAt = nil
+	switch {
+	case err == nil:
+		delivery.Status = DeliveredDelivery
+		delivery.DeliveredAt = &now
+	case !hook.Active || delivery.Attempts >= maxWebhookAttempts:
+		delivery.Status = FailedDelivery
+	default:
+		delivery.Status = PendingDelivery
+		next := now.Add(webhookBackoff(delivery.Attempts))
+		delivery.NextAttemptAt = &next
+	}
+	if err != nil {
+		delivery.LastError = err.Error()
+		if len(delivery.LastError) > maxDeliveryError {
+			delivery.LastError = delivery.LastError[:maxDeliveryError]
+		}
+	}
+	return d.repo.UpdateWebhookDelivery(ctx, *delivery)
+}
+
+// DeliverWebhooks attempts the pending deliveries that are due at the time and returns their number.
+// The deliveries of a webhook that cannot be read are left for a next run.
+func (d *Domain) DeliverWebhooks(ctx context.Context, now time.Time) (int, error) {
+	due, err := d.repo.ListDueDeliveries(ctx, now, webhookBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+	}
+	var firstErr error
+	hooks := map[uint]*Webhook{}
+	for _, v := range due {
+		if _, ok := hooks[v.WebhookID]; ok {
+			continue
+		}
+		hook, err := d.repo.GetWebhook(ctx, v.WebhookID)
+		if errors.Is(err, ErrWebhookNotFound) {
+			// the deliveries of a removed webhook fail on their next attempt
+			hook = &Webhook{ID: v.WebhookID}
+		} else if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+			}
+			hook = nil
+		}
+		hooks[v.WebhookID] = hook
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	senders := make(chan struct{}, webhookSenders)
+	for i := range due {
+		if hooks[due[i].WebhookID] == nil {
+			continue
+		}
+		wg.Add(1)
+		senders <- struct{}{}
+		go func(delivery *WebhookDelivery) {
+			defer wg.Done()
+			err := d.attemptDelivery(ctx, hooks[delivery.WebhookID], delivery, now)
+			<-senders
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%w: %v", ErrInternalDBFailure, err)
+				}
+				mu.Unlock()
+			}
+		}(&due[i])
+	}
+	wg.Wait()
+	return len(due), firstErr
+}
+
+// RunWebhookDispatcher attempts the due deliveries of the webhooks every interval until the context is done.
+func (d *Domain) RunWebhookDispatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := d.DeliverWebhooks(ctx, time.Now())
+		if err != nil {
+			log.Println("Error webhooks: ", err)
+		} else if n == webhookBatchSize && ctx.Err() == nil {
+			// more deliveries are due, they are attempted without waiting
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// webhookMockDB keeps the deliveries of the webhooks in memory.
+func webhookMockDB(hooks []Webhook) (*MockDB, map[uint]*WebhookDelivery) {
+	var mu sync.Mutex
+	deliveries := map[uint]*WebhookDelivery{}
+	mdb := &MockDB{}
+	mdb.listWebhooks = func(ctx context.Context, orgID uint) ([]Webhook, error) {
+		orgHooks := []Webhook{}
+		for _, v := range hooks {
+			if v.OrgID == orgID {
+				orgHooks = append(orgHooks, v)
+			}
+		}
+		return orgHooks, nil
+	}
+	mdb.getWebhook = func(ctx context.Context, webhookID uint) (*Webhook, error) {
+		for _, v := range hooks {
+			if v.ID == webhookID {
+				hook := v
+				return &hook, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %v", ErrWebhookNotFound, errors.New("record not found"))
+	}
+	mdb.addWebhookDeliveries = func(ctx context.Context, added []WebhookDelivery) ([]WebhookDelivery, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		for i := range added {
+			added[i].ID = uint(len(deliveries) + 1)
+			delivery := added[i]
+			deliveries[delivery.ID] = &delivery
+		}
+		return added, nil
+	}
+	mdb.getWebhookDelivery = func(ctx context.Context, webhookID, deliveryID uint) (*WebhookDelivery, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		delivery, ok := deliveries[deliveryID]
+		if !ok || delivery.WebhookID != webhookID {
+			return nil, errors.New("record not found")
+		}
+		return delivery, nil
+	}
+	mdb.updateWebhookDelivery = func(ctx context.Context, delivery WebhookDelivery) error {
+		mu.Lock()
+		defer mu.Unlock()
+		deliveries[delivery.ID] = &delivery
+		return nil
+	}
+	mdb.listDueDeliveries = func(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		due := []WebhookDelivery{}
+		for id := uint(1); id <= uint(len(deliveries)); id++ {
+			v := deliveries[id]
+			if v.Status == PendingDelivery && v.NextAttemptAt != nil && !v.NextAttemptAt.After(now) {
+				due = append(due, *v)
+			}
+		}
+		return due, nil
+	}
+	return mdb, deliveries
+}
+
+// webhookReceiver answers with 500 to the first failures and keeps the requests that it accepts.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	failures int
+	requests []*http.Request
+	payloads [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	payload, _ := io.ReadAll(req.Body)
+	r.requests = append(r.requests, req)
+	r.payloads = append(r.payloads, payload)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestWebhookSignature(t *testing.T) {
+	payload := []byte(`{"event":"ping"}`)
+	signature := SignWebhookPayload("secret", 1700000000, payload)
+	assert.Regexp(t, "^sha256=[0-9a-f]{64}$", signature)
+	assert.True(t, VerifyWebhookSignature("secret", 1700000000, payload, signature))
+	assert.False(t, VerifyWebhookSignature("other", 1700000000, payload, signature))
+	assert.False(t, VerifyWebhookSignature("secret", 1700000001, payload, signature))
+	assert.False(t, VerifyWebhookSignature("secret", 1700000000, []byte(`{"event":"pong"}`), signature))
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, time.Minute, webhookBackoff(1))
+	assert.Equal(t, 2*time.Minute, webhookBackoff(2))
+	assert.Equal(t, 32*time.Minute, webhookBackoff(6))
+	assert.Equal(t, time.Hour, webhookBackoff(7))
+	assert.Equal(t, time.Hour, webhookBackoff(20))
+}
+
+func TestPublishAndDeliverWebhooks(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	hooks := []Webhook{
+		{ID: 1, OrgID: 1, URL: server.URL, Active: true, Secret: "secret-of-the-first",
+			Events: []WebhookEventType{AssetCreatedEvent}, AssetTypes: []AssetType{InsightAssetType}},
+		{ID: 2, OrgID: 1, URL: server.URL, Active: true, Secret: "secret-of-the-second",
+			AssetTypes: []AssetType{ChartAssetType}},
+		{ID: 3, OrgID: 1, URL: server.URL, Active: false, Secret: "secret-of-the-third"},
+		{ID: 4, OrgID: 2, URL: server.URL, Active: true, Secret: "secret-of-the-fourth"},
+	}
+	mdb, deliveries := webhookMockDB(hooks)
+	mdb.addAsset = func(ctx context.Context, scope AssetScope, asset InputAsset) (*Asset, error) {
+		return &Asset{ID: 7, OrgID: scope.OrgID, Data: asset.Data}, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	admin := &User{ID: 1, IsAdmin: true, OrgID: 1}
+
+	_, err := dom.AddAsset(ctx, admin, InputAsset{Data: &Insight{Text: "40% of millenials", Description: "bla bla"}})
+	assert.NoError(t, err)
+	// only the first webhook receives the new insights of the organization
+	assert.Equal(t, 1, len(deliveries))
+	assert.Equal(t, uint(1), deliveries[1].WebhookID)
+
+	now := time.Now()
+	n, err := dom.DeliverWebhooks(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, DeliveredDelivery, deliveries[1].Status)
+	assert.Equal(t, 1, deliveries[1].Attempts)
+	assert.Equal(t, http.StatusNoContent, deliveries[1].LastStatusCode)
+
+	assert.Equal(t, 1, len(receiver.requests))
+	req := receiver.requests[0]
+	assert.Equal(t, string(AssetCreatedEvent), req.Header.Get(WebhookEventHeader))
+	assert.Equal(t, "1", req.Header.Get(WebhookDeliveryHeader))
+	timestamp, _ := strconv.ParseInt(req.Header.Get(WebhookTimestampHeader), 10, 64)
+	assert.True(t, VerifyWebhookSignature("secret-of-the-first", timestamp, receiver.payloads[0], req.Header.Get(WebhookSignatureHeader)))
+	event := struct {
+		Event     WebhookEventType `json:"event"`
+		OrgID     uint             `json:"orgID"`
+		AssetType AssetType        `json:"assetType"`
+		AssetID   uint             `json:"assetID"`
+		Asset     struct {
+			Data Insight `json:"data"`
+		} `json:"asset"`
+	}{}
+	assert.NoError(t, json.Unmarshal(receiver.payloads[0], &event))
+	assert.Equal(t, AssetCreatedEvent, event.Event)
+	assert.Equal(t, uint(1), event.OrgID)
+	assert.Equal(t, InsightAssetType, event.AssetType)
+	assert.Equal(t, uint(7), event.AssetID)
+	assert.Equal(t, "40% of millenials", event.Asset.Data.Text)
+
+	// nothing is due anymore
+	n, err = dom.DeliverWebhooks(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestRetryWebhookDeliveries(t *testing.T) {
+	receiver := &webhookReceiver{failures: 2}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	mdb, deliveries := webhookMockDB([]Webhook{{ID: 1, OrgID: 1, URL: server.URL, Active: true, Secret: "secret"}})
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	dom.publishEvents(ctx, WebhookEvent{Event: AssetDeletedEvent, OrgID: 1, AssetType: ChartAssetType, AssetID: 3})
+
+	now := time.Now()
+	_, err := dom.DeliverWebhooks(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, PendingDelivery, deliveries[1].Status)
+	assert.Equal(t, http.StatusInternalServerError, deliveries[1].LastStatusCode)
+	assert.NotEmpty(t, deliveries[1].LastError)
+	assert.Equal(t, now.Add(time.Minute), *deliveries[1].NextAttemptAt)
+
+	// the delivery is not attempted before its backoff
+	n, err := dom.DeliverWebhooks(ctx, now.Add(30*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	now = now.Add(time.Minute)
+	_, err = dom.DeliverWebhooks(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deliveries[1].Attempts)
+	assert.Equal(t, now.Add(2*time.Minute), *deliveries[1].NextAttemptAt)
+
+	now = now.Add(2 * time.Minute)
+	_, err = dom.DeliverWebhooks(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, DeliveredDelivery, deliveries[1].Status)
+	assert.Equal(t, 3, deliveries[1].Attempts)
+	assert.Empty(t, deliveries[1].LastError)
+	assert.Nil(t, deliveries[1].NextAttemptAt)
+	assert.Equal(t, 1, len(receiver.requests))
+}
+
+func TestFailedAndRedeliveredWebhook(t *testing.T) {
+	receiver := &webhookReceiver{failures: maxWebhookAttempts}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	mdb, deliveries := webhookMockDB([]Webhook{{ID: 1, OrgID: 1, URL: server.URL, Active: true, Secret: "secret"}})
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	dom.publishEvents(ctx, WebhookEvent{Event: AssetFavouritedEvent, OrgID: 1, AssetType: ChartAssetType, AssetID: 3, UserID: 2})
+
+	now := time.Now()
+	for i := 0; i < maxWebhookAttempts; i++ {
+		_, err := dom.DeliverWebhooks(ctx, now)
+		assert.NoError(t, err)
+		now = now.Add(webhookRetryMax)
+	}
+	assert.Equal(t, FailedDelivery, deliveries[1].Status)
+	assert.Equal(t, maxWebhookAttempts, deliveries[1].Attempts)
+	assert.Nil(t, deliveries[1].NextAttemptAt)
+
+	admin := &User{ID: 1, IsAdmin: true, OrgID: 1}
+	redelivered, err := dom.RedeliverWebhook(ctx, admin, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, DeliveredDelivery, redelivered.Status)
+	assert.Equal(t, uint(1), redelivered.RedeliveryOf)
+	assert.Equal(t, deliveries[1].Payload, string(receiver.payloads[0]))
+
+	_, err = dom.RedeliverWebhook(ctx, admin, 1, 9)
+	assert.ErrorIs(t, err, ErrDeliveryNotFound)
+	// the webhooks of another organization are not found
+	_, err = dom.RedeliverWebhook(ctx, &User{ID: 3, IsAdmin: true, OrgID: 2}, 1, 1)
+	assert.ErrorIs(t, err, ErrWebhookNotFound)
+	_, err = dom.RedeliverWebhook(ctx, &User{ID: 3, OrgID: 1}, 1, 1)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestAddAndPingWebhook(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	hooks := []Webhook{}
+	mdb, _ := webhookMockDB(nil)
+	mdb.addWebhook = func(ctx context.Context, hook Webhook) (*Webhook, error) {
+		hook.ID = uint(len(hooks) + 1)
+		hooks = append(hooks, hook)
+		return &hook, nil
+	}
+	mdb.getWebhook = func(ctx context.Context, webhookID uint) (*Webhook, error) {
+		hook := hooks[webhookID-1]
+		return &hook, nil
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	admin := &User{ID: 1, IsAdmin: true, OrgID: 1}
+
+	inactive := false
+	hook, err := dom.AddWebhook(ctx, admin, WebhookInput{URL: server.URL, Active: &inactive})
+	assert.NoError(t, err)
+	assert.Equal(t, 2*webhookSecretSize, len(hook.Secret))
+	assert.Equal(t, uint(1), hook.OrgID)
+	assert.False(t, hook.Active)
+
+	// the pings are sent also to the inactive webhooks
+	delivery, err := dom.PingWebhook(ctx, admin, hook.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, DeliveredDelivery, delivery.Status)
+	assert.Equal(t, string(PingEvent), receiver.requests[0].Header.Get(WebhookEventHeader))
+
+	for _, input := range []WebhookInput{
+		{URL: "not a url"},
+		{URL: server.URL, Events: []WebhookEventType{"asset.viewed"}},
+		{URL: server.URL, AssetTypes: []AssetType{"maps"}},
+		{URL: server.URL, Secret: "short"},
+	} {
+		_, err = dom.AddWebhook(ctx, admin, input)
+		assert.ErrorIs(t, err, ErrWrongWebhookInput)
+	}
+	_, err = dom.AddWebhook(ctx, &User{ID: 2, OrgID: 1}, WebhookInput{URL: server.URL})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestWebhookLookupFailures(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	hooks := []Webhook{
+		{ID: 1, OrgID: 1, URL: server.URL, Active: true, Secret: "secret"},
+		{ID: 2, OrgID: 2, URL: server.URL, Active: true, Secret: "secret"},
+	}
+	mdb, deliveries := webhookMockDB(hooks)
+	listWebhooks := mdb.listWebhooks
+	mdb.listWebhooks = func(ctx context.Context, orgID uint) ([]Webhook, error) {
+		if orgID == 1 {
+			return nil, errors.New("connection refused")
+		}
+		return listWebhooks(ctx, orgID)
+	}
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	// the events of the other organizations are published without the webhooks of the first one
+	dom.publishEvents(ctx,
+		WebhookEvent{Event: AssetDeletedEvent, OrgID: 1, AssetType: ChartAssetType, AssetID: 3},
+		WebhookEvent{Event: AssetDeletedEvent, OrgID: 2, AssetType: ChartAssetType, AssetID: 4})
+	assert.Equal(t, 1, len(deliveries))
+	assert.Equal(t, uint(2), deliveries[1].WebhookID)
+
+	// the deliveries are left pending when their webhook cannot be read
+	getWebhook := mdb.getWebhook
+	mdb.getWebhook = func(ctx context.Context, webhookID uint) (*Webhook, error) {
+		return nil, errors.New("connection refused")
+	}
+	now := time.Now()
+	_, err := dom.DeliverWebhooks(ctx, now)
+	assert.ErrorIs(t, err, ErrInternalDBFailure)
+	assert.Equal(t, PendingDelivery, deliveries[1].Status)
+	assert.Equal(t, 0, deliveries[1].Attempts)
+
+	mdb.getWebhook = getWebhook
+	_, err = dom.DeliverWebhooks(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, DeliveredDelivery, deliveries[1].Status)
+
+	// the deliveries of a removed webhook fail
+	dom.publishEvents(ctx, WebhookEvent{Event: AssetDeletedEvent, OrgID: 2, AssetType: ChartAssetType, AssetID: 5})
+	mdb.getWebhook = func(ctx context.Context, webhookID uint) (*Webhook, error) {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookNotFound, errors.New("record not found"))
+	}
+	_, err = dom.DeliverWebhooks(ctx, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, FailedDelivery, deliveries[2].Status)
+}
+
+func TestPingIsNotDispatchedWhileSent(t *testing.T) {
+	hooks := []Webhook{{ID: 1, OrgID: 1, Active: true, Secret: "secret"}}
+	mdb, deliveries := webhookMockDB(hooks)
+	dom := NewDomain(mdb)
+	ctx := context.Background()
+	requests := 0
+	dispatched := -1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if requests == 1 {
+			// the dispatcher runs while the ping is sent
+			dispatched, _ = dom.DeliverWebhooks(ctx, time.Now().Add(time.Hour))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	hooks[0].URL = server.URL
+
+	delivery, err := dom.PingWebhook(ctx, &User{ID: 1, IsAdmin: true, OrgID: 1}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, DeliveredDelivery, delivery.Status)
+	assert.Equal(t, 0, dispatched)
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, 1, deliveries[1].Attempts)
+}
@@ -1,13 +1,29 @@
 package httpapi
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"platform-go-challenge/domain"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
+// timeQueryParam parses an optional RFC 3339 time of the query.
+func timeQueryParam(c echo.Context, name string) (*time.Time, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, fmt.Errorf("%s should be an RFC 3339 time", name)
+	}
+	return &t, nil
+}
+
 func (s *Server) addAssetHandler(c echo.Context) error {
 	user, err := getUserDomain(c)
 	if err != nil {
@@ -56,8 +72,26 @@ func (s *Server) addAssetHandler(c echo.Context) error {
 		}
 		assetData = &in
 	}
+	publishAt, err := timeQueryParam(c, "publishAt")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	expireAt, err := timeQueryParam(c, "expireAt")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
 	asset := domain.InputAsset{
-		Data: assetData,
+		Data:       assetData,
+		Visibility: domain.Visibility(c.QueryParam("visibility")),
+		PublishAt:  publishAt,
+		ExpireAt:   expireAt,
+		Locale:     c.QueryParam("locale"),
 	}
 	newAsset, err := s.domain.AddAsset(c.Request().Context(), user, asset)
 	if err != nil {
@@ -67,6 +101,7 @@ func (s *Server) addAssetHandler(c echo.Context) error {
 		})
 	}
 
+	localizeAssets(c, newAsset)
 	return c.JSON(http.StatusOK, newAsset)
 }
 
@@ -169,17 +204,53 @@ func (s *Server) updateAssetHandler(c echo.Context) error {
 		}
 		assetData = &in
 	}
+	publishAt, err := timeQueryParam(c, "publishAt")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	expireAt, err := timeQueryParam(c, "expireAt")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
 	asset := domain.InputAsset{
-		Data: assetData,
+		Data:       assetData,
+		Visibility: domain.Visibility(c.QueryParam("visibility")),
+		PublishAt:  publishAt,
+		ExpireAt:   expireAt,
+		Locale:     c.QueryParam("locale"),
 	}
 
 	newAsset, err := s.domain.UpdateAsset(c.Request().Context(), user, uint(assetId), asset)
 	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnauthorized):
+			return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+				"status": "Unauthorized",
+				"error":  err.Error(),
+			})
+		case errors.Is(err, domain.ErrWrongAssetInput), errors.Is(err, domain.ErrWrongStatusInput):
+			return c.JSON(http.StatusBadRequest, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		case errors.Is(err, domain.ErrAssetNotFound):
+			return c.JSON(http.StatusNotFound, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, ResponseStatus{
 			Status: FailureStatus,
 			Error:  err.Error(),
 		})
 	}
+	localizeAssets(c, newAsset)
 	return c.JSON(http.StatusOK, newAsset)
 }
 
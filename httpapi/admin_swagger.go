@@ -10,6 +10,10 @@ import "github.com/labstack/echo/v4"
 // @Accept       json
 // @Produce      json
 // @Param        insight  body  domain.Insight  true  "insight"
+// @Param        visibility  query  string  false  "public, organization or private"
+// @Param        publishAt  query  string  false  "RFC 3339 time to publish the approved asset at"
+// @Param        expireAt  query  string  false  "RFC 3339 time to archive the asset at"
+// @Param        locale  query  string  false  "ISO 639-1 language of the texts, en by default"
 // @Success      200  {object}  AssetInsightJson
 // @Failure      401  {object}	ResponseStatus
 // @Router       /api/v1/admin/insights [POST]
@@ -24,6 +28,10 @@ func (s *Server) addInsightHandler(c echo.Context) error {
 // @Accept       json
 // @Produce      json
 // @Param        chart  body  domain.Chart  true  "chart"
+// @Param        visibility  query  string  false  "public, organization or private"
+// @Param        publishAt  query  string  false  "RFC 3339 time to publish the approved asset at"
+// @Param        expireAt  query  string  false  "RFC 3339 time to archive the asset at"
+// @Param        locale  query  string  false  "ISO 639-1 language of the texts, en by default"
 // @Success      200  {object}  AssetChartJson
 // @Failure      401  {object}	ResponseStatus
 // @Router       /api/v1/admin/charts [POST]
@@ -38,6 +46,10 @@ func (s *Server) addChartHandler(c echo.Context) error {
 // @Accept       json
 // @Produce      json
 // @Param        audience  body  domain.Audience  true  "audience"
+// @Param        visibility  query  string  false  "public, organization or private"
+// @Param        publishAt  query  string  false  "RFC 3339 time to publish the approved asset at"
+// @Param        expireAt  query  string  false  "RFC 3339 time to archive the asset at"
+// @Param        locale  query  string  false  "ISO 639-1 language of the texts, en by default"
 // @Success      200  {object}  AssetAudienceJson
 // @Failure      401  {object}	ResponseStatus
 // @Router       /api/v1/admin/audiences [POST]
@@ -47,13 +59,18 @@ func (s *Server) addAudienceHandler(c echo.Context) error {
 }
 
 // @Summary      Update Insight
-// @Description  Update an existing asset from insights
+// @Description  Update an existing asset from insights, only in draft or archived status
 // @Tags         admin
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "Insight ID"
 // @Param        insight  body  domain.Insight  true  "insight"
+// @Param        visibility  query  string  false  "public, organization or private"
+// @Param        publishAt  query  string  false  "RFC 3339 time to publish the approved asset at"
+// @Param        expireAt  query  string  false  "RFC 3339 time to archive the asset at"
+// @Param        locale  query  string  false  "ISO 639-1 language of the texts, en by default"
 // @Success      200  {object}  AssetInsightJson
+// @Failure      400  {object}	ResponseStatus
 // @Failure      401  {object}	ResponseStatus
 // @Router       /api/v1/admin/insights/{id} [PUT]
 // @Security     BearerAuth
@@ -62,13 +79,18 @@ func (s *Server) updateInsightHandler(c echo.Context) error {
 }
 
 // @Summary      Update Chart
-// @Description  Update an existing asset from charts
+// @Description  Update an existing asset from charts, only in draft or archived status
 // @Tags         admin
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "Chart ID"
 // @Param        chart  body  domain.Chart  true  "chart"
+// @Param        visibility  query  string  false  "public, organization or private"
+// @Param        publishAt  query  string  false  "RFC 3339 time to publish the approved asset at"
+// @Param        expireAt  query  string  false  "RFC 3339 time to archive the asset at"
+// @Param        locale  query  string  false  "ISO 639-1 language of the texts, en by default"
 // @Success      200  {object}  AssetChartJson
+// @Failure      400  {object}	ResponseStatus
 // @Failure      401  {object}	ResponseStatus
 // @Router       /api/v1/admin/charts/{id} [PUT]
 // @Security     BearerAuth
@@ -77,13 +99,18 @@ func (s *Server) updateChartHandler(c echo.Context) error {
 }
 
 // @Summary      Update Audience
-// @Description  Update an existing asset from audiences
+// @Description  Update an existing asset from audiences, only in draft or archived status
 // @Tags         admin
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "Audience ID"
 // @Param        audience  body  domain.Audience  true  "audience"
+// @Param        visibility  query  string  false  "public, organization or private"
+// @Param        publishAt  query  string  false  "RFC 3339 time to publish the approved asset at"
+// @Param        expireAt  query  string  false  "RFC 3339 time to archive the asset at"
+// @Param        locale  query  string  false  "ISO 639-1 language of the texts, en by default"
 // @Success      200  {object}  AssetAudienceJson
+// @Failure      400  {object}	ResponseStatus
 // @Failure      401  {object}	ResponseStatus
 // @Router       /api/v1/admin/audiences/{id} [PUT]
 // @Security     BearerAuth
@@ -0,0 +1,68 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/domain"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary      Compare Audiences
+// @Description  Compare from 2 to 10 audiences and get the segment of their common users, the differences of every dimension and a similarity score from 0 to 1
+// @Tags         user
+// @Produce      json
+// @Param        ids   query      string  true  "comma separated audience IDs"
+// @Success      200  {object}  domain.AudienceComparison
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/audiences/compare [GET]
+// @Security     BearerAuth
+func (s *Server) compareAudiencesHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	ids := []uint{}
+	for _, param := range c.QueryParams()["ids"] {
+		for _, idStr := range strings.Split(param, ",") {
+			id, err := strconv.ParseUint(strings.TrimSpace(idStr), 10, 64)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, ResponseStatus{
+					Status: FailureStatus,
+					Error:  "audience ID not a number",
+				})
+			}
+			ids = append(ids, uint(id))
+		}
+	}
+	cmp, err := s.domain.CompareAudiences(c.Request().Context(), user, ids)
+	if err != nil {
+		if errors.Is(err, domain.ErrWrongQueryInput) {
+			return c.JSON(http.StatusBadRequest, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		if errors.Is(err, domain.ErrAssetNotFound) {
+			return c.JSON(http.StatusNotFound, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	if cmp.Intersection != nil {
+		cmp.Intersection.LocalizeCountries(requestLanguages(c))
+	}
+	return c.JSON(http.StatusOK, cmp)
+}
@@ -65,6 +65,7 @@ func (s *Server) loginUserHandler(c echo.Context) error {
 	u, err := s.domain.LoginUser(c.Request().Context(), domain.LoginCredentials{
 		Username: in.Username,
 		Password: in.Password,
+		OrgID:    in.OrgID,
 	})
 	if err != nil {
 		return c.JSON(http.StatusUnauthorized, ResponseStatus{
@@ -82,6 +83,7 @@ func (s *Server) loginUserHandler(c echo.Context) error {
 		u.ID,
 		in.Username,
 		u.IsAdmin,
+		u.OrgID,
 		jwt.StandardClaims{
 			ExpiresAt: expiresAt,
 		},
@@ -102,6 +104,7 @@ func (s *Server) loginUserHandler(c echo.Context) error {
 		Token:     &t,
 		ExpiresAt: &expiresAt,
 		Username:  &in.Username,
+		OrgID:     &u.OrgID,
 	})
 }
 
@@ -0,0 +1,163 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/chartrender"
+	"platform-go-challenge/domain"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary      Render Chart
+// @Description  Draw a chart as an SVG or a PNG image with its titles, its axes and a legend
+// @Tags         user
+// @Produce      image/svg+xml
+// @Produce      image/png
+// @Param        id   path      int  true  "Chart ID"
+// @Param        format   query      string  false  "svg (default) or png"
+// @Param        width   query      int  false  "width in pixels, 800 by default"
+// @Param        height   query      int  false  "height in pixels, 500 by default"
+// @Success      200
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/charts/{id}/render [GET]
+// @Security     BearerAuth
+func (s *Server) renderChartHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	chartId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "chart ID not a number",
+		})
+	}
+	opts := chartrender.Options{Format: chartrender.Format(c.QueryParam("format"))}
+	for param, v := range map[string]*int{"width": &opts.Width, "height": &opts.Height} {
+		if c.QueryParam(param) == "" {
+			continue
+		}
+		*v, err = strconv.Atoi(c.QueryParam(param))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ResponseStatus{
+				Status: FailureStatus,
+				Error:  param + " not a number",
+			})
+		}
+	}
+	err = opts.Validate()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	asset, err := s.domain.GetChartToRender(c.Request().Context(), user, uint(chartId))
+	if err != nil {
+		if !errors.Is(err, domain.ErrAssetNotFound) {
+			return c.JSON(http.StatusInternalServerError, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusNotFound, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	chart, ok := asset.Data.(*domain.Chart)
+	if !ok {
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset is not a chart",
+		})
+	}
+
+	key := chartrender.Key(asset.ID, asset.Version, asset.Locale, opts)
+	etag := `"` + key + `"`
+	c.Response().Header().Set("ETag", etag)
+	if c.Request().Header.Get("If-None-Match") == etag {
+		return c.NoContent(http.StatusNotModified)
+	}
+	image, ok := s.charts.Get(key)
+	if !ok {
+		image, err = chartrender.Render(chart, opts)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		s.charts.Add(key, image)
+	}
+	return c.Blob(http.StatusOK, opts.Format.ContentType(), image)
+}
+
+// @Summary      Chart Analytics
+// @Description  Get the min, max, mean, median, linear regression and correlation of every series of a chart, with optional moving average, resampling and downsampling
+// @Tags         user
+// @Produce      json
+// @Param        id   path      int  true  "Chart ID"
+// @Param        movingAverage   query      int  false  "window of the moving average"
+// @Param        resample   query      int  false  "number of buckets of equal X width"
+// @Param        maxPoints   query      int  false  "maximum number of points of the returned series"
+// @Success      200  {object}  domain.ChartAnalytics
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/charts/{id}/analytics [GET]
+// @Security     BearerAuth
+func (s *Server) chartAnalyticsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	chartId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "chart ID not a number",
+		})
+	}
+	query := domain.ChartAnalyticsQuery{}
+	err = (&echo.DefaultBinder{}).BindQueryParams(c, &query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	analytics, err := s.domain.AnalyzeChart(c.Request().Context(), user, uint(chartId), query)
+	if err != nil {
+		if errors.Is(err, domain.ErrWrongQueryInput) {
+			return c.JSON(http.StatusBadRequest, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		if errors.Is(err, domain.ErrAssetNotFound) {
+			return c.JSON(http.StatusNotFound, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, analytics)
+}
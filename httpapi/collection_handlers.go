@@ -0,0 +1,355 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/domain"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary      List my collections
+// @Description  Get the collections of favourite assets of the user
+// @Tags         collections
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   domain.Collection
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/me/collections [GET]
+// @Security     BearerAuth
+func (s *Server) listCollectionsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	collections, err := s.domain.ListCollections(c.Request().Context(), user)
+	if err != nil {
+		return collectionErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, collections)
+}
+
+// @Summary      Create Collection
+// @Description  Create a named collection for favourite assets of any type
+// @Tags         collections
+// @Accept       json
+// @Produce      json
+// @Param        collection  body  domain.Collection  true  "collection"
+// @Success      200  {object}  domain.Collection
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/me/collections [POST]
+// @Security     BearerAuth
+func (s *Server) createCollectionHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	in := domain.Collection{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	collection, err := s.domain.CreateCollection(c.Request().Context(), user, in)
+	if err != nil {
+		return collectionErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, collection)
+}
+
+// @Summary      Rename Collection
+// @Description  Rename a collection of the user
+// @Tags         collections
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Collection ID"
+// @Param        collection  body  domain.Collection  true  "collection"
+// @Success      200  {object}  domain.Collection
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/me/collections/{id} [PUT]
+// @Security     BearerAuth
+func (s *Server) renameCollectionHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	collectionId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "collection ID not a number",
+		})
+	}
+	in := domain.Collection{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	collection, err := s.domain.RenameCollection(c.Request().Context(), user, uint(collectionId), in)
+	if err != nil {
+		return collectionErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, collection)
+}
+
+// @Summary      Delete Collection
+// @Description  Delete a collection of the user together with its items
+// @Tags         collections
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Collection ID"
+// @Success      200  {object}  ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/me/collections/{id} [DELETE]
+// @Security     BearerAuth
+func (s *Server) deleteCollectionHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	collectionId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "collection ID not a number",
+		})
+	}
+	err = s.domain.DeleteCollection(c.Request().Context(), user, uint(collectionId))
+	if err != nil {
+		return collectionErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ResponseStatus{
+		Status: SuccessStatus,
+	})
+}
+
+// @Summary      List Collection Items
+// @Description  Get the items of a collection in the user-defined order, starting after the last position
+// @Tags         collections
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Collection ID"
+// @Param        limit   query      int  true  "number of items in the page"
+// @Param        lastPosition   query      int  false  "position of the last item of the previous page"
+// @Success      200  {object}  domain.ListedCollectionItems
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/me/collections/{id}/items [GET]
+// @Security     BearerAuth
+func (s *Server) listCollectionItemsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	collectionId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "collection ID not a number",
+		})
+	}
+	query := domain.QueryCollectionItems{}
+	err = (&echo.DefaultBinder{}).BindQueryParams(c, &query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	ls, err := s.domain.ListCollectionItems(c.Request().Context(), user, uint(collectionId), query)
+	if err != nil {
+		return collectionErrorResponse(c, err)
+	}
+	for _, item := range ls.Items {
+		localizeAssets(c, item.Asset)
+	}
+	return c.JSON(http.StatusOK, ls)
+}
+
+// @Summary      Add Collection Item
+// @Description  Add an asset of any type at the end of a collection
+// @Tags         collections
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Collection ID"
+// @Param        item  body  domain.CollectionItem  true  "the assetType and the assetID"
+// @Success      200  {object}  domain.CollectionItem
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/me/collections/{id}/items [POST]
+// @Security     BearerAuth
+func (s *Server) addCollectionItemHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	collectionId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "collection ID not a number",
+		})
+	}
+	in := domain.CollectionItem{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	item, err := s.domain.AddCollectionItem(c.Request().Context(), user, uint(collectionId), in)
+	if err != nil {
+		return collectionErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, item)
+}
+
+// @Summary      Remove Collection Item
+// @Description  Remove an item from a collection
+// @Tags         collections
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Collection ID"
+// @Param        itemID   path      int  true  "Item ID"
+// @Success      200  {object}  ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/me/collections/{id}/items/{itemID} [DELETE]
+// @Security     BearerAuth
+func (s *Server) removeCollectionItemHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	collectionId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "collection ID not a number",
+		})
+	}
+	itemIdStr := c.Param("itemID")
+	itemId, err := strconv.ParseUint(itemIdStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "item ID not a number",
+		})
+	}
+	err = s.domain.RemoveCollectionItem(c.Request().Context(), user, uint(collectionId), uint(itemId))
+	if err != nil {
+		return collectionErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ResponseStatus{
+		Status: SuccessStatus,
+	})
+}
+
+// @Summary      Reorder Collection
+// @Description  Move the given items to the top of the collection in the given order. The rest of the items keep their order after them.
+// @Tags         collections
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Collection ID"
+// @Param        order  body  RequestCollectionOrder  true  "item IDs in the new order"
+// @Success      200  {object}  ResponseStatus
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/me/collections/{id}/order [PUT]
+// @Security     BearerAuth
+func (s *Server) reorderCollectionHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	collectionId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "collection ID not a number",
+		})
+	}
+	in := RequestCollectionOrder{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	err = s.domain.ReorderCollection(c.Request().Context(), user, uint(collectionId), in.ItemIDs)
+	if err != nil {
+		return collectionErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ResponseStatus{
+		Status: SuccessStatus,
+	})
+}
+
+func collectionErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthorized):
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	case errors.Is(err, domain.ErrWrongCollectionInput), errors.Is(err, domain.ErrWrongQueryInput):
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	case errors.Is(err, domain.ErrCollectionNotFound), errors.Is(err, domain.ErrAssetNotFound):
+		return c.JSON(http.StatusNotFound, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ResponseStatus{
+		Status: FailureStatus,
+		Error:  err.Error(),
+	})
+}
@@ -0,0 +1,360 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/domain"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+func commentErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthorized):
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	case errors.Is(err, domain.ErrWrongCommentInput), errors.Is(err, domain.ErrWrongQueryInput):
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	case errors.Is(err, domain.ErrAssetNotFound), errors.Is(err, domain.ErrCommentNotFound):
+		return c.JSON(http.StatusNotFound, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ResponseStatus{
+		Status: FailureStatus,
+		Error:  err.Error(),
+	})
+}
+
+// @Summary      List Comments
+// @Description  Get a page of a thread of the comments of an asset from the oldest one, the comments without parent or the replies to a comment. The text of the hidden comments is only shown to the administrators of the asset.
+// @Tags         user
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        limit   query      int  true  "number of comments, up to 100"
+// @Param        lastID   query      int  false  "the last comment ID of the previous page"
+// @Param        parentID   query      int  false  "the comment of the replies"
+// @Success      200  {object}  domain.ListedComments
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/{assetType}/{id}/comments [GET]
+// @Security     BearerAuth
+func (s *Server) listCommentsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	query := domain.QueryComments{}
+	err = (&echo.DefaultBinder{}).BindQueryParams(c, &query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	ls, err := s.domain.ListComments(c.Request().Context(), user, uint(assetId), assetType, query)
+	if err != nil {
+		return commentErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ls)
+}
+
+// @Summary      Add Comment
+// @Description  Comment on an asset, or reply to one of its comments. The users are mentioned with @ and their username.
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        comment  body  domain.CommentInput  true  "the text and the comment it replies to"
+// @Success      201  {object}  domain.Comment
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/{assetType}/{id}/comments [POST]
+// @Security     BearerAuth
+func (s *Server) addCommentHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	in := domain.CommentInput{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	comment, err := s.domain.AddComment(c.Request().Context(), user, uint(assetId), assetType, in)
+	if err != nil {
+		return commentErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusCreated, comment)
+}
+
+// @Summary      Edit Comment
+// @Description  Replace the text of a comment of the user, and the users that it mentions
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        commentID   path      int  true  "Comment ID"
+// @Param        comment  body  domain.CommentInput  true  "the new text"
+// @Success      200  {object}  domain.Comment
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/{assetType}/{id}/comments/{commentID} [PUT]
+// @Security     BearerAuth
+func (s *Server) editCommentHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	commentId, err := strconv.ParseUint(c.Param("commentID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "comment ID not a number",
+		})
+	}
+	in := domain.CommentInput{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	edited, err := s.domain.EditComment(c.Request().Context(), user, uint(assetId), assetType, uint(commentId), in)
+	if err != nil {
+		return commentErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, edited)
+}
+
+// @Summary      Delete Comment
+// @Description  Remove the text of a comment, which stays in its thread with its replies. The author or an administrator of the asset deletes a comment.
+// @Tags         user
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        commentID   path      int  true  "Comment ID"
+// @Success      200  {object}  ResponseStatus
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/{assetType}/{id}/comments/{commentID} [DELETE]
+// @Security     BearerAuth
+func (s *Server) deleteCommentHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	commentId, err := strconv.ParseUint(c.Param("commentID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "comment ID not a number",
+		})
+	}
+	err = s.domain.DeleteComment(c.Request().Context(), user, uint(assetId), assetType, uint(commentId))
+	if err != nil {
+		return commentErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ResponseStatus{
+		Status: SuccessStatus,
+	})
+}
+
+// @Summary      Moderate Comment
+// @Description  Hide a comment on an asset of the organization from the users, or show it again
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        commentID   path      int  true  "Comment ID"
+// @Param        moderation  body  domain.CommentModeration  true  "hide or show the comment"
+// @Success      200  {object}  domain.Comment
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/{assetType}/{id}/comments/{commentID}/moderation [PUT]
+// @Security     BearerAuth
+func (s *Server) moderateCommentHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	commentId, err := strconv.ParseUint(c.Param("commentID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "comment ID not a number",
+		})
+	}
+	in := domain.CommentModeration{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	moderated, err := s.domain.ModerateComment(c.Request().Context(), user, uint(assetId), assetType, uint(commentId), in)
+	if err != nil {
+		return commentErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, moderated)
+}
+
+// @Summary      Mentions
+// @Description  Get a page of the comments that mention the user from the oldest one, on the assets that the user sees. A page can have less comments than its limit.
+// @Tags         user
+// @Produce      json
+// @Param        limit   query      int  true  "number of comments, up to 100"
+// @Param        lastID   query      int  false  "the last comment ID of the previous page"
+// @Success      200  {object}  domain.ListedComments
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/me/mentions [GET]
+// @Security     BearerAuth
+func (s *Server) listMentionsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	query := domain.QueryComments{}
+	err = (&echo.DefaultBinder{}).BindQueryParams(c, &query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	ls, err := s.domain.ListMentions(c.Request().Context(), user, query)
+	if err != nil {
+		return commentErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ls)
+}
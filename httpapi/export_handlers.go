@@ -0,0 +1,154 @@
+package httpapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"platform-go-challenge/assetexport"
+	"platform-go-challenge/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// exportAssets streams the assets of the query as a file of the format of the query, csv by default.
+// The response starts with the first page of assets, so the errors that happen later only end it early.
+func (s *Server) exportAssets(c echo.Context, user *domain.User, query domain.QueryAssets, favQuery *domain.QueryFavouriteAssets, name string) error {
+	format := assetexport.Format(c.QueryParam("format"))
+	if format == "" {
+		format = assetexport.CSVFormat
+	}
+	res := c.Response()
+	var w assetexport.Writer
+	start := func() error {
+		var err error
+		w, err = assetexport.NewWriter(res, format, query.Type)
+		if err != nil {
+			return err
+		}
+		res.Header().Set(echo.HeaderContentType, format.ContentType())
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s-%s.%s"`, name, query.Type, format))
+		res.WriteHeader(http.StatusOK)
+		return nil
+	}
+	// the format is checked before any asset is read
+	err := assetexport.Validate(format, query.Type)
+	if err == nil {
+		err = s.domain.ExportAssets(c.Request().Context(), user, query, favQuery, func(assets []domain.Asset) error {
+			if w == nil {
+				err := start()
+				if err != nil {
+					return err
+				}
+			}
+			for i := range assets {
+				localizeAssets(c, &assets[i])
+			}
+			err := w.Write(assets)
+			if err != nil {
+				return err
+			}
+			res.Flush()
+			return nil
+		})
+	}
+	if err != nil && !res.Committed {
+		switch {
+		case errors.Is(err, domain.ErrUnauthorized):
+			return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+				"status": "Unauthorized",
+				"error":  err.Error(),
+			})
+		case errors.Is(err, domain.ErrWrongQueryInput), errors.Is(err, assetexport.ErrWrongFormat):
+			return c.JSON(http.StatusBadRequest, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if w == nil {
+		err = start()
+		if err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// @Summary      Export assets
+// @Description  Download every asset of the listing of the query as CSV, JSON Lines or XLSX. The query has the options of the listing of assets, the export starts after lastID and the limit, when set, is the maximum number of exported assets. The CSV and XLSX files have the fields of the assets followed by the JSON fields of their data, in which lists of texts are separated with "|".
+// @Tags         user
+// @Accept       json
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        format  query  string  false  "csv, jsonl or xlsx, csv by default"
+// @Param        query  body  QueryAssets  true  "query options"
+// @Param        Accept-Language  header  string  false  "preferred languages of the texts"
+// @Success      200  {file}  file
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/assets/export [POST]
+// @Security     BearerAuth
+func (s *Server) exportAssetsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	query := QueryAssets{}
+	err = c.Bind(&query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return s.exportAssets(c, user, query.QueryAssets, query.Who, "assets")
+}
+
+// @Summary      Export favourite assets
+// @Description  Download the favourite assets of the user of a type as CSV, JSON Lines or XLSX, with their notes. The query has the options of the listing of favourites, the export starts after lastID and the limit, when set, is the maximum number of exported assets.
+// @Tags         user
+// @Accept       json
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        format  query  string  false  "csv, jsonl or xlsx, csv by default"
+// @Param        query  body  domain.QueryAssets  true  "query options"
+// @Param        Accept-Language  header  string  false  "preferred languages of the texts"
+// @Success      200  {file}  file
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/me/favourites/export [POST]
+// @Security     BearerAuth
+func (s *Server) exportMyFavouritesHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	query := domain.QueryAssets{}
+	err = c.Bind(&query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	favQuery := domain.QueryFavouriteAssets{
+		FromUserID: user.ID,
+		OnlyFav:    true,
+	}
+	return s.exportAssets(c, user, query, &favQuery, "favourites")
+}
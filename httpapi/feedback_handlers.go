@@ -0,0 +1,281 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/domain"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+func feedbackErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthorized):
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	case errors.Is(err, domain.ErrWrongFeedbackInput), errors.Is(err, domain.ErrWrongQueryInput):
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	case errors.Is(err, domain.ErrAssetNotFound):
+		return c.JSON(http.StatusNotFound, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ResponseStatus{
+		Status: FailureStatus,
+		Error:  err.Error(),
+	})
+}
+
+// @Summary      Rate Asset
+// @Description  Rate the usefulness of an asset from 1 to 5, replacing the previous rating of the user. The ratings and the open flags of every asset are in its "feedback".
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        rating  body  domain.RatingInput  true  "the rating"
+// @Success      200  {object}  domain.AssetFeedback
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/{assetType}/{id}/rating [PUT]
+// @Security     BearerAuth
+func (s *Server) rateAssetHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	in := domain.RatingInput{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	feedback, err := s.domain.RateAsset(c.Request().Context(), user, uint(assetId), assetType, in)
+	if err != nil {
+		return feedbackErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, feedback)
+}
+
+// @Summary      Unrate Asset
+// @Description  Remove the rating of the user on an asset
+// @Tags         user
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Success      200  {object}  domain.AssetFeedback
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/{assetType}/{id}/rating [DELETE]
+// @Security     BearerAuth
+func (s *Server) unrateAssetHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	feedback, err := s.domain.UnrateAsset(c.Request().Context(), user, uint(assetId), assetType)
+	if err != nil {
+		return feedbackErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, feedback)
+}
+
+// @Summary      Flag Asset
+// @Description  Flag an asset as outdated or incorrect for the administrators. Flagging again with the same reason returns the open flag of the user.
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        flag  body  domain.FlagInput  true  "the reason and an optional comment"
+// @Success      201  {object}  domain.AssetFlag
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/{assetType}/{id}/flags [POST]
+// @Security     BearerAuth
+func (s *Server) flagAssetHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	in := domain.FlagInput{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	flag, err := s.domain.FlagAsset(c.Request().Context(), user, uint(assetId), assetType, in)
+	if err != nil {
+		return feedbackErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusCreated, flag)
+}
+
+// @Summary      Flagged assets
+// @Description  The queue of the assets of the organization with open flags, the assets with the most open flags first and then the assets flagged first
+// @Tags         admin
+// @Produce      json
+// @Param        type   query      string  false  "charts, insights or audiences, any type by default"
+// @Param        limit   query      int  false  "number of assets, up to 100, 20 by default"
+// @Success      200  {array}   domain.FlaggedAsset
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/admin/flags [GET]
+// @Security     BearerAuth
+func (s *Server) flaggedAssetsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	query := domain.QueryFlaggedAssets{}
+	err = (&echo.DefaultBinder{}).BindQueryParams(c, &query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	flagged, err := s.domain.FlaggedAssets(c.Request().Context(), user, query)
+	if err != nil {
+		return feedbackErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, flagged)
+}
+
+// @Summary      Resolve flags
+// @Description  Resolve the open flags of an asset of the organization with a reason, or all of them without reason
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        resolution  body  domain.FlagResolution  false  "the reason of the flags"
+// @Success      200  {object}  ResolvedFlags
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/{assetType}/{id}/flags/resolve [POST]
+// @Security     BearerAuth
+func (s *Server) resolveFlagsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	in := domain.FlagResolution{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	n, err := s.domain.ResolveFlags(c.Request().Context(), user, uint(assetId), assetType, in)
+	if err != nil {
+		return feedbackErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ResolvedFlags{
+		Status:   SuccessStatus,
+		Resolved: n,
+	})
+}
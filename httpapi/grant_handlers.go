@@ -0,0 +1,313 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/domain"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary      Create Group
+// @Description  Create a group of users in the active organization to share private assets with
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        group  body  domain.Group  true  "group"
+// @Success      200  {object}  domain.Group
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/admin/groups [POST]
+// @Security     BearerAuth
+func (s *Server) createGroupHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	if !user.IsAdmin {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+		})
+	}
+	in := domain.Group{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	group, err := s.domain.CreateGroup(c.Request().Context(), user, in)
+	if err != nil {
+		if errors.Is(err, domain.ErrWrongGroupInput) {
+			return c.JSON(http.StatusBadRequest, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, group)
+}
+
+// @Summary      Add Group Member
+// @Description  Add a user as a member of a group
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Group ID"
+// @Param        member  body  RequestGroupMember  true  "member"
+// @Success      200  {object}  ResponseStatus
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/groups/{id}/members [POST]
+// @Security     BearerAuth
+func (s *Server) addGroupMemberHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	if !user.IsAdmin {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+		})
+	}
+	idStr := c.Param("id")
+	groupId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "group ID not a number",
+		})
+	}
+	in := RequestGroupMember{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	err = s.domain.AddGroupMember(c.Request().Context(), user, uint(groupId), in.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrGroupNotFound) || errors.Is(err, domain.ErrUserNotFound) {
+			return c.JSON(http.StatusNotFound, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, ResponseStatus{
+		Status: SuccessStatus,
+	})
+}
+
+// @Summary      Grant Asset
+// @Description  Give access to a private asset to a user or a group
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        grant  body  domain.AssetGrant  true  "set either the userID or the groupID"
+// @Success      200  {object}  domain.AssetGrant
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/{assetType}/{id}/grants [POST]
+// @Security     BearerAuth
+func (s *Server) grantAssetHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	if !user.IsAdmin {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	in := domain.AssetGrant{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	grant, err := s.domain.GrantAsset(c.Request().Context(), user, uint(assetId), assetType, in)
+	if err != nil {
+		return grantErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, grant)
+}
+
+// @Summary      Revoke Asset Grant
+// @Description  Remove the access of a user or a group from a private asset
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        grantID   path      int  true  "Grant ID"
+// @Success      200  {object}  ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/{assetType}/{id}/grants/{grantID} [DELETE]
+// @Security     BearerAuth
+func (s *Server) revokeAssetGrantHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	if !user.IsAdmin {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	grantIdStr := c.Param("grantID")
+	grantId, err := strconv.ParseUint(grantIdStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "grant ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	err = s.domain.RevokeAssetGrant(c.Request().Context(), user, uint(assetId), assetType, uint(grantId))
+	if err != nil {
+		return grantErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ResponseStatus{
+		Status: SuccessStatus,
+	})
+}
+
+// @Summary      List Asset Grants
+// @Description  Get the users and groups that have access to a private asset
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Success      200  {array}   domain.AssetGrant
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/{assetType}/{id}/grants [GET]
+// @Security     BearerAuth
+func (s *Server) listAssetGrantsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	if !user.IsAdmin {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	grants, err := s.domain.ListAssetGrants(c.Request().Context(), user, uint(assetId), assetType)
+	if err != nil {
+		return grantErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, grants)
+}
+
+func grantErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthorized):
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	case errors.Is(err, domain.ErrWrongGrantInput):
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	case errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrGroupNotFound), errors.Is(err, domain.ErrAssetNotFound):
+		return c.JSON(http.StatusNotFound, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ResponseStatus{
+		Status: FailureStatus,
+		Error:  err.Error(),
+	})
+}
@@ -0,0 +1,96 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/domain"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxImportSize is the largest file of assets that can be imported at once.
+const maxImportSize = 32 << 20
+
+// importFormat returns the format of the query, or the format of the content type when there is none.
+func importFormat(c echo.Context) domain.ImportFormat {
+	if f := c.QueryParam("format"); f != "" {
+		return domain.ImportFormat(f)
+	}
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), "text/csv") {
+		return domain.CSVImportFormat
+	}
+	return domain.JSONLImportFormat
+}
+
+// @Summary      Import Assets
+// @Description  Add the assets of a CSV or JSON Lines file as drafts. The header of a CSV file has the JSON fields of the asset type, text fields are taken as they are, lists of texts are separated with "|" and the other fields are JSON values. Every line of a JSON Lines file is the JSON of an asset. The report has the outcome of every row. With dryRun the rows are only validated. With a chunkSize the rows are imported in chunks and a chunk is imported only when all of its rows are valid, otherwise all the rows are imported or none of them.
+// @Tags         admin
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        format  query  string  false  "csv or jsonl, by default csv for text/csv content and jsonl otherwise"
+// @Param        dryRun  query  bool  false  "only validate the rows"
+// @Param        chunkSize  query  int  false  "rows per transaction, up to 1000, all the rows when 0"
+// @Param        visibility  query  string  false  "public, organization or private"
+// @Param        locale  query  string  false  "ISO 639-1 language of the texts, en by default"
+// @Success      200  {object}  domain.ImportReport
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/admin/{assetType}/import [POST]
+// @Security     BearerAuth
+func (s *Server) importAssetsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	default:
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset type is not correct",
+		})
+	}
+	opts := domain.ImportOptions{}
+	err = (&echo.DefaultBinder{}).BindQueryParams(c, &opts)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	opts.Format = importFormat(c)
+	body := http.MaxBytesReader(c.Response(), c.Request().Body, maxImportSize)
+	report, err := s.domain.ImportAssets(c.Request().Context(), user, assetType, body, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnauthorized):
+			return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+				"status": "Unauthorized",
+				"error":  err.Error(),
+			})
+		case errors.Is(err, domain.ErrWrongImportInput):
+			return c.JSON(http.StatusBadRequest, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,85 @@
+package httpapi
+
+import (
+	"platform-go-challenge/domain"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// requestLanguages returns the ISO 639-1 languages of the Accept-Language header, the preferred first.
+// The wildcard and the languages with quality 0 are skipped.
+func requestLanguages(c echo.Context) []string {
+	type weighted struct {
+		lang string
+		q    float64
+	}
+	ls := []weighted{}
+	for _, part := range strings.Split(c.Request().Header.Get("Accept-Language"), ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		lang := strings.SplitN(tag, "-", 2)[0]
+		if lang == "" || lang == "*" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				v, err := strconv.ParseFloat(f[2:], 64)
+				if err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			ls = append(ls, weighted{lang: lang, q: q})
+		}
+	}
+	sort.SliceStable(ls, func(i, j int) bool {
+		return ls[i].q > ls[j].q
+	})
+	languages := []string{}
+	seen := map[string]bool{}
+	for _, v := range ls {
+		if !seen[v.lang] {
+			seen[v.lang] = true
+			languages = append(languages, v.lang)
+		}
+	}
+	return languages
+}
+
+// languagesMiddleware keeps the languages of the request in its context, so that the domain returns
+// the assets with their texts translated to them.
+func languagesMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		languages := requestLanguages(c)
+		if len(languages) > 0 {
+			ctx := domain.WithLanguages(c.Request().Context(), languages)
+			c.SetRequest(c.Request().WithContext(ctx))
+		}
+		return next(c)
+	}
+}
+
+// localizeAssets sets the localized names of the countries of the audiences in the language of the request.
+func localizeAssets(c echo.Context, assets ...*domain.Asset) {
+	languages := requestLanguages(c)
+	for _, a := range assets {
+		if a == nil {
+			continue
+		}
+		if au, ok := a.Data.(*domain.Audience); ok {
+			au.LocalizeCountries(languages)
+		}
+	}
+}
+
+func localizeListedAssets(c echo.Context, ls *domain.ListedAssets) {
+	for i := range ls.Assets {
+		localizeAssets(c, &ls.Assets[i])
+	}
+}
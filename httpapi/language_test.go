@@ -0,0 +1,21 @@
+package httpapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestLanguages(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "fr-CH, en;q=0.8, de;q=0.9, fr;q=0.7, *;q=0.5, it;q=0")
+	c := e.NewContext(req, httptest.NewRecorder())
+	assert.Equal(t, []string{"fr", "de", "en"}, requestLanguages(c))
+
+	req.Header.Del("Accept-Language")
+	assert.Equal(t, []string{}, requestLanguages(c))
+}
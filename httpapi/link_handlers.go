@@ -0,0 +1,145 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/domain"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary      Link Assets
+// @Description  Link an insight to the chart that supports it (supportedBy) or to the audience it is about (about)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        assetType   path      string  true  "insights"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        link  body  domain.AssetLink  true  "set the relation, the toType and the toID"
+// @Success      200  {object}  domain.AssetLink
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/{assetType}/{id}/links [POST]
+// @Security     BearerAuth
+func (s *Server) linkAssetsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	in := domain.AssetLink{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	link, err := s.domain.LinkAssets(c.Request().Context(), user, uint(assetId), assetType, in)
+	if err != nil {
+		return linkErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, link)
+}
+
+// @Summary      Unlink Assets
+// @Description  Remove a link that starts from the asset
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        assetType   path      string  true  "insights"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        linkID   path      int  true  "Link ID"
+// @Success      200  {object}  ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/{assetType}/{id}/links/{linkID} [DELETE]
+// @Security     BearerAuth
+func (s *Server) unlinkAssetsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	linkIdStr := c.Param("linkID")
+	linkId, err := strconv.ParseUint(linkIdStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "link ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	err = s.domain.UnlinkAssets(c.Request().Context(), user, uint(assetId), assetType, uint(linkId))
+	if err != nil {
+		return linkErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ResponseStatus{
+		Status: SuccessStatus,
+	})
+}
+
+func linkErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthorized):
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	case errors.Is(err, domain.ErrWrongLinkInput):
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	case errors.Is(err, domain.ErrAssetNotFound):
+		return c.JSON(http.StatusNotFound, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ResponseStatus{
+		Status: FailureStatus,
+		Error:  err.Error(),
+	})
+}
@@ -0,0 +1,146 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/domain"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary      Create Organization
+// @Description  Create a new organization with its own isolated asset catalog
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        organization  body  domain.Organization  true  "organization"
+// @Success      200  {object}  domain.Organization
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/admin/organizations [POST]
+// @Security     BearerAuth
+func (s *Server) createOrganizationHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	if !user.IsAdmin {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+		})
+	}
+	in := domain.Organization{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	org, err := s.domain.CreateOrganization(c.Request().Context(), user, in)
+	if err != nil {
+		if errors.Is(err, domain.ErrWrongOrganizationInput) {
+			return c.JSON(http.StatusBadRequest, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, org)
+}
+
+// @Summary      Add Organization Member
+// @Description  Add a user as a member of an organization, optionally with administrator rights in it. Only the administrators of the organization are authorized
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Organization ID"
+// @Param        member  body  domain.OrganizationMember  true  "member"
+// @Success      200  {object}  ResponseStatus
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/organizations/{id}/members [POST]
+// @Security     BearerAuth
+func (s *Server) addOrganizationMemberHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	orgId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "organization ID not a number",
+		})
+	}
+	in := domain.OrganizationMember{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	err = s.domain.AddOrganizationMember(c.Request().Context(), user, uint(orgId), in)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthorized) {
+			return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+				"status": "Unauthorized",
+				"error":  err.Error(),
+			})
+		}
+		if errors.Is(err, domain.ErrOrganizationNotFound) || errors.Is(err, domain.ErrUserNotFound) {
+			return c.JSON(http.StatusNotFound, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, ResponseStatus{
+		Status: SuccessStatus,
+	})
+}
+
+// @Summary      List my organizations
+// @Description  Get the organizations the user is a member of. Login with the orgID to switch the active organization.
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   domain.Organization
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/me/organizations [GET]
+// @Security     BearerAuth
+func (s *Server) listMyOrganizationsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	orgs, err := s.domain.ListUserOrganizations(c.Request().Context(), user)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, orgs)
+}
@@ -0,0 +1,55 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary      Recommended assets
+// @Description  Get the assets of any type that the users who favour the same assets as the user also favour, and the audiences that are similar to the favourite audiences of the user. The recommendations are refreshed periodically.
+// @Tags         user
+// @Produce      json
+// @Param        limit   query      int  false  "number of assets, up to 50, 10 by default"
+// @Param        Accept-Language  header  string  false  "preferred languages of the texts"
+// @Success      200  {array}   domain.RecommendedAsset
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/me/recommendations [GET]
+// @Security     BearerAuth
+func (s *Server) recommendationsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	query := domain.QueryRecommendations{}
+	err = (&echo.DefaultBinder{}).BindQueryParams(c, &query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	recommended, err := s.domain.Recommendations(c.Request().Context(), user, query)
+	if err != nil {
+		if errors.Is(err, domain.ErrWrongQueryInput) {
+			return c.JSON(http.StatusBadRequest, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	for _, v := range recommended {
+		localizeAssets(c, v.Asset)
+	}
+	return c.JSON(http.StatusOK, recommended)
+}
@@ -7,5 +7,6 @@ func fromUserDomainToUserJson(user domain.User) UserJson {
 		ID:       user.ID,
 		Username: user.Username,
 		IsAdmin:  user.IsAdmin,
+		OrgID:    user.OrgID,
 	}
 }
@@ -2,6 +2,7 @@ package httpapi
 
 import (
 	"fmt"
+	"platform-go-challenge/chartrender"
 	"platform-go-challenge/domain"
 
 	_ "platform-go-challenge/docs"
@@ -15,10 +16,14 @@ type Server struct {
 	domain domain.IDomain
 	port   int
 	secret string
+	charts *chartrender.Cache
 }
 
+// renderedChartsCacheSize is the number of chart images that are kept in memory.
+const renderedChartsCacheSize = 500
+
 func NewServer(domain domain.IDomain, port int, secret string) *Server {
-	return &Server{domain: domain, port: port, secret: secret}
+	return &Server{domain: domain, port: port, secret: secret, charts: chartrender.NewCache(renderedChartsCacheSize)}
 }
 
 func (s *Server) Run() {
@@ -41,18 +46,77 @@ func (s *Server) Run() {
 	r := e.Group("/api/v1")
 
 	r.Use(middleware.JWTWithConfig(config))
+	r.Use(languagesMiddleware)
 	r.POST("/admin/:assetType", s.addAssetHandler)
+	r.POST("/admin/:assetType/import", s.importAssetsHandler)
 	r.PUT("/admin/:assetType/:id", s.updateAssetHandler)
 	r.DELETE("/admin/:assetType/:id", s.deleteAssetHandler)
+	r.POST("/admin/organizations", s.createOrganizationHandler)
+	r.POST("/admin/organizations/:id/members", s.addOrganizationMemberHandler)
+	r.POST("/admin/groups", s.createGroupHandler)
+	r.POST("/admin/groups/:id/members", s.addGroupMemberHandler)
+	r.GET("/admin/:assetType/:id/grants", s.listAssetGrantsHandler)
+	r.POST("/admin/:assetType/:id/grants", s.grantAssetHandler)
+	r.DELETE("/admin/:assetType/:id/grants/:grantID", s.revokeAssetGrantHandler)
+	r.POST("/admin/tags", s.createTagHandler)
+	r.PUT("/admin/tags/:id", s.renameTagHandler)
+	r.DELETE("/admin/tags/:id", s.deleteTagHandler)
+	r.POST("/admin/tags/:id/merge", s.mergeTagsHandler)
+	r.PUT("/admin/:assetType/:id/tags", s.setAssetTagsHandler)
+	r.POST("/admin/:assetType/:id/links", s.linkAssetsHandler)
+	r.DELETE("/admin/:assetType/:id/links/:linkID", s.unlinkAssetsHandler)
+	r.PUT("/admin/:assetType/:id/status", s.transitionAssetHandler)
+	r.GET("/admin/:assetType/:id/translations", s.listAssetTranslationsHandler)
+	r.PUT("/admin/:assetType/:id/translations/:locale", s.setAssetTranslationHandler)
+	r.DELETE("/admin/:assetType/:id/translations/:locale", s.deleteAssetTranslationHandler)
+	r.GET("/admin/:assetType/:id/views", s.assetViewsHandler)
+	r.PUT("/admin/:assetType/:id/comments/:commentID/moderation", s.moderateCommentHandler)
+	r.GET("/admin/flags", s.flaggedAssetsHandler)
+	r.POST("/admin/:assetType/:id/flags/resolve", s.resolveFlagsHandler)
+	r.GET("/admin/webhooks", s.listWebhooksHandler)
+	r.POST("/admin/webhooks", s.addWebhookHandler)
+	r.PUT("/admin/webhooks/:id", s.updateWebhookHandler)
+	r.DELETE("/admin/webhooks/:id", s.deleteWebhookHandler)
+	r.POST("/admin/webhooks/:id/ping", s.pingWebhookHandler)
+	r.GET("/admin/webhooks/:id/deliveries", s.listWebhookDeliveriesHandler)
+	r.POST("/admin/webhooks/:id/deliveries/:deliveryID/redeliver", s.redeliverWebhookHandler)
 
 	r.GET("/me", s.meHandler)
+	r.GET("/me/organizations", s.listMyOrganizationsHandler)
+	r.GET("/me/collections", s.listCollectionsHandler)
+	r.POST("/me/collections", s.createCollectionHandler)
+	r.PUT("/me/collections/:id", s.renameCollectionHandler)
+	r.DELETE("/me/collections/:id", s.deleteCollectionHandler)
+	r.GET("/me/collections/:id/items", s.listCollectionItemsHandler)
+	r.POST("/me/collections/:id/items", s.addCollectionItemHandler)
+	r.DELETE("/me/collections/:id/items/:itemID", s.removeCollectionItemHandler)
+	r.PUT("/me/collections/:id/order", s.reorderCollectionHandler)
 	r.POST("/me/favourites", s.listMyFavourites)
+	r.POST("/me/favourites/export", s.exportMyFavouritesHandler)
+	r.POST("/me/favourites/batch", s.favourAssetsHandler)
+	r.GET("/me/recommendations", s.recommendationsHandler)
+	r.GET("/me/recent", s.recentAssetsHandler)
+	r.GET("/me/mentions", s.listMentionsHandler)
 
 	r.POST("/assets", s.listAssetsHandler)
+	r.POST("/assets/export", s.exportAssetsHandler)
+	r.GET("/tags", s.listTagsHandler)
+	r.GET("/audiences/compare", s.compareAudiencesHandler)
+	r.GET("/:assetType/trending", s.trendingAssetsHandler)
 
 	r.GET("/:assetType/:id", s.getAssetHandler)
+	r.GET("/charts/:id/render", s.renderChartHandler)
+	r.GET("/charts/:id/analytics", s.chartAnalyticsHandler)
 	r.PUT("/:assetType/:id/favourite", s.favourAnAssetHandler)
 	r.DELETE("/:assetType/:id/favourite", s.favourAnAssetHandler)
+	r.PUT("/:assetType/:id/favourite/note", s.setFavouriteNoteHandler)
+	r.GET("/:assetType/:id/comments", s.listCommentsHandler)
+	r.POST("/:assetType/:id/comments", s.addCommentHandler)
+	r.PUT("/:assetType/:id/comments/:commentID", s.editCommentHandler)
+	r.DELETE("/:assetType/:id/comments/:commentID", s.deleteCommentHandler)
+	r.PUT("/:assetType/:id/rating", s.rateAssetHandler)
+	r.DELETE("/:assetType/:id/rating", s.unrateAssetHandler)
+	r.POST("/:assetType/:id/flags", s.flagAssetHandler)
 
 	e.Logger.Fatal(e.Start(fmt.Sprint(":", s.port)))
 }
@@ -0,0 +1,86 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/domain"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary      Change Asset Status
+// @Description  Move an asset through its lifecycle: draft to inReview or archived, inReview to draft or published, published to archived and archived to draft. Publishing approves the asset and has to be done by another administrator than its author, and an approved asset with a publishAt time in the future is scheduled, which moves to draft or archived. Only published assets are seen by the users.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        transition  body  domain.StatusTransition  true  "the new status"
+// @Success      200  {object}  domain.Asset
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/{assetType}/{id}/status [PUT]
+// @Security     BearerAuth
+func (s *Server) transitionAssetHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	in := domain.StatusTransition{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	asset, err := s.domain.TransitionAsset(c.Request().Context(), user, uint(assetId), assetType, in)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnauthorized):
+			return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+				"status": "Unauthorized",
+				"error":  err.Error(),
+			})
+		case errors.Is(err, domain.ErrWrongStatusInput):
+			return c.JSON(http.StatusBadRequest, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		case errors.Is(err, domain.ErrAssetNotFound):
+			return c.JSON(http.StatusNotFound, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	localizeAssets(c, asset)
+	return c.JSON(http.StatusOK, asset)
+}
@@ -0,0 +1,294 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/domain"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary      Create Tag
+// @Description  Create a tag in the active organization to group assets of any type
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        tag  body  domain.Tag  true  "tag"
+// @Success      200  {object}  domain.Tag
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/admin/tags [POST]
+// @Security     BearerAuth
+func (s *Server) createTagHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	in := domain.Tag{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	tag, err := s.domain.CreateTag(c.Request().Context(), user, in)
+	if err != nil {
+		return tagErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, tag)
+}
+
+// @Summary      Rename Tag
+// @Description  Rename a tag of the active organization
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Tag ID"
+// @Param        tag  body  domain.Tag  true  "tag"
+// @Success      200  {object}  domain.Tag
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/tags/{id} [PUT]
+// @Security     BearerAuth
+func (s *Server) renameTagHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	tagId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "tag ID not a number",
+		})
+	}
+	in := domain.Tag{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	tag, err := s.domain.RenameTag(c.Request().Context(), user, uint(tagId), in)
+	if err != nil {
+		return tagErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, tag)
+}
+
+// @Summary      Merge Tags
+// @Description  Move the assets of a tag to another tag and delete the first one
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Tag ID"
+// @Param        merge  body  RequestTagMerge  true  "the tag to merge into"
+// @Success      200  {object}  ResponseStatus
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/tags/{id}/merge [POST]
+// @Security     BearerAuth
+func (s *Server) mergeTagsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	tagId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "tag ID not a number",
+		})
+	}
+	in := RequestTagMerge{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	err = s.domain.MergeTags(c.Request().Context(), user, uint(tagId), in.IntoTagID)
+	if err != nil {
+		return tagErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ResponseStatus{
+		Status: SuccessStatus,
+	})
+}
+
+// @Summary      Delete Tag
+// @Description  Delete a tag and remove it from its assets
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Tag ID"
+// @Success      200  {object}  ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/tags/{id} [DELETE]
+// @Security     BearerAuth
+func (s *Server) deleteTagHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	tagId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "tag ID not a number",
+		})
+	}
+	err = s.domain.DeleteTag(c.Request().Context(), user, uint(tagId))
+	if err != nil {
+		return tagErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ResponseStatus{
+		Status: SuccessStatus,
+	})
+}
+
+// @Summary      Set Asset Tags
+// @Description  Replace the tags of an asset
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        tags  body  RequestAssetTags  true  "tags"
+// @Success      200  {object}  ResponseStatus
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/{assetType}/{id}/tags [PUT]
+// @Security     BearerAuth
+func (s *Server) setAssetTagsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	in := RequestAssetTags{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	err = s.domain.SetAssetTags(c.Request().Context(), user, uint(assetId), assetType, in.TagIDs)
+	if err != nil {
+		return tagErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ResponseStatus{
+		Status: SuccessStatus,
+	})
+}
+
+// @Summary      List Tags
+// @Description  Get the tags of the active organization with the number of their assets that the user sees
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        assetType   query      string  false  "count only charts, insights or audiences"
+// @Success      200  {array}   domain.Tag
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/tags [GET]
+// @Security     BearerAuth
+func (s *Server) listTagsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	var assetType domain.AssetType
+	switch c.QueryParam("assetType") {
+	case "":
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	default:
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "wrong asset type",
+		})
+	}
+	tags, err := s.domain.ListTags(c.Request().Context(), user, assetType)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, tags)
+}
+
+func tagErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthorized):
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	case errors.Is(err, domain.ErrWrongTagInput):
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	case errors.Is(err, domain.ErrTagNotFound), errors.Is(err, domain.ErrAssetNotFound):
+		return c.JSON(http.StatusNotFound, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ResponseStatus{
+		Status: FailureStatus,
+		Error:  err.Error(),
+	})
+}
@@ -0,0 +1,58 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary      Trending assets
+// @Description  Rank the assets of a type by the favourites they gained in the last hours, a week by default. Every asset has its total favourites in "favouriteCount" and the favourites of the window in "gained".
+// @Tags         user
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        hours   query      int  false  "window of the ranking, up to 720"
+// @Param        limit   query      int  false  "number of assets, up to 100, 10 by default"
+// @Param        Accept-Language  header  string  false  "preferred languages of the texts"
+// @Success      200  {array}   domain.TrendingAsset
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/{assetType}/trending [GET]
+// @Security     BearerAuth
+func (s *Server) trendingAssetsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	query := domain.QueryTrending{}
+	err = (&echo.DefaultBinder{}).BindQueryParams(c, &query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	query.Type = domain.AssetType(c.Param("assetType"))
+	trending, err := s.domain.TrendingAssets(c.Request().Context(), user, query)
+	if err != nil {
+		if errors.Is(err, domain.ErrWrongQueryInput) {
+			return c.JSON(http.StatusBadRequest, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	for i := range trending {
+		localizeAssets(c, &trending[i].Asset)
+	}
+	return c.JSON(http.StatusOK, trending)
+}
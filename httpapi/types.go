@@ -23,6 +23,7 @@ type JwtUserClaims struct {
 	ID       uint   `json:"id"`
 	Username string `json:"username"`
 	Admin    bool   `json:"admin"`
+	OrgID    uint   `json:"orgID"`
 	jwt.StandardClaims
 }
 
@@ -36,6 +37,23 @@ type RequestUserLogin struct {
 	Username         string `json:"username"`
 	Password         string `json:"password"`
 	ExpiresInMinutes int    `json:"expiresInMinutes"`
+	OrgID            uint   `json:"orgID"`
+}
+
+type RequestGroupMember struct {
+	UserID uint `json:"userID"`
+}
+
+type RequestCollectionOrder struct {
+	ItemIDs []uint `json:"itemIDs"`
+}
+
+type RequestTagMerge struct {
+	IntoTagID uint `json:"intoTagID"`
+}
+
+type RequestAssetTags struct {
+	TagIDs []uint `json:"tagIDs"`
 }
 
 type ResponseStatus struct {
@@ -43,18 +61,33 @@ type ResponseStatus struct {
 	Error  string     `json:"error,omitempty"`
 }
 
+// FavouriteStatus tells if the asset is favoured after the call and if the call changed it.
+type FavouriteStatus struct {
+	Status      StatusType `json:"status"`
+	IsFavourite bool       `json:"isFavourite"`
+	Changed     bool       `json:"changed"`
+}
+
+// ResolvedFlags has the number of the flags that were resolved.
+type ResolvedFlags struct {
+	Status   StatusType `json:"status"`
+	Resolved int64      `json:"resolved"`
+}
+
 type ResponseLogin struct {
 	Status    StatusType `json:"status"`
 	Error     *error     `json:"error,omitempty"`
 	Token     *string    `json:"token,omitempty"`
 	Username  *string    `json:"username,omitempty"`
 	ExpiresAt *int64     `json:"expiresAt,omitempty"`
+	OrgID     *uint      `json:"orgID,omitempty"`
 }
 
 type UserJson struct {
 	ID       uint   `json:"id"`
 	Username string `json:"username"`
 	IsAdmin  bool   `json:"isAdmin"`
+	OrgID    uint   `json:"orgID"`
 }
 
 type QueryAssets struct {
@@ -65,18 +98,24 @@ type QueryAssets struct {
 // dummy structures used for documenting better the swagger
 
 type AssetInsightJson struct {
-	ID   uint `json:"id"`
-	Data domain.Insight
+	ID         uint              `json:"id"`
+	OrgID      uint              `json:"orgID"`
+	Visibility domain.Visibility `json:"visibility"`
+	Data       domain.Insight
 }
 
 type AssetChartJson struct {
-	ID   uint `json:"id"`
-	Data domain.Chart
+	ID         uint              `json:"id"`
+	OrgID      uint              `json:"orgID"`
+	Visibility domain.Visibility `json:"visibility"`
+	Data       domain.Chart
 }
 
 type AssetAudienceJson struct {
-	ID   uint `json:"id"`
-	Data domain.Audience
+	ID         uint              `json:"id"`
+	OrgID      uint              `json:"orgID"`
+	Visibility domain.Visibility `json:"visibility"`
+	Data       domain.Audience
 }
 
 type ListInsightsJson struct {
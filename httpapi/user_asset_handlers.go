@@ -42,6 +42,10 @@ func (s *Server) getAssetHandler(c echo.Context) error {
 			Error:  err.Error(),
 		})
 	}
+	localizeAssets(c, asset)
+	for _, v := range asset.Related {
+		localizeAssets(c, v.Asset)
+	}
 	return c.JSON(http.StatusOK, asset)
 }
 
@@ -51,6 +55,7 @@ func (s *Server) getAssetHandler(c echo.Context) error {
 // @Accept       json
 // @Produce      json
 // @Param        query  body  QueryAssets  true  "query options"
+// @Param        Accept-Language  header  string  false  "preferred languages of the texts"
 // @Success      200  {object}  ListInsightsJson
 // @Failure      400  {object}	ResponseStatus
 // @Failure      401  {object}	ResponseStatus
@@ -86,9 +91,22 @@ func (s *Server) listAssetsHandler(c echo.Context) error {
 			Error:  err.Error(),
 		})
 	}
+	localizeListedAssets(c, ls)
 	return c.JSON(http.StatusOK, ls)
 }
 
+// @Summary      Favour an asset
+// @Description  Favour the asset with PUT or unfavour it with DELETE. Both are idempotent and "changed" tells if the favourites of the user changed. The assets that the user cannot see are not found, except the favourite ones that are unfavoured.
+// @Tags         user
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Success      200  {object}  FavouriteStatus
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/{assetType}/{id}/favourite [PUT]
+// @Security     BearerAuth
 func (s *Server) favourAnAssetHandler(c echo.Context) error {
 	user, err := getUserDomain(c)
 	if err != nil {
@@ -120,24 +138,76 @@ func (s *Server) favourAnAssetHandler(c echo.Context) error {
 	if c.Request().Method == "DELETE" {
 		isFavourite = false
 	}
-	err = s.domain.FavouriteAsset(c.Request().Context(), user, uint(assetId), assetType, isFavourite)
+	changed, err := s.domain.FavouriteAsset(c.Request().Context(), user, uint(assetId), assetType, isFavourite)
 	if err != nil {
+		if errors.Is(err, domain.ErrAssetNotFound) {
+			return c.JSON(http.StatusNotFound, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
 		return c.JSON(http.StatusBadRequest, ResponseStatus{
 			Status: FailureStatus,
 			Error:  err.Error(),
 		})
 	}
-	return c.JSON(http.StatusOK, echo.Map{
-		"status": SuccessStatus,
+	return c.JSON(http.StatusOK, FavouriteStatus{
+		Status:      SuccessStatus,
+		IsFavourite: isFavourite,
+		Changed:     changed,
 	})
 }
 
+// @Summary      Favour many assets
+// @Description  Favour the assets of the batch, or unfavour them when favourite is false, in a single transaction. The result of every asset tells if it succeeded and if it changed, the assets that are not found fail without stopping the others.
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        batch  body  domain.FavouriteBatch  true  "up to 100 assets"
+// @Success      200  {array}   domain.FavouriteResult
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/me/favourites/batch [POST]
+// @Security     BearerAuth
+func (s *Server) favourAssetsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	batch := domain.FavouriteBatch{}
+	err = c.Bind(&batch)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	results, err := s.domain.FavouriteAssets(c.Request().Context(), user, batch)
+	if err != nil {
+		if errors.Is(err, domain.ErrWrongFavourInput) {
+			return c.JSON(http.StatusBadRequest, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, results)
+}
+
 // @Summary      List of favourite assets
-// @Description  Get list of favourite assets of the user based on the asset type, the number of assets in the page and the last ID to start counting
+// @Description  Get list of favourite assets of the user based on the asset type, the number of assets in the page and the last ID to start counting. The noteSearch option keeps only the favourites with notes that contain the text.
 // @Tags         user
 // @Accept       json
 // @Produce      json
 // @Param        query  body  domain.QueryAssets  true  "query options"
+// @Param        Accept-Language  header  string  false  "preferred languages of the texts"
 // @Success      200  {object}  ListChartsJson
 // @Failure      400  {object}	ResponseStatus
 // @Failure      401  {object}	ResponseStatus
@@ -170,5 +240,76 @@ func (s *Server) listMyFavourites(c echo.Context) error {
 			Error:  err.Error(),
 		})
 	}
+	localizeListedAssets(c, ls)
 	return c.JSON(http.StatusOK, ls)
 }
+
+// @Summary      Note on a favourite asset
+// @Description  Keep a private markdown note on an asset that is already in the favourites. An empty text removes the note.
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Param        note  body  domain.FavouriteNote  true  "only the text is needed"
+// @Success      200  {object}  domain.FavouriteNote
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/{assetType}/{id}/favourite/note [PUT]
+// @Security     BearerAuth
+func (s *Server) setFavouriteNoteHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	in := domain.FavouriteNote{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	note, err := s.domain.SetFavouriteNote(c.Request().Context(), user, uint(assetId), assetType, in)
+	if err != nil {
+		if errors.Is(err, domain.ErrFavouriteNotFound) {
+			return c.JSON(http.StatusNotFound, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		if errors.Is(err, domain.ErrWrongNoteInput) {
+			return c.JSON(http.StatusBadRequest, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, note)
+}
@@ -41,5 +41,6 @@ func getUserDomain(c echo.Context) (*domain.User, error) {
 		Username: claims.Username,
 		ID:       claims.ID,
 		IsAdmin:  claims.Admin,
+		OrgID:    claims.OrgID,
 	}, nil
 }
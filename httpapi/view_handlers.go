@@ -0,0 +1,116 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/domain"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary      Recently viewed assets
+// @Description  Get the assets of any type that the user viewed lately, from the last one. The views are kept for the retention period of the server.
+// @Tags         user
+// @Produce      json
+// @Param        limit   query      int  false  "number of assets, up to 100, 20 by default"
+// @Param        Accept-Language  header  string  false  "preferred languages of the texts"
+// @Success      200  {array}   domain.RecentAsset
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/me/recent [GET]
+// @Security     BearerAuth
+func (s *Server) recentAssetsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	query := domain.QueryRecentAssets{}
+	err = (&echo.DefaultBinder{}).BindQueryParams(c, &query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	recent, err := s.domain.RecentAssets(c.Request().Context(), user, query)
+	if err != nil {
+		if errors.Is(err, domain.ErrWrongQueryInput) {
+			return c.JSON(http.StatusBadRequest, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	for _, v := range recent {
+		localizeAssets(c, v.Asset)
+	}
+	return c.JSON(http.StatusOK, recent)
+}
+
+// @Summary      Asset views
+// @Description  Count all the views of an asset, and the views and the users that viewed it in the retention period
+// @Tags         admin
+// @Produce      json
+// @Param        assetType   path      string  true  "charts, insights or audiences"
+// @Param        id   path      int  true  "Asset ID"
+// @Success      200  {object}  domain.AssetViewStats
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/{assetType}/{id}/views [GET]
+// @Security     BearerAuth
+func (s *Server) assetViewsHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	idStr := c.Param("id")
+	assetId, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "asset ID not a number",
+		})
+	}
+	at := c.Param("assetType")
+	var assetType domain.AssetType
+	switch at {
+	case AssetTypeInsights:
+		assetType = domain.InsightAssetType
+	case AssetTypeCharts:
+		assetType = domain.ChartAssetType
+	case AssetTypeAudiences:
+		assetType = domain.AudienceAssetType
+	}
+	stats, err := s.domain.AssetViewStats(c.Request().Context(), user, uint(assetId), assetType)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnauthorized):
+			return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+				"status": "Unauthorized",
+				"error":  err.Error(),
+			})
+		case errors.Is(err, domain.ErrAssetNotFound):
+			return c.JSON(http.StatusNotFound, ResponseStatus{
+				Status: FailureStatus,
+				Error:  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, stats)
+}
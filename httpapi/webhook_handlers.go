@@ -0,0 +1,286 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"platform-go-challenge/domain"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+func webhookErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthorized):
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	case errors.Is(err, domain.ErrWrongWebhookInput), errors.Is(err, domain.ErrWrongQueryInput):
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	case errors.Is(err, domain.ErrWebhookNotFound), errors.Is(err, domain.ErrDeliveryNotFound):
+		return c.JSON(http.StatusNotFound, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ResponseStatus{
+		Status: FailureStatus,
+		Error:  err.Error(),
+	})
+}
+
+// @Summary      List Webhooks
+// @Description  Get the webhooks of the organization, without their secrets
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   domain.Webhook
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/admin/webhooks [GET]
+// @Security     BearerAuth
+func (s *Server) listWebhooksHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	hooks, err := s.domain.ListWebhooks(c.Request().Context(), user)
+	if err != nil {
+		return webhookErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, hooks)
+}
+
+// @Summary      Add Webhook
+// @Description  Register a webhook for the events of the assets of the organization: asset.created, asset.updated, asset.deleted and asset.favourited. Empty events or asset types receive all of them. Every delivery is a POST of the JSON event, signed in the X-Webhook-Signature header with "sha256=" and the hex HMAC-SHA256 of the X-Webhook-Timestamp header, a dot and the body. A random secret is generated when none is given, and the secret is only returned here.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        webhook  body  domain.WebhookInput  true  "the URL, the filters and the secret"
+// @Success      201  {object}  domain.Webhook
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Router       /api/v1/admin/webhooks [POST]
+// @Security     BearerAuth
+func (s *Server) addWebhookHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	in := domain.WebhookInput{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	hook, err := s.domain.AddWebhook(c.Request().Context(), user, in)
+	if err != nil {
+		return webhookErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusCreated, hook)
+}
+
+// @Summary      Update Webhook
+// @Description  Replace the URL and the filters of a webhook. The secret and the activity are kept when they are not given.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"
+// @Param        webhook  body  domain.WebhookInput  true  "the URL, the filters, the secret and the activity"
+// @Success      200  {object}  domain.Webhook
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/webhooks/{id} [PUT]
+// @Security     BearerAuth
+func (s *Server) updateWebhookHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	webhookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "webhook ID not a number",
+		})
+	}
+	in := domain.WebhookInput{}
+	err = c.Bind(&in)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	hook, err := s.domain.UpdateWebhook(c.Request().Context(), user, uint(webhookId), in)
+	if err != nil {
+		return webhookErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, hook)
+}
+
+// @Summary      Delete Webhook
+// @Description  Remove a webhook with its deliveries
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      200  {object}  ResponseStatus
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/webhooks/{id} [DELETE]
+// @Security     BearerAuth
+func (s *Server) deleteWebhookHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	webhookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "webhook ID not a number",
+		})
+	}
+	err = s.domain.DeleteWebhook(c.Request().Context(), user, uint(webhookId))
+	if err != nil {
+		return webhookErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ResponseStatus{
+		Status: SuccessStatus,
+	})
+}
+
+// @Summary      Ping Webhook
+// @Description  Send a ping event to a webhook at once, also when it is not active, and get its delivery
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      200  {object}  domain.WebhookDelivery
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/webhooks/{id}/ping [POST]
+// @Security     BearerAuth
+func (s *Server) pingWebhookHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	webhookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "webhook ID not a number",
+		})
+	}
+	delivery, err := s.domain.PingWebhook(c.Request().Context(), user, uint(webhookId))
+	if err != nil {
+		return webhookErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, delivery)
+}
+
+// @Summary      Webhook Deliveries
+// @Description  Get a page of the delivery log of a webhook from the oldest delivery, with the status, the attempts and the outcome of the last attempt of every delivery
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"
+// @Param        limit   query      int  true  "number of deliveries, up to 100"
+// @Param        lastID   query      int  false  "the last delivery ID of the previous page"
+// @Success      200  {object}  domain.ListedDeliveries
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/webhooks/{id}/deliveries [GET]
+// @Security     BearerAuth
+func (s *Server) listWebhookDeliveriesHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	webhookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "webhook ID not a number",
+		})
+	}
+	query := domain.QueryDeliveries{}
+	err = (&echo.DefaultBinder{}).BindQueryParams(c, &query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  err.Error(),
+		})
+	}
+	ls, err := s.domain.ListWebhookDeliveries(c.Request().Context(), user, uint(webhookId), query)
+	if err != nil {
+		return webhookErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ls)
+}
+
+// @Summary      Redeliver Webhook
+// @Description  Send the payload of a delivery again at once as a new delivery, which is retried like the others when it fails
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"
+// @Param        deliveryID   path      int  true  "Delivery ID"
+// @Success      200  {object}  domain.WebhookDelivery
+// @Failure      400  {object}	ResponseStatus
+// @Failure      401  {object}	ResponseStatus
+// @Failure      404  {object}	ResponseStatus
+// @Router       /api/v1/admin/webhooks/{id}/deliveries/{deliveryID}/redeliver [POST]
+// @Security     BearerAuth
+func (s *Server) redeliverWebhookHandler(c echo.Context) error {
+	user, err := getUserDomain(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, echo.Map{
+			"status": "Unauthorized",
+			"error":  err.Error(),
+		})
+	}
+	webhookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "webhook ID not a number",
+		})
+	}
+	deliveryId, err := strconv.ParseUint(c.Param("deliveryID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseStatus{
+			Status: FailureStatus,
+			Error:  "delivery ID not a number",
+		})
+	}
+	delivery, err := s.domain.RedeliverWebhook(c.Request().Context(), user, uint(webhookId), uint(deliveryId))
+	if err != nil {
+		return webhookErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, delivery)
+}
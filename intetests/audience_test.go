@@ -71,6 +71,13 @@ func TestListAudiencesSuccess(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
+	reviewer, err := dom.CreateUser(ctx, domain.User{
+		Username: "reviewer",
+		Password: "password",
+		IsAdmin:  true,
+	})
+	assert.NoError(t, err)
+
 	user, err := dom.CreateUser(ctx, domain.User{
 		Username: "user",
 		Password: "password",
@@ -93,6 +100,7 @@ func TestListAudiencesSuccess(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, uint(i), asset.ID)
 		assert.Equal(t, desc, asset.Data.(*domain.Audience).Description)
+		publishAsset(t, dom, admin, reviewer, asset.ID, domain.AudienceAssetType)
 	}
 	qa := domain.QueryAssets{
 		Limit:  10,
@@ -134,6 +142,13 @@ func TestListFavouriteAudiencesSuccess(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
+	reviewer, err := dom.CreateUser(ctx, domain.User{
+		Username: "reviewer",
+		Password: "password",
+		IsAdmin:  true,
+	})
+	assert.NoError(t, err)
+
 	user, err := dom.CreateUser(ctx, domain.User{
 		Username: "user",
 		Password: "password",
@@ -156,8 +171,9 @@ func TestListFavouriteAudiencesSuccess(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, uint(i), asset.ID)
 		assert.Equal(t, desc, asset.Data.(*domain.Audience).Description)
+		publishAsset(t, dom, admin, reviewer, asset.ID, domain.AudienceAssetType)
 		if i%2 == 0 {
-			err := dom.FavouriteAsset(ctx, user, asset.ID, domain.AudienceAssetType, true)
+			_, err := dom.FavouriteAsset(ctx, user, asset.ID, domain.AudienceAssetType, true)
 			assert.NoError(t, err)
 		}
 	}
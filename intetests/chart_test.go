@@ -20,6 +20,13 @@ func TestListChartsSuccess(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
+	reviewer, err := dom.CreateUser(ctx, domain.User{
+		Username: "reviewer",
+		Password: "password",
+		IsAdmin:  true,
+	})
+	assert.NoError(t, err)
+
 	user, err := dom.CreateUser(ctx, domain.User{
 		Username: "user",
 		Password: "password",
@@ -43,6 +50,7 @@ func TestListChartsSuccess(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, uint(i), asset.ID)
 		assert.Equal(t, desc, asset.Data.(*domain.Chart).Description)
+		publishAsset(t, dom, admin, reviewer, asset.ID, domain.ChartAssetType)
 	}
 	qa := domain.QueryAssets{
 		Limit:  10,
@@ -139,6 +147,13 @@ func TestListFavouriteChartsSuccess(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
+	reviewer, err := dom.CreateUser(ctx, domain.User{
+		Username: "reviewer",
+		Password: "password",
+		IsAdmin:  true,
+	})
+	assert.NoError(t, err)
+
 	user, err := dom.CreateUser(ctx, domain.User{
 		Username: "user",
 		Password: "password",
@@ -162,8 +177,9 @@ func TestListFavouriteChartsSuccess(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, uint(i), asset.ID)
 		assert.Equal(t, desc, asset.Data.(*domain.Chart).Description)
+		publishAsset(t, dom, admin, reviewer, asset.ID, domain.ChartAssetType)
 		if i%2 == 0 {
-			err := dom.FavouriteAsset(ctx, user, asset.ID, domain.ChartAssetType, true)
+			_, err := dom.FavouriteAsset(ctx, user, asset.ID, domain.ChartAssetType, true)
 			assert.NoError(t, err)
 		}
 	}
@@ -64,6 +64,13 @@ func TestListInsightsSuccess(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
+	reviewer, err := dom.CreateUser(ctx, domain.User{
+		Username: "reviewer",
+		Password: "password",
+		IsAdmin:  true,
+	})
+	assert.NoError(t, err)
+
 	user, err := dom.CreateUser(ctx, domain.User{
 		Username: "user",
 		Password: "password",
@@ -81,6 +88,7 @@ func TestListInsightsSuccess(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, uint(i), asset.ID)
 		assert.Equal(t, desc, asset.Data.(*domain.Insight).Description)
+		publishAsset(t, dom, admin, reviewer, asset.ID, domain.InsightAssetType)
 	}
 	qa := domain.QueryAssets{
 		Limit:  10,
@@ -122,6 +130,13 @@ func TestListFavouriteInsightsSuccess(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
+	reviewer, err := dom.CreateUser(ctx, domain.User{
+		Username: "reviewer",
+		Password: "password",
+		IsAdmin:  true,
+	})
+	assert.NoError(t, err)
+
 	user, err := dom.CreateUser(ctx, domain.User{
 		Username: "user",
 		Password: "password",
@@ -139,8 +154,9 @@ func TestListFavouriteInsightsSuccess(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, uint(i), asset.ID)
 		assert.Equal(t, desc, asset.Data.(*domain.Insight).Description)
+		publishAsset(t, dom, admin, reviewer, asset.ID, domain.InsightAssetType)
 		if i%2 == 0 {
-			err := dom.FavouriteAsset(ctx, user, asset.ID, domain.InsightAssetType, true)
+			_, err := dom.FavouriteAsset(ctx, user, asset.ID, domain.InsightAssetType, true)
 			assert.NoError(t, err)
 		}
 	}
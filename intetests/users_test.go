@@ -49,6 +49,13 @@ func TestAuthenticationToListFavOfOtherUsersSuccess(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
+	reviewer, err := dom.CreateUser(ctx, domain.User{
+		Username: "reviewer",
+		Password: "password",
+		IsAdmin:  true,
+	})
+	assert.NoError(t, err)
+
 	user, err := dom.CreateUser(ctx, domain.User{
 		Username: "user",
 		Password: "password",
@@ -77,14 +84,15 @@ func TestAuthenticationToListFavOfOtherUsersSuccess(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, uint(1), asset.ID)
 	assert.Equal(t, "example", asset.Data.(*domain.Audience).Description)
+	publishAsset(t, dom, admin, reviewer, asset.ID, domain.AudienceAssetType)
 
-	err = dom.FavouriteAsset(ctx, admin, asset.ID, domain.AudienceAssetType, true)
+	_, err = dom.FavouriteAsset(ctx, admin, asset.ID, domain.AudienceAssetType, true)
 	assert.NoError(t, err)
 
-	err = dom.FavouriteAsset(ctx, user, asset.ID, domain.AudienceAssetType, true)
+	_, err = dom.FavouriteAsset(ctx, user, asset.ID, domain.AudienceAssetType, true)
 	assert.NoError(t, err)
 
-	err = dom.FavouriteAsset(ctx, user2, asset.ID, domain.AudienceAssetType, true)
+	_, err = dom.FavouriteAsset(ctx, user2, asset.ID, domain.AudienceAssetType, true)
 	assert.NoError(t, err)
 
 	qa := domain.QueryAssets{
@@ -1,6 +1,7 @@
 package intetests
 
 import (
+	"context"
 	"platform-go-challenge/domain"
 	"platform-go-challenge/sqldb"
 	"testing"
@@ -21,3 +22,16 @@ func setupSuite(tb testing.TB) (*domain.Domain, func(tb testing.TB)) {
 		sqldb.Close()
 	}
 }
+
+// publishAsset moves a new asset of the author through the review to the published status.
+func publishAsset(tb testing.TB, dom *domain.Domain, author, reviewer *domain.User, assetID uint, at domain.AssetType) {
+	ctx := context.Background()
+	_, err := dom.TransitionAsset(ctx, author, assetID, at, domain.StatusTransition{Status: domain.InReviewStatus})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	_, err = dom.TransitionAsset(ctx, reviewer, assetID, at, domain.StatusTransition{Status: domain.PublishedStatus})
+	if err != nil {
+		tb.Fatal(err)
+	}
+}
@@ -1,17 +1,46 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"platform-go-challenge/domain"
 	"platform-go-challenge/httpapi"
 	"platform-go-challenge/sqldb"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// schedulerInterval is how often the scheduled assets are published and the expired ones archived.
+const schedulerInterval = time.Minute
+
+// viewFlushInterval is how often the views of the assets are stored.
+const viewFlushInterval = 5 * time.Second
+
+// webhookInterval is how often the due deliveries of the webhooks are attempted.
+const webhookInterval = 5 * time.Second
+
+// recommenderInterval is how often the similarities of the assets are computed again from the favourites.
+const recommenderInterval = time.Hour
+
 func main() {
+	normalizeCountries := flag.Bool("normalize-countries", false, "rewrite the countries of the stored audiences as ISO codes and exit")
+	importFile := flag.String("import", "", "import the assets of a CSV or JSON Lines file and exit")
+	importType := flag.String("import-type", "", "type of the imported assets: charts, insights or audiences")
+	importUser := flag.String("import-user", "", "username of the administrator that imports the assets")
+	importOrg := flag.Uint("import-org", 0, "organization of the imported assets")
+	dryRun := flag.Bool("dry-run", false, "only validate the rows of the import")
+	chunkSize := flag.Int("chunk-size", 0, "rows of the import per transaction, all the rows when 0")
+	viewRetention := flag.Duration("view-retention", 90*24*time.Hour, "how long the views of the assets are kept")
+	flag.Parse()
 	godotenv.Load()
 
 	dbHost := os.Getenv("MYSQL_HOST")
@@ -27,11 +56,75 @@ func main() {
 		log.Fatal(err)
 	}
 	db.CreateTables()
+	if *normalizeCountries {
+		updated, unknown, err := db.NormalizeAudienceCountries(context.Background())
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("updated %d audiences, audiences with unknown countries: %v", updated, unknown)
+		return
+	}
 	dom := domain.NewDomain(db)
+	if *importFile != "" {
+		opts := domain.ImportOptions{DryRun: *dryRun, ChunkSize: *chunkSize}
+		report, err := importAssets(dom, db, *importFile, *importType, *importUser, *importOrg, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		out, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(out))
+		if report.Failed > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+	go dom.RunScheduler(context.Background(), schedulerInterval)
+	go dom.RunRecommender(context.Background(), recommenderInterval)
+	go dom.RunViewRecorder(context.Background(), viewFlushInterval, *viewRetention)
+	go dom.RunWebhookDispatcher(context.Background(), webhookInterval)
 	server := httpapi.NewServer(dom, port, secret)
 	server.Run()
 }
 
+// importAssets imports a file of assets as the administrator with the username, in the organization.
+// The format of the file is given by its extension.
+func importAssets(dom *domain.Domain, db *sqldb.DB, path, assetType, username string, orgID uint, opts domain.ImportOptions) (*domain.ImportReport, error) {
+	ctx := context.Background()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		opts.Format = domain.CSVImportFormat
+	case ".jsonl", ".ndjson":
+		opts.Format = domain.JSONLImportFormat
+	default:
+		return nil, errors.New("the file should be .csv, .jsonl or .ndjson")
+	}
+	user, err := db.FindUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("user %s: %v", username, err)
+	}
+	if orgID != 0 {
+		isMember, err := db.IsOrganizationMember(ctx, orgID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !isMember {
+			return nil, fmt.Errorf("user %s is not a member of the organization %d", username, orgID)
+		}
+		isAdmin, err := db.IsOrganizationAdmin(ctx, orgID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		user.OrgID = orgID
+		user.IsAdmin = isAdmin
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return dom.ImportAssets(ctx, user, domain.AssetType(assetType), f, opts)
+}
+
 // @title GWI Server API
 // @version 1.0
 // @description This is API for GWI Server.
@@ -5,81 +5,274 @@ import (
 	"errors"
 	"fmt"
 	"platform-go-challenge/domain"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-func (d *DB) AddAsset(ctx context.Context, asset domain.InputAsset) (*domain.Asset, error) {
-	newAsset := &domain.Asset{}
+// tenantScope keeps only the rows of the asset table that belong to the organization of the scope.
+func tenantScope(table string, scope domain.AssetScope) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(table+".organization_id = ?", scope.OrgID)
+	}
+}
+
+// likePattern escapes the wildcards of the text and wraps it for a "contains" LIKE query.
+func likePattern(text string) string {
+	r := strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+	return "%" + r.Replace(text) + "%"
+}
+
+// tagScope keeps only the rows of the asset table that have all the given tags.
+func tagScope(table string, tags []string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(tags) == 0 {
+			return db
+		}
+		names := map[string]bool{}
+		for _, v := range tags {
+			names[v] = true
+		}
+		tagged := "SELECT asset_tags.asset_id FROM asset_tags INNER JOIN tags ON tags.id = asset_tags.tag_id " +
+			"WHERE asset_tags.asset_type = ? AND tags.name IN ? GROUP BY asset_tags.asset_id HAVING COUNT(DISTINCT tags.name) = ?"
+		return db.Where(table+".id IN ("+tagged+")", table, tags, len(names))
+	}
+}
+
+// assetLocale is the locale of a new asset, the assets that are added without one are in the default locale.
+func assetLocale(locale string) string {
+	if locale == "" {
+		return domain.DefaultLocale
+	}
+	return locale
+}
+
+// assetStatus is the status of a new asset, the assets that are added without one are published.
+func assetStatus(status domain.AssetStatus) string {
+	if status == "" {
+		return string(domain.PublishedStatus)
+	}
+	return string(status)
+}
+
+// liveCondition keeps the published assets that have not expired.
+func liveCondition(table string, now time.Time) (string, []interface{}) {
+	return "(" + table + ".status = ? AND (" + table + ".expire_at IS NULL OR " + table + ".expire_at > ?))",
+		[]interface{}{domain.PublishedStatus, now}
+}
+
+// favouredCondition keeps the published assets and the expired ones, which remain in the favourites.
+func favouredCondition(table string, now time.Time) (string, []interface{}) {
+	return "(" + table + ".status = ? OR (" + table + ".status = ? AND " + table + ".expire_at <= ?))",
+		[]interface{}{domain.PublishedStatus, domain.ArchivedStatus, now}
+}
+
+// accessScope keeps only the rows of the asset table that the user of the scope is allowed to see.
+// Public assets are seen by everyone, organization assets by the members of the organization
+// and private assets by the administrators of the organization and the users with a grant.
+// Only the administrators of the organization see its assets that do not match the status condition.
+func accessScope(table string, scope domain.AssetScope, statusCondition string, statusArgs []interface{}) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if scope.IsAdmin {
+			args := append([]interface{}{domain.PublicVisibility}, statusArgs...)
+			args = append(args, scope.OrgID)
+			return db.Where("(("+table+".visibility = ? AND "+statusCondition+") OR "+table+".organization_id = ?)", args...)
+		}
+		db = db.Where(statusCondition, statusArgs...)
+		granted := "SELECT asset_grants.asset_id FROM asset_grants WHERE asset_grants.asset_type = ? AND asset_grants.deleted_at IS NULL AND " +
+			"(asset_grants.user_id = ? OR asset_grants.user_group_id IN " +
+			"(SELECT user_group_members.user_group_id FROM user_group_members WHERE user_group_members.user_id = ? AND user_group_members.deleted_at IS NULL))"
+		return db.Where("("+table+".visibility = ? OR ("+table+".organization_id = ? AND ("+table+".visibility = ? OR "+table+".id IN ("+granted+"))))",
+			domain.PublicVisibility, scope.OrgID, domain.OrganizationVisibility, table, scope.UserID, scope.UserID)
+	}
+}
+
+// visibleScope keeps the rows that the user of the scope sees in the listings, the published assets that have not expired.
+func visibleScope(table string, scope domain.AssetScope) func(*gorm.DB) *gorm.DB {
+	condition, args := liveCondition(table, time.Now())
+	return accessScope(table, scope, condition, args)
+}
+
+// favouriteScope keeps the rows that the user of the scope sees in the listings with favourites.
+// Only the listing of the favourites alone includes the expired assets.
+func favouriteScope(table string, scope domain.AssetScope, onlyFav bool) func(*gorm.DB) *gorm.DB {
+	if !onlyFav {
+		return visibleScope(table, scope)
+	}
+	condition, args := favouredCondition(table, time.Now())
+	return accessScope(table, scope, condition, args)
+}
+
+func (d *DB) AddAsset(ctx context.Context, scope domain.AssetScope, asset domain.InputAsset) (*domain.Asset, error) {
+	return addAsset(d.db, scope, asset)
+}
+
+// AddAssets adds all the assets in a transaction, or none of them.
+func (d *DB) AddAssets(ctx context.Context, scope domain.AssetScope, assets []domain.InputAsset) ([]*domain.Asset, error) {
+	newAssets := []*domain.Asset{}
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		for _, v := range assets {
+			a, err := addAsset(tx, scope, v)
+			if err != nil {
+				return err
+			}
+			newAssets = append(newAssets, a)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newAssets, nil
+}
+
+func addAsset(db *gorm.DB, scope domain.AssetScope, asset domain.InputAsset) (*domain.Asset, error) {
+	var newAsset *domain.Asset
 	switch v := asset.Data.(type) {
 	case *domain.Insight:
 		in := &Insight{}
 		in.FromDomain(v)
-		err := d.db.Create(in).Error
+		in.OrganizationID = scope.OrgID
+		in.Visibility = string(asset.Visibility)
+		in.Version = 1
+		in.Status = assetStatus(asset.Status)
+		in.AuthorID = scope.UserID
+		in.EditorID = scope.UserID
+		in.PublishAt = asset.PublishAt
+		in.ExpireAt = asset.ExpireAt
+		in.Locale = assetLocale(asset.Locale)
+		err := db.Create(in).Error
 		if err != nil {
 			return nil, err
 		}
-		newAsset.ID = in.ID
-		newAsset.Data = in.ToDomain()
+		newAsset = in.ToAsset(in.ToDomain())
 	case *domain.Chart:
 		ch := &Chart{}
 		ch.FromDomain(v)
-		err := d.db.Create(ch).Error
+		ch.OrganizationID = scope.OrgID
+		ch.Visibility = string(asset.Visibility)
+		ch.Version = 1
+		ch.Status = assetStatus(asset.Status)
+		ch.AuthorID = scope.UserID
+		ch.EditorID = scope.UserID
+		ch.PublishAt = asset.PublishAt
+		ch.ExpireAt = asset.ExpireAt
+		ch.Locale = assetLocale(asset.Locale)
+		err := db.Create(ch).Error
 		if err != nil {
 			return nil, err
 		}
-		newAsset.ID = ch.ID
-		newAsset.Data = ch.ToDomain()
+		newAsset = ch.ToAsset(ch.ToDomain())
 	case *domain.Audience:
 		au := &Audience{}
 		au.FromDomain(v)
-		err := d.db.Create(au).Error
+		au.OrganizationID = scope.OrgID
+		au.Visibility = string(asset.Visibility)
+		au.Version = 1
+		au.Status = assetStatus(asset.Status)
+		au.AuthorID = scope.UserID
+		au.EditorID = scope.UserID
+		au.PublishAt = asset.PublishAt
+		au.ExpireAt = asset.ExpireAt
+		au.Locale = assetLocale(asset.Locale)
+		err := db.Create(au).Error
 		if err != nil {
 			return nil, err
 		}
-		newAsset.ID = au.ID
-		newAsset.Data = au.ToDomain()
+		newAsset = au.ToAsset(au.ToDomain())
 	default:
 		return nil, fmt.Errorf("AddAsset: %w", ErrThisAssetTypeDoesNotExist)
 	}
 	return newAsset, nil
 }
 
-func (d *DB) UpdateAsset(ctx context.Context, assetID uint, asset domain.InputAsset) (*domain.Asset, error) {
+func (d *DB) UpdateAsset(ctx context.Context, scope domain.AssetScope, assetID uint, asset domain.InputAsset) (*domain.Asset, error) {
 	if assetID <= 0 {
 		return nil, errors.New("add id ")
 	}
-	newAsset := &domain.Asset{}
+	var newAsset *domain.Asset
 	switch v := asset.Data.(type) {
 	case *domain.Insight:
 		in := &Insight{}
-		d.db.First(in, assetID)
+		err := d.db.Scopes(tenantScope("insights", scope)).First(in, assetID).Error
+		if err != nil {
+			return nil, err
+		}
 		in.FromDomain(v)
-		err := d.db.Save(in).Error
+		if asset.Visibility != "" {
+			in.Visibility = string(asset.Visibility)
+		}
+		if asset.PublishAt != nil {
+			in.PublishAt = asset.PublishAt
+		}
+		if asset.ExpireAt != nil {
+			in.ExpireAt = asset.ExpireAt
+		}
+		if asset.Locale != "" {
+			in.Locale = asset.Locale
+		}
+		in.EditorID = scope.UserID
+		in.Version++
+		err = d.db.Omit(counterColumns...).Save(in).Error
 		if err != nil {
 			return nil, err
 		}
-		newAsset.ID = in.ID
-		newAsset.Data = in.ToDomain()
+		newAsset = in.ToAsset(in.ToDomain())
 	case *domain.Chart:
 		ch := &Chart{}
-		d.db.First(ch, assetID)
+		err := d.db.Scopes(tenantScope("charts", scope)).First(ch, assetID).Error
+		if err != nil {
+			return nil, err
+		}
 		ch.FromDomain(v)
-		err := d.db.Save(ch).Error
+		if asset.Visibility != "" {
+			ch.Visibility = string(asset.Visibility)
+		}
+		if asset.PublishAt != nil {
+			ch.PublishAt = asset.PublishAt
+		}
+		if asset.ExpireAt != nil {
+			ch.ExpireAt = asset.ExpireAt
+		}
+		if asset.Locale != "" {
+			ch.Locale = asset.Locale
+		}
+		ch.EditorID = scope.UserID
+		ch.Version++
+		err = d.db.Omit(counterColumns...).Save(ch).Error
 		if err != nil {
 			return nil, err
 		}
-		newAsset.ID = ch.ID
-		newAsset.Data = ch.ToDomain()
+		newAsset = ch.ToAsset(ch.ToDomain())
 
 	case *domain.Audience:
 		au := &Audience{}
-		d.db.First(au, assetID)
+		err := d.db.Scopes(tenantScope("audiences", scope)).First(au, assetID).Error
+		if err != nil {
+			return nil, err
+		}
 		au.FromDomain(v)
-		err := d.db.Save(au).Error
+		if asset.Visibility != "" {
+			au.Visibility = string(asset.Visibility)
+		}
+		if asset.PublishAt != nil {
+			au.PublishAt = asset.PublishAt
+		}
+		if asset.ExpireAt != nil {
+			au.ExpireAt = asset.ExpireAt
+		}
+		if asset.Locale != "" {
+			au.Locale = asset.Locale
+		}
+		au.EditorID = scope.UserID
+		au.Version++
+		err = d.db.Omit(counterColumns...).Save(au).Error
 		if err != nil {
 			return nil, err
 		}
-		newAsset.ID = au.ID
-		newAsset.Data = au.ToDomain()
+		newAsset = au.ToAsset(au.ToDomain())
 	default:
 		return nil, fmt.Errorf("UpdateAsset: %w", ErrThisAssetTypeDoesNotExist)
 	}
@@ -87,55 +280,65 @@ func (d *DB) UpdateAsset(ctx context.Context, assetID uint, asset domain.InputAs
 	return newAsset, nil
 }
 
-func (d *DB) GetAsset(ctx context.Context, at domain.AssetType, assetID uint) (*domain.Asset, error) {
-	newAsset := &domain.Asset{}
+func (d *DB) GetAsset(ctx context.Context, scope domain.AssetScope, at domain.AssetType, assetID uint) (*domain.Asset, error) {
+	var newAsset *domain.Asset
 	switch at {
 	case domain.InsightAssetType:
 		in := &Insight{}
-		err := d.db.First(in, assetID).Error
+		err := d.db.Scopes(visibleScope("insights", scope)).First(in, assetID).Error
 		if err != nil {
 			return nil, err
 		}
-		newAsset.ID = in.ID
-		newAsset.Data = in.ToDomain()
+		newAsset = in.ToAsset(in.ToDomain())
 	case domain.ChartAssetType:
 		ch := &Chart{}
-		err := d.db.First(ch, assetID).Error
+		err := d.db.Scopes(visibleScope("charts", scope)).First(ch, assetID).Error
 		if err != nil {
 			return nil, err
 		}
-		newAsset.ID = ch.ID
-		newAsset.Data = ch.ToDomain()
+		newAsset = ch.ToAsset(ch.ToDomain())
 	case domain.AudienceAssetType:
 		au := &Audience{}
-		err := d.db.First(au, assetID).Error
+		err := d.db.Scopes(visibleScope("audiences", scope)).First(au, assetID).Error
 		if err != nil {
 			return nil, err
 		}
-		newAsset.ID = au.ID
-		newAsset.Data = au.ToDomain()
+		newAsset = au.ToAsset(au.ToDomain())
 	default:
 		return nil, fmt.Errorf("GetAsset: %w", ErrThisAssetTypeDoesNotExist)
 	}
-	return newAsset, nil
+	assets := []domain.Asset{*newAsset}
+	err := d.attachTags(at, assets)
+	if err != nil {
+		return nil, err
+	}
+	return &assets[0], nil
+}
+
+// ListVisibleAssets returns the assets of the type with the IDs that the user of the scope sees, in the order of their IDs.
+func (d *DB) ListVisibleAssets(ctx context.Context, scope domain.AssetScope, at domain.AssetType, assetIDs []uint) ([]domain.Asset, error) {
+	if len(assetIDs) == 0 {
+		return []domain.Asset{}, nil
+	}
+	return d.findAssets(at, assetIDs, visibleScope(string(at), scope))
 }
 
-func (d *DB) DeleteAsset(ctx context.Context, at domain.AssetType, assetID uint) error {
+func (d *DB) DeleteAsset(ctx context.Context, scope domain.AssetScope, at domain.AssetType, assetID uint) error {
 	switch at {
 	case domain.InsightAssetType:
-		err := d.db.Unscoped().Delete(&Insight{}, assetID).Error
+		err := d.db.Scopes(tenantScope("insights", scope)).Unscoped().Delete(&Insight{}, assetID).Error
 		if err != nil {
 			return err
 		}
 
 	case domain.ChartAssetType:
-		err := d.db.Unscoped().Delete(&Chart{}, assetID).Error
+		err := d.db.Scopes(tenantScope("charts", scope)).Unscoped().Delete(&Chart{}, assetID).Error
 		if err != nil {
 			return err
 		}
 
 	case domain.AudienceAssetType:
-		err := d.db.Unscoped().Delete(&Audience{}, assetID).Error
+		err := d.db.Scopes(tenantScope("audiences", scope)).Unscoped().Delete(&Audience{}, assetID).Error
 		if err != nil {
 			return err
 		}
@@ -145,8 +348,8 @@ func (d *DB) DeleteAsset(ctx context.Context, at domain.AssetType, assetID uint)
 	return nil
 }
 
-func (d *DB) ListAssets(ctx context.Context, query domain.QueryAssets) (*domain.ListedAssets, error) {
-	gormQuery := d.db
+func (d *DB) ListAssets(ctx context.Context, scope domain.AssetScope, query domain.QueryAssets) (*domain.ListedAssets, error) {
+	gormQuery := d.db.Scopes(visibleScope(string(query.Type), scope), tagScope(string(query.Type), query.Tags))
 	if query.IsDesc {
 		gormQuery = gormQuery.Where("id < ?", query.LastID).Order("id desc")
 	} else {
@@ -176,6 +379,10 @@ func (d *DB) ListAssets(ctx context.Context, query domain.QueryAssets) (*domain.
 		}
 		assets = listRowsToAssets(aus)
 	}
+	err := d.attachTags(query.Type, assets)
+	if err != nil {
+		return nil, err
+	}
 	var firstID uint = 0
 	var lastID uint = 0
 	if len(assets) > 0 {
@@ -192,66 +399,121 @@ func (d *DB) ListAssets(ctx context.Context, query domain.QueryAssets) (*domain.
 	return &dl, nil
 }
 
-func (d *DB) FavouriteAsset(ctx context.Context, userID, assetID uint, at domain.AssetType, isFavourite bool) (uint, error) {
-	var nid uint = 0
-	var err error
+// favouriteModel returns a new favourite of the user for the asset and the column of the asset in its table.
+func favouriteModel(at domain.AssetType, userID, assetID uint) (interface{}, string, error) {
 	switch at {
 	case domain.InsightAssetType:
-		if isFavourite {
-			count := int64(0)
-			d.db.Model(FavouriteInsight{}).Where("user_id = ? AND insight_id = ? ", userID, assetID).Count(&count)
-			if count == 0 {
-				in := &FavouriteInsight{UserID: userID, InsightID: assetID}
-				err = d.db.Create(in).Error
-				nid = in.ID
-			} else {
-				err = errors.New("record exists")
-			}
-		} else {
-			in := &FavouriteInsight{}
-			err = d.db.Where("user_id = ? AND insight_id = ? ", userID, assetID).Unscoped().Delete(in).Error
-		}
+		return &FavouriteInsight{UserID: userID, InsightID: assetID}, "insight_id", nil
 	case domain.ChartAssetType:
-		if isFavourite {
-			count := int64(0)
-			d.db.Model(FavouriteChart{}).Where("user_id = ? AND chart_id = ? ", userID, assetID).Count(&count)
-			if count == 0 {
-				ch := &FavouriteChart{UserID: userID, ChartID: assetID}
-				err = d.db.Create(ch).Error
-				nid = ch.ID
-			} else {
-				err = errors.New("record exists")
-			}
-		} else {
-			in := &FavouriteChart{}
-			err = d.db.Where("user_id = ? AND chart_id = ? ", userID, assetID).Unscoped().Delete(in).Error
-		}
+		return &FavouriteChart{UserID: userID, ChartID: assetID}, "chart_id", nil
 	case domain.AudienceAssetType:
+		return &FavouriteAudience{UserID: userID, AudienceID: assetID}, "audience_id", nil
+	}
+	return nil, "", ErrThisAssetTypeDoesNotExist
+}
+
+// FavouriteAsset favours or unfavours the asset and reports if the favourites of the user changed, so
+// favouring an asset twice or unfavouring an asset that is not favoured does nothing. The unique index of
+// the favourites keeps a single favourite of the user for the asset even with concurrent calls, and the
+// favourite count of the asset changes in the same transaction as its favourites.
+func (d *DB) FavouriteAsset(ctx context.Context, scope domain.AssetScope, userID, assetID uint, at domain.AssetType, isFavourite bool) (bool, error) {
+	fav, column, err := favouriteModel(at, userID, assetID)
+	if err != nil {
+		return false, fmt.Errorf("FavouriteAsset: %w", err)
+	}
+	model, _ := assetModel(at)
+	changed := false
+	err = d.db.Transaction(func(tx *gorm.DB) error {
+		txDB := &DB{db: tx}
 		if isFavourite {
-			count := int64(0)
-			d.db.Model(FavouriteAudience{}).Where("user_id = ? AND audience_id = ? ", userID, assetID).Count(&count)
-			if count == 0 {
-				au := &FavouriteAudience{UserID: userID, AudienceID: assetID}
-				err = d.db.Create(au).Error
-				nid = au.ID
-			} else {
-				err = errors.New("record exists")
+			_, err := txDB.GetAsset(ctx, scope, at, assetID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: %v", domain.ErrAssetNotFound, err)
 			}
-		} else {
-			in := &FavouriteAudience{}
-			err = d.db.Where("user_id = ? AND audience_id = ? ", userID, assetID).Unscoped().Delete(in).Error
+			if err != nil {
+				return err
+			}
+			res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(fav)
+			if res.Error != nil {
+				return res.Error
+			}
+			changed = res.RowsAffected == 1
+			if !changed {
+				return nil
+			}
+			return tx.Model(model).Where("id = ?", assetID).UpdateColumn("favourite_count", gorm.Expr("favourite_count + 1")).Error
+		}
+		// expired assets are not visible but they can still be removed from the favourites
+		empty, _, _ := favouriteModel(at, 0, 0)
+		res := tx.Where("user_id = ? AND "+column+" = ?", userID, assetID).Unscoped().Delete(empty)
+		if res.Error != nil {
+			return res.Error
+		}
+		changed = res.RowsAffected > 0
+		if changed {
+			return tx.Model(model).Where("id = ?", assetID).UpdateColumn("favourite_count", gorm.Expr("GREATEST(favourite_count, 1) - 1")).Error
+		}
+		_, err := txDB.GetAsset(ctx, scope, at, assetID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: %v", domain.ErrAssetNotFound, err)
 		}
+		return err
+	})
+	if err != nil {
+		return false, err
 	}
+	return changed, nil
+}
+
+// FavouriteAssets favours or unfavours the assets in a transaction. The assets that are not found fail on
+// their own, while any other error rolls back the whole batch.
+func (d *DB) FavouriteAssets(ctx context.Context, scope domain.AssetScope, userID uint, items []domain.FavouriteItem, isFavourite bool) ([]domain.FavouriteResult, error) {
+	results := []domain.FavouriteResult{}
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		txDB := &DB{db: tx}
+		for _, v := range items {
+			result := domain.FavouriteResult{AssetType: v.AssetType, AssetID: v.AssetID, OK: true}
+			changed, err := txDB.FavouriteAsset(ctx, scope, userID, v.AssetID, v.AssetType, isFavourite)
+			switch {
+			case errors.Is(err, domain.ErrAssetNotFound):
+				result.OK = false
+				result.Error = domain.ErrAssetNotFound.Error()
+			case err != nil:
+				return err
+			}
+			result.Changed = changed
+			results = append(results, result)
+		}
+		return nil
+	})
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
+	return results, nil
+}
 
-	return nid, nil
+func (d *DB) SetFavouriteNote(ctx context.Context, userID, assetID uint, at domain.AssetType, note domain.FavouriteNote) (bool, error) {
+	updates := map[string]interface{}{"note": note.Text, "note_updated_at": note.UpdatedAt}
+	var gormQuery *gorm.DB
+	switch at {
+	case domain.InsightAssetType:
+		gormQuery = d.db.Model(&FavouriteInsight{}).Where("user_id = ? AND insight_id = ? ", userID, assetID).Updates(updates)
+	case domain.ChartAssetType:
+		gormQuery = d.db.Model(&FavouriteChart{}).Where("user_id = ? AND chart_id = ? ", userID, assetID).Updates(updates)
+	case domain.AudienceAssetType:
+		gormQuery = d.db.Model(&FavouriteAudience{}).Where("user_id = ? AND audience_id = ? ", userID, assetID).Updates(updates)
+	default:
+		return false, fmt.Errorf("SetFavouriteNote: %w", ErrThisAssetTypeDoesNotExist)
+	}
+	if gormQuery.Error != nil {
+		return false, gormQuery.Error
+	}
+	return gormQuery.RowsAffected > 0, nil
 }
 
-func (d *DB) listFavouriteAudiences(ctx context.Context, userID uint, onlyFav bool, query domain.QueryAssets) (*domain.ListedAssets, error) {
+func (d *DB) listFavouriteAudiences(ctx context.Context, scope domain.AssetScope, userID uint, onlyFav bool, query domain.QueryAssets) (*domain.ListedAssets, error) {
 	var aus []AudienceWithFavour
-	gormQuery := d.db.Model(Audience{}).Select("audiences.*, (favourite_audiences.user_id = ?) AS is_favourite", userID)
+	gormQuery := d.db.Model(Audience{}).Scopes(favouriteScope("audiences", scope, onlyFav), tagScope("audiences", query.Tags)).Select("audiences.*, (favourite_audiences.user_id = ?) AS is_favourite, favourite_audiences.note AS note, favourite_audiences.note_updated_at AS note_updated_at", userID)
 	if onlyFav {
 		if query.IsDesc {
 			gormQuery = gormQuery.Joins("INNER JOIN favourite_audiences ON favourite_audiences.audience_id = audiences.id AND audiences.id < ? AND favourite_audiences.user_id = ?", query.LastID, userID).Order("audiences.id desc")
@@ -260,12 +522,15 @@ func (d *DB) listFavouriteAudiences(ctx context.Context, userID uint, onlyFav bo
 		}
 	} else {
 		if query.IsDesc {
-			gormQuery = gormQuery.Joins("LEFT JOIN favourite_audiences ON favourite_audiences.audience_id = audiences.id AND audiences.id < ? AND favourite_audiences.user_id = ?", query.LastID, userID).Order("audiences.id desc")
+			gormQuery = gormQuery.Joins("LEFT JOIN favourite_audiences ON favourite_audiences.audience_id = audiences.id AND favourite_audiences.user_id = ?", userID).Where("audiences.id < ?", query.LastID).Order("audiences.id desc")
 		} else {
-			gormQuery = gormQuery.Joins("LEFT JOIN favourite_audiences ON favourite_audiences.audience_id = audiences.id AND audiences.id > ? AND favourite_audiences.user_id = ?", query.LastID, userID).Order("audiences.id asc")
+			gormQuery = gormQuery.Joins("LEFT JOIN favourite_audiences ON favourite_audiences.audience_id = audiences.id AND favourite_audiences.user_id = ?", userID).Where("audiences.id > ?", query.LastID).Order("audiences.id asc")
 		}
 	}
 
+	if query.NoteSearch != "" {
+		gormQuery = gormQuery.Where("favourite_audiences.note LIKE ?", likePattern(query.NoteSearch))
+	}
 	gormQuery.Limit(query.Limit).Unscoped().Find(&aus)
 	assets := listRowsToAssets(aus)
 	var firstID uint = 0
@@ -285,9 +550,9 @@ func (d *DB) listFavouriteAudiences(ctx context.Context, userID uint, onlyFav bo
 	return &la, nil
 }
 
-func (d *DB) listFavouriteInsights(ctx context.Context, userID uint, onlyFav bool, query domain.QueryAssets) (*domain.ListedAssets, error) {
+func (d *DB) listFavouriteInsights(ctx context.Context, scope domain.AssetScope, userID uint, onlyFav bool, query domain.QueryAssets) (*domain.ListedAssets, error) {
 	var ins []InsightWithFavour
-	gormQuery := d.db.Model(Insight{}).Select("insights.*, (favourite_insights.user_id = ?) AS is_favourite", userID)
+	gormQuery := d.db.Model(Insight{}).Scopes(favouriteScope("insights", scope, onlyFav), tagScope("insights", query.Tags)).Select("insights.*, (favourite_insights.user_id = ?) AS is_favourite, favourite_insights.note AS note, favourite_insights.note_updated_at AS note_updated_at", userID)
 	if onlyFav {
 		if query.IsDesc {
 			gormQuery = gormQuery.Joins("INNER JOIN favourite_insights ON favourite_insights.insight_id = insights.id AND insights.id < ? AND favourite_insights.user_id = ?", query.LastID, userID).Order("insights.id desc")
@@ -296,12 +561,15 @@ func (d *DB) listFavouriteInsights(ctx context.Context, userID uint, onlyFav boo
 		}
 	} else {
 		if query.IsDesc {
-			gormQuery = gormQuery.Joins("LEFT JOIN favourite_insights ON favourite_insights.insight_id = insights.id AND insights.id < ? AND favourite_insights.user_id = ?", query.LastID, userID).Order("insights.id desc")
+			gormQuery = gormQuery.Joins("LEFT JOIN favourite_insights ON favourite_insights.insight_id = insights.id AND favourite_insights.user_id = ?", userID).Where("insights.id < ?", query.LastID).Order("insights.id desc")
 		} else {
-			gormQuery = gormQuery.Joins("LEFT JOIN favourite_insights ON favourite_insights.insight_id = insights.id AND insights.id > ? AND favourite_insights.user_id = ?", query.LastID, userID).Order("insights.id asc")
+			gormQuery = gormQuery.Joins("LEFT JOIN favourite_insights ON favourite_insights.insight_id = insights.id AND favourite_insights.user_id = ?", userID).Where("insights.id > ?", query.LastID).Order("insights.id asc")
 		}
 	}
 
+	if query.NoteSearch != "" {
+		gormQuery = gormQuery.Where("favourite_insights.note LIKE ?", likePattern(query.NoteSearch))
+	}
 	gormQuery.Limit(query.Limit).Unscoped().Find(&ins)
 	assets := listRowsToAssets(ins)
 	var firstID uint = 0
@@ -321,9 +589,9 @@ func (d *DB) listFavouriteInsights(ctx context.Context, userID uint, onlyFav boo
 	return &la, nil
 }
 
-func (d *DB) listFavouriteCharts(ctx context.Context, userID uint, onlyFav bool, query domain.QueryAssets) (*domain.ListedAssets, error) {
+func (d *DB) listFavouriteCharts(ctx context.Context, scope domain.AssetScope, userID uint, onlyFav bool, query domain.QueryAssets) (*domain.ListedAssets, error) {
 	var chs []ChartWithFavour
-	gormQuery := d.db.Model(Chart{}).Select("charts.*, (favourite_charts.user_id = ?) AS is_favourite", userID)
+	gormQuery := d.db.Model(Chart{}).Scopes(favouriteScope("charts", scope, onlyFav), tagScope("charts", query.Tags)).Select("charts.*, (favourite_charts.user_id = ?) AS is_favourite, favourite_charts.note AS note, favourite_charts.note_updated_at AS note_updated_at", userID)
 	if onlyFav {
 		if query.IsDesc {
 			gormQuery = gormQuery.Joins("INNER JOIN favourite_charts ON favourite_charts.chart_id = charts.id AND charts.id < ? AND favourite_charts.user_id = ?", query.LastID, userID).Order("charts.id desc")
@@ -332,12 +600,15 @@ func (d *DB) listFavouriteCharts(ctx context.Context, userID uint, onlyFav bool,
 		}
 	} else {
 		if query.IsDesc {
-			gormQuery = gormQuery.Joins("LEFT JOIN favourite_charts ON favourite_charts.chart_id = charts.id AND charts.id < ? AND favourite_charts.user_id = ?", query.LastID, userID).Order("charts.id desc")
+			gormQuery = gormQuery.Joins("LEFT JOIN favourite_charts ON favourite_charts.chart_id = charts.id AND favourite_charts.user_id = ?", userID).Where("charts.id < ?", query.LastID).Order("charts.id desc")
 		} else {
-			gormQuery = gormQuery.Joins("LEFT JOIN favourite_charts ON favourite_charts.chart_id = charts.id AND charts.id > ? AND favourite_charts.user_id = ?", query.LastID, userID).Order("charts.id asc")
+			gormQuery = gormQuery.Joins("LEFT JOIN favourite_charts ON favourite_charts.chart_id = charts.id AND favourite_charts.user_id = ?", userID).Where("charts.id > ?", query.LastID).Order("charts.id asc")
 		}
 	}
 
+	if query.NoteSearch != "" {
+		gormQuery = gormQuery.Where("favourite_charts.note LIKE ?", likePattern(query.NoteSearch))
+	}
 	gormQuery.Limit(query.Limit).Unscoped().Find(&chs)
 	assets := listRowsToAssets(chs)
 	var firstID uint = 0
@@ -357,16 +628,27 @@ func (d *DB) listFavouriteCharts(ctx context.Context, userID uint, onlyFav bool,
 	return &la, nil
 }
 
-func (d *DB) ListFavouriteAssets(ctx context.Context, userID uint, onlyFav bool, query domain.QueryAssets) (*domain.ListedAssets, error) {
+func (d *DB) ListFavouriteAssets(ctx context.Context, scope domain.AssetScope, userID uint, onlyFav bool, query domain.QueryAssets) (*domain.ListedAssets, error) {
+	var la *domain.ListedAssets
+	var err error
 	switch query.Type {
 	case domain.InsightAssetType:
-		return d.listFavouriteInsights(ctx, userID, onlyFav, query)
+		la, err = d.listFavouriteInsights(ctx, scope, userID, onlyFav, query)
 	case domain.AudienceAssetType:
-		return d.listFavouriteAudiences(ctx, userID, onlyFav, query)
+		la, err = d.listFavouriteAudiences(ctx, scope, userID, onlyFav, query)
 	case domain.ChartAssetType:
-		return d.listFavouriteCharts(ctx, userID, onlyFav, query)
+		la, err = d.listFavouriteCharts(ctx, scope, userID, onlyFav, query)
+	default:
+		return nil, fmt.Errorf("ListFavouriteAssets: %w", ErrThisAssetTypeDoesNotExist)
+	}
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("ListFavouriteAssets: %w", ErrThisAssetTypeDoesNotExist)
+	err = d.attachTags(query.Type, la.Assets)
+	if err != nil {
+		return nil, err
+	}
+	return la, nil
 }
 
 func (d *DB) RemoveFavouriteAssetFromEveryone(ctx context.Context, assetID uint, at domain.AssetType) error {
@@ -389,5 +671,6 @@ func (d *DB) RemoveFavouriteAssetFromEveryone(ctx context.Context, assetID uint,
 	default:
 		return fmt.Errorf("RemoveFavouriteAssetFromEveryone: %w", ErrThisAssetTypeDoesNotExist)
 	}
-	return nil
+	model, _ := assetModel(at)
+	return d.db.Model(model).Where("id = ?", assetID).UpdateColumn("favourite_count", 0).Error
 }
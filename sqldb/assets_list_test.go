@@ -15,7 +15,7 @@ func TestListInsights(t *testing.T) {
 	ctx := context.Background()
 	for i := 1; i <= 100; i++ {
 		desc := fmt.Sprintf("example %d", i)
-		asset, err := db.AddAsset(ctx, domain.InputAsset{
+		asset, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
 			Data: &domain.Insight{
 				Text:        "40% of millenials spend more than 3hours on social media daily",
 				Description: desc,
@@ -31,7 +31,7 @@ func TestListInsights(t *testing.T) {
 		Type:   domain.InsightAssetType,
 		IsDesc: false,
 	}
-	la, err := db.ListAssets(ctx, qa)
+	la, err := db.ListAssets(ctx, domain.AssetScope{}, qa)
 	fmt.Println(la)
 
 	assert.NoError(t, err)
@@ -46,7 +46,7 @@ func TestListInsights(t *testing.T) {
 		Type:   domain.InsightAssetType,
 		IsDesc: true,
 	}
-	la, err = db.ListAssets(ctx, qa)
+	la, err = db.ListAssets(ctx, domain.AssetScope{}, qa)
 	fmt.Println(la)
 
 	assert.NoError(t, err)
@@ -62,7 +62,7 @@ func TestListCharts(t *testing.T) {
 	ctx := context.Background()
 	for i := 1; i <= 100; i++ {
 		desc := fmt.Sprintf("example %d", i)
-		asset, err := db.AddAsset(ctx, domain.InputAsset{
+		asset, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
 			Data: &domain.Chart{
 				Description: desc,
 				Title:       "Relationship between tax and GDP",
@@ -84,7 +84,7 @@ func TestListCharts(t *testing.T) {
 		Type:   domain.ChartAssetType,
 		IsDesc: false,
 	}
-	la, err := db.ListAssets(ctx, qa)
+	la, err := db.ListAssets(ctx, domain.AssetScope{}, qa)
 	fmt.Println(la)
 
 	assert.NoError(t, err)
@@ -99,7 +99,7 @@ func TestListCharts(t *testing.T) {
 		Type:   domain.ChartAssetType,
 		IsDesc: true,
 	}
-	la, err = db.ListAssets(ctx, qa)
+	la, err = db.ListAssets(ctx, domain.AssetScope{}, qa)
 	fmt.Println(la)
 
 	assert.NoError(t, err)
@@ -115,7 +115,7 @@ func TestListAudiences(t *testing.T) {
 	ctx := context.Background()
 	for i := 1; i <= 100; i++ {
 		desc := fmt.Sprintf("example %d", i)
-		asset, err := db.AddAsset(ctx, domain.InputAsset{
+		asset, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
 			Data: &domain.Audience{
 				AgeMax:            30,
 				AgeMin:            20,
@@ -136,7 +136,7 @@ func TestListAudiences(t *testing.T) {
 		Type:   domain.AudienceAssetType,
 		IsDesc: false,
 	}
-	la, err := db.ListAssets(ctx, qa)
+	la, err := db.ListAssets(ctx, domain.AssetScope{}, qa)
 	fmt.Println(la)
 
 	assert.NoError(t, err)
@@ -151,7 +151,7 @@ func TestListAudiences(t *testing.T) {
 		Type:   domain.AudienceAssetType,
 		IsDesc: true,
 	}
-	la, err = db.ListAssets(ctx, qa)
+	la, err = db.ListAssets(ctx, domain.AssetScope{}, qa)
 	fmt.Println(la)
 
 	assert.NoError(t, err)
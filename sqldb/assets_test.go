@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
 )
 
 func setupSuite(tb testing.TB) (*DB, func(tb testing.TB)) {
@@ -29,7 +30,7 @@ func TestCRUDInsight(t *testing.T) {
 	db, teardownSuite := setupSuite(t)
 	defer teardownSuite(t)
 	ctx := context.Background()
-	asset, err := db.AddAsset(ctx, domain.InputAsset{
+	asset, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
 		Data: &domain.Insight{
 			Text:        "40% of millenials spend more than 3hours on social media daily",
 			Description: "example",
@@ -39,7 +40,7 @@ func TestCRUDInsight(t *testing.T) {
 	assert.Equal(t, uint(1), asset.ID)
 	assert.Equal(t, "example", asset.Data.(*domain.Insight).Description)
 
-	asset, err = db.UpdateAsset(ctx, 1, domain.InputAsset{
+	asset, err = db.UpdateAsset(ctx, domain.AssetScope{}, 1, domain.InputAsset{
 		Data: &domain.Insight{
 			Text:        "100% of millenials spend more than 3hours on social media daily",
 			Description: "updated example",
@@ -49,14 +50,14 @@ func TestCRUDInsight(t *testing.T) {
 	assert.Equal(t, uint(1), asset.ID)
 	assert.Equal(t, "updated example", asset.Data.(*domain.Insight).Description)
 
-	gottenAsset, err := db.GetAsset(ctx, domain.InsightAssetType, asset.ID)
+	gottenAsset, err := db.GetAsset(ctx, domain.AssetScope{}, domain.InsightAssetType, asset.ID)
 	assert.NotNil(t, gottenAsset)
 	assert.NoError(t, err)
 	assert.EqualValues(t, asset, gottenAsset)
-	err = db.DeleteAsset(ctx, domain.InsightAssetType, asset.ID)
+	err = db.DeleteAsset(ctx, domain.AssetScope{}, domain.InsightAssetType, asset.ID)
 	assert.Nil(t, err)
 
-	_, err = db.GetAsset(ctx, domain.InsightAssetType, asset.ID)
+	_, err = db.GetAsset(ctx, domain.AssetScope{}, domain.InsightAssetType, asset.ID)
 	assert.NotNil(t, err)
 }
 
@@ -64,7 +65,7 @@ func TestCRUDChart(t *testing.T) {
 	db, teardownSuite := setupSuite(t)
 	defer teardownSuite(t)
 	ctx := context.Background()
-	asset, err := db.AddAsset(ctx, domain.InputAsset{
+	asset, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
 		Data: &domain.Chart{
 			Description: "bla bla",
 			Title:       "Relationship between tax and GDP",
@@ -80,7 +81,7 @@ func TestCRUDChart(t *testing.T) {
 	assert.Equal(t, uint(1), asset.ID)
 	assert.Equal(t, "bla bla", asset.Data.(*domain.Chart).Description)
 
-	asset, err = db.UpdateAsset(ctx, 1, domain.InputAsset{
+	asset, err = db.UpdateAsset(ctx, domain.AssetScope{}, 1, domain.InputAsset{
 		Data: &domain.Chart{
 			Description: "bla bla 2",
 			Title:       "Relationship between tax and GDP",
@@ -96,22 +97,97 @@ func TestCRUDChart(t *testing.T) {
 	assert.Equal(t, uint(1), asset.ID)
 	assert.Equal(t, "bla bla 2", asset.Data.(*domain.Chart).Description)
 
-	gottenAsset, err := db.GetAsset(ctx, domain.ChartAssetType, asset.ID)
+	gottenAsset, err := db.GetAsset(ctx, domain.AssetScope{}, domain.ChartAssetType, asset.ID)
 	assert.NotNil(t, gottenAsset)
 	assert.NoError(t, err)
 	assert.EqualValues(t, asset, gottenAsset)
-	err = db.DeleteAsset(ctx, domain.ChartAssetType, asset.ID)
+	err = db.DeleteAsset(ctx, domain.AssetScope{}, domain.ChartAssetType, asset.ID)
 	assert.Nil(t, err)
 
-	_, err = db.GetAsset(ctx, domain.ChartAssetType, asset.ID)
+	_, err = db.GetAsset(ctx, domain.AssetScope{}, domain.ChartAssetType, asset.ID)
 	assert.NotNil(t, err)
 }
 
+func TestChartSeries(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	asset, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+		Data: &domain.Chart{
+			Description: "bla bla",
+			Title:       "Sales per country",
+			XTitle:      "Country",
+			YTitle:      "Sales",
+			Kind:        domain.BarChartKind,
+			XAxis:       domain.CategoryAxisType,
+			Series: []domain.Series{
+				{Name: "2021", Categories: []string{"Sweden", "Greece"}, Y: []float64{10, 20}},
+				{Name: "2022", Categories: []string{"Sweden", "Greece"}, Y: []float64{15, 25}},
+			},
+		}})
+	assert.NoError(t, err)
+
+	gottenAsset, err := db.GetAsset(ctx, domain.AssetScope{}, domain.ChartAssetType, asset.ID)
+	assert.NoError(t, err)
+	chart := gottenAsset.Data.(*domain.Chart)
+	assert.Equal(t, domain.BarChartKind, chart.Kind)
+	assert.Equal(t, domain.CategoryAxisType, chart.XAxis)
+	assert.Equal(t, 2, len(chart.Series))
+	assert.Equal(t, []string{"Sweden", "Greece"}, chart.Series[1].Categories)
+}
+
+func TestChartLegacyData(t *testing.T) {
+	ch := Chart{
+		Title:  "Relationship between tax and GDP",
+		XTitle: "GDP",
+		YTitle: "Tax",
+		Data:   datatypes.JSON(`{"x":[1,2],"y":[3,4]}`),
+	}
+	chart := ch.ToDomain()
+	assert.Equal(t, domain.LineChartKind, chart.Kind)
+	assert.Equal(t, domain.NumericAxisType, chart.XAxis)
+	assert.Equal(t, []float64{3, 4}, chart.Data.Y)
+	assert.Equal(t, 1, len(chart.AllSeries()))
+	assert.Equal(t, "Tax", chart.AllSeries()[0].Name)
+}
+
+func TestAudienceLegacyRow(t *testing.T) {
+	au := Audience{
+		AgeMax:            29,
+		AgeMin:            20,
+		Gender:            "female",
+		Country:           "Sweden",
+		HoursSpent:        3,
+		NumberOfPurchases: 4,
+		Description:       "bla bla",
+	}
+	audience := au.ToDomain()
+	assert.Equal(t, []domain.GenderType{domain.FemaleGenderType}, audience.AllGenders())
+	assert.Equal(t, []string{"Sweden"}, audience.AllCountries())
+	assert.Equal(t, domain.IntRange{Min: 3, Max: 3}, audience.HoursSpentBounds())
+	assert.Nil(t, audience.Devices)
+
+	au.FromDomain(&domain.Audience{
+		AgeMax:                 29,
+		AgeMin:                 20,
+		Genders:                []domain.GenderType{domain.NonBinaryGenderType},
+		Countries:              []string{"SE"},
+		HoursSpentRange:        &domain.IntRange{Min: 1, Max: 4},
+		NumberOfPurchasesRange: &domain.IntRange{Min: 0, Max: 9},
+		Devices:                []domain.DeviceType{domain.MobileDeviceType},
+		Description:            "bla bla",
+	})
+	audience = au.ToDomain()
+	assert.Equal(t, []string{"SE"}, audience.Countries)
+	assert.Equal(t, domain.IntRange{Min: 0, Max: 9}, audience.NumberOfPurchasesBounds())
+	assert.Equal(t, []domain.DeviceType{domain.MobileDeviceType}, audience.Devices)
+}
+
 func TestCRUDAudience(t *testing.T) {
 	db, teardownSuite := setupSuite(t)
 	defer teardownSuite(t)
 	ctx := context.Background()
-	asset, err := db.AddAsset(ctx, domain.InputAsset{
+	asset, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
 		Data: &domain.Audience{
 			AgeMax:            30,
 			AgeMin:            20,
@@ -126,7 +202,7 @@ func TestCRUDAudience(t *testing.T) {
 	assert.Equal(t, uint(1), asset.ID)
 	assert.Equal(t, "bla bla", asset.Data.(*domain.Audience).Description)
 
-	asset, err = db.UpdateAsset(ctx, 1, domain.InputAsset{
+	asset, err = db.UpdateAsset(ctx, domain.AssetScope{}, 1, domain.InputAsset{
 		Data: &domain.Audience{
 			AgeMax:            30,
 			AgeMin:            20,
@@ -141,13 +217,36 @@ func TestCRUDAudience(t *testing.T) {
 	assert.Equal(t, uint(1), asset.ID)
 	assert.Equal(t, "bla bla 2", asset.Data.(*domain.Audience).Description)
 
-	gottenAsset, err := db.GetAsset(ctx, domain.AudienceAssetType, asset.ID)
+	gottenAsset, err := db.GetAsset(ctx, domain.AssetScope{}, domain.AudienceAssetType, asset.ID)
 	assert.NotNil(t, gottenAsset)
 	assert.NoError(t, err)
 	assert.EqualValues(t, asset, gottenAsset)
-	err = db.DeleteAsset(ctx, domain.AudienceAssetType, asset.ID)
+	err = db.DeleteAsset(ctx, domain.AssetScope{}, domain.AudienceAssetType, asset.ID)
 	assert.Nil(t, err)
 
-	_, err = db.GetAsset(ctx, domain.AudienceAssetType, asset.ID)
+	_, err = db.GetAsset(ctx, domain.AssetScope{}, domain.AudienceAssetType, asset.ID)
 	assert.NotNil(t, err)
 }
+
+func TestAddAssets(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	admin := domain.AssetScope{UserID: 1, IsAdmin: true}
+	assets, err := db.AddAssets(ctx, admin, []domain.InputAsset{
+		{Data: &domain.Insight{Text: "first", Description: "example"}},
+		{Data: &domain.Insight{Text: "second", Description: "example"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(assets))
+	assert.Equal(t, uint(2), assets[1].ID)
+
+	_, err = db.AddAssets(ctx, admin, []domain.InputAsset{
+		{Data: &domain.Insight{Text: "third", Description: "example"}},
+		{Data: "not an asset"},
+	})
+	assert.Error(t, err)
+	la, err := db.ListAssets(ctx, admin, domain.QueryAssets{Limit: 10, Type: domain.InsightAssetType})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(la.Assets))
+}
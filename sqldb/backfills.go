@@ -0,0 +1,92 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"platform-go-challenge/domain"
+)
+
+// backfillBatchSize is the number of rows that a backfill reads at once.
+const backfillBatchSize = 100
+
+// NormalizeAudienceCountries rewrites the countries of the stored audiences as ISO alpha-2 codes.
+// It returns the number of updated audiences and the IDs of the audiences with countries that are not
+// recognized, which are left as they are. Running it again does not update anything.
+func (d *DB) NormalizeAudienceCountries(ctx context.Context) (int, []uint, error) {
+	updated := 0
+	unknown := []uint{}
+	var lastID uint
+	for {
+		rows := []Audience{}
+		err := d.db.WithContext(ctx).Where("id > ?", lastID).Order("id").Limit(backfillBatchSize).Find(&rows).Error
+		if err != nil {
+			return updated, unknown, err
+		}
+		if len(rows) == 0 {
+			return updated, unknown, nil
+		}
+		for i := range rows {
+			au := &rows[i]
+			lastID = au.ID
+			audience := au.ToDomain()
+			changed := false
+			recognized := true
+			if audience.Country != "" {
+				code, err := domain.CountryCode(audience.Country)
+				if err != nil {
+					recognized = false
+				} else if code != audience.Country {
+					audience.Country = code
+					changed = true
+				}
+			}
+			for j, c := range audience.Countries {
+				code, err := domain.CountryCode(c)
+				if err != nil {
+					recognized = false
+				} else if code != c {
+					audience.Countries[j] = code
+					changed = true
+				}
+			}
+			if !recognized {
+				unknown = append(unknown, au.ID)
+			}
+			if !changed {
+				continue
+			}
+			au.FromDomain(audience)
+			au.Version++
+			err = d.db.WithContext(ctx).Omit(counterColumns...).Save(au).Error
+			if err != nil {
+				return updated, unknown, err
+			}
+			updated++
+		}
+	}
+}
+
+// uncountedAssetTypes returns the asset types with tables that do not have the favourite count yet.
+func (d *DB) uncountedAssetTypes() []domain.AssetType {
+	mgt := d.db.Migrator()
+	types := []domain.AssetType{}
+	for _, at := range []domain.AssetType{domain.InsightAssetType, domain.ChartAssetType, domain.AudienceAssetType} {
+		model, _ := assetModel(at)
+		if mgt.HasTable(model) && !mgt.HasColumn(model, "favourite_count") {
+			types = append(types, at)
+		}
+	}
+	return types
+}
+
+// countFavourites sets the favourite count of every asset of the type from its favourites.
+func (d *DB) countFavourites(at domain.AssetType) error {
+	_, column, err := favouriteModel(at, 0, 0)
+	if err != nil {
+		return err
+	}
+	table := string(at)
+	favourites := "favourite_" + table
+	return d.db.Exec(fmt.Sprintf("UPDATE %[1]s SET favourite_count = (SELECT COUNT(*) FROM %[2]s WHERE %[2]s.%[3]s = %[1]s.id)",
+		table, favourites, column)).Error
+}
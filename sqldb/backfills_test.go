@@ -0,0 +1,35 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeAudienceCountries(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	rows := []Audience{
+		{AgeMax: 30, AgeMin: 20, Gender: "female", Country: "sweden", HoursSpent: 3, NumberOfPurchases: 3, Description: "1"},
+		{AgeMax: 30, AgeMin: 20, Gender: "female", Country: "SE", HoursSpent: 3, NumberOfPurchases: 3, Description: "2"},
+		{AgeMax: 30, AgeMin: 20, Gender: "female", Country: "Mordor", HoursSpent: 3, NumberOfPurchases: 3, Description: "3"},
+	}
+	assert.NoError(t, db.GormDB().Create(&rows).Error)
+
+	updated, unknown, err := db.NormalizeAudienceCountries(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, []uint{rows[2].ID}, unknown)
+
+	asset, err := db.GetAsset(ctx, domain.AssetScope{IsAdmin: true}, domain.AudienceAssetType, rows[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "SE", asset.Data.(*domain.Audience).Country)
+	assert.Equal(t, uint(2), asset.Version)
+
+	updated, _, err = db.NormalizeAudienceCountries(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, updated)
+}
@@ -0,0 +1,165 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"platform-go-challenge/domain"
+
+	"gorm.io/gorm"
+)
+
+func (d *DB) AddCollection(ctx context.Context, userID uint, collection domain.Collection) (*domain.Collection, error) {
+	c := &Collection{UserID: userID}
+	c.FromDomain(&collection)
+	err := d.db.Create(c).Error
+	if err != nil {
+		return nil, err
+	}
+	return c.ToDomain(), nil
+}
+
+func (d *DB) GetCollection(ctx context.Context, userID, collectionID uint) (*domain.Collection, error) {
+	c := Collection{}
+	err := d.db.Where("user_id = ? ", userID).First(&c, collectionID).Error
+	if err != nil {
+		return nil, err
+	}
+	return c.ToDomain(), nil
+}
+
+func (d *DB) UpdateCollection(ctx context.Context, userID, collectionID uint, collection domain.Collection) (*domain.Collection, error) {
+	c := &Collection{}
+	err := d.db.Where("user_id = ? ", userID).First(c, collectionID).Error
+	if err != nil {
+		return nil, err
+	}
+	c.FromDomain(&collection)
+	err = d.db.Save(c).Error
+	if err != nil {
+		return nil, err
+	}
+	return c.ToDomain(), nil
+}
+
+func (d *DB) DeleteCollection(ctx context.Context, userID, collectionID uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Unscoped().Where("user_id = ? ", userID).Delete(&Collection{}, collectionID)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Unscoped().Where("collection_id = ? ", collectionID).Delete(&CollectionItem{}).Error
+	})
+}
+
+func (d *DB) ListCollections(ctx context.Context, userID uint) ([]domain.Collection, error) {
+	var cs []Collection
+	err := d.db.Where("user_id = ? ", userID).Order("id asc").Find(&cs).Error
+	if err != nil {
+		return nil, err
+	}
+	collections := []domain.Collection{}
+	for _, v := range cs {
+		collections = append(collections, *v.ToDomain())
+	}
+	return collections, nil
+}
+
+// AddCollectionItem appends the asset at the end of the collection.
+// When the asset is already in the collection, the existing item is returned.
+func (d *DB) AddCollectionItem(ctx context.Context, collectionID uint, item domain.CollectionItem) (*domain.CollectionItem, error) {
+	ci := &CollectionItem{}
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("collection_id = ? AND asset_type = ? AND asset_id = ? ", collectionID, string(item.AssetType), item.AssetID).First(ci).Error
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		var lastPosition int
+		err = tx.Model(&CollectionItem{}).Select("COALESCE(MAX(position), 0)").Where("collection_id = ? ", collectionID).Find(&lastPosition).Error
+		if err != nil {
+			return err
+		}
+		ci = &CollectionItem{
+			CollectionID: collectionID,
+			AssetType:    string(item.AssetType),
+			AssetID:      item.AssetID,
+			Position:     lastPosition + 1,
+		}
+		return tx.Create(ci).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ci.ToDomain(), nil
+}
+
+func (d *DB) RemoveCollectionItem(ctx context.Context, collectionID, itemID uint) error {
+	return d.db.Unscoped().Where("collection_id = ? ", collectionID).Delete(&CollectionItem{}, itemID).Error
+}
+
+func (d *DB) ReorderCollectionItems(ctx context.Context, collectionID uint, itemIDs []uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		var cis []CollectionItem
+		err := tx.Where("collection_id = ? ", collectionID).Order("position asc").Find(&cis).Error
+		if err != nil {
+			return err
+		}
+		byID := map[uint]CollectionItem{}
+		for _, v := range cis {
+			byID[v.ID] = v
+		}
+		ordered := []CollectionItem{}
+		moved := map[uint]bool{}
+		for _, id := range itemIDs {
+			if v, ok := byID[id]; ok {
+				ordered = append(ordered, v)
+				moved[id] = true
+			}
+		}
+		for _, v := range cis {
+			if !moved[v.ID] {
+				ordered = append(ordered, v)
+			}
+		}
+		for i, v := range ordered {
+			err := tx.Model(&CollectionItem{}).Where("id = ? ", v.ID).Update("position", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (d *DB) ListCollectionItems(ctx context.Context, collectionID uint, query domain.QueryCollectionItems) (*domain.ListedCollectionItems, error) {
+	var cis []CollectionItem
+	err := d.db.Where("collection_id = ? AND position > ? ", collectionID, query.LastPosition).Order("position asc").Limit(query.Limit).Find(&cis).Error
+	if err != nil {
+		return nil, err
+	}
+	items := []domain.CollectionItem{}
+	for _, v := range cis {
+		items = append(items, *v.ToDomain())
+	}
+	firstPosition := 0
+	lastPosition := 0
+	if len(items) > 0 {
+		firstPosition = items[0].Position
+		lastPosition = items[len(items)-1].Position
+	}
+	return &domain.ListedCollectionItems{
+		Limit:         query.Limit,
+		FirstPosition: firstPosition,
+		LastPosition:  lastPosition,
+		Items:         items,
+	}, nil
+}
+
+func (d *DB) RemoveAssetFromCollections(ctx context.Context, at domain.AssetType, assetID uint) error {
+	return d.db.Unscoped().Where("asset_type = ? AND asset_id = ? ", string(at), assetID).Delete(&CollectionItem{}).Error
+}
@@ -0,0 +1,60 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectionItems(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	collection, err := db.AddCollection(ctx, 1, domain.Collection{Name: "retail"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), collection.ID)
+
+	_, err = db.GetCollection(ctx, 2, collection.ID)
+	assert.Error(t, err)
+
+	collection, err = db.UpdateCollection(ctx, 1, collection.ID, domain.Collection{Name: "Q3 retail"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Q3 retail", collection.Name)
+
+	types := []domain.AssetType{domain.ChartAssetType, domain.InsightAssetType, domain.AudienceAssetType}
+	for i, at := range types {
+		item, err := db.AddCollectionItem(ctx, collection.ID, domain.CollectionItem{AssetType: at, AssetID: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, i+1, item.Position)
+	}
+	item, err := db.AddCollectionItem(ctx, collection.ID, domain.CollectionItem{AssetType: domain.ChartAssetType, AssetID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, item.Position)
+
+	err = db.ReorderCollectionItems(ctx, collection.ID, []uint{3})
+	assert.NoError(t, err)
+	ls, err := db.ListCollectionItems(ctx, collection.ID, domain.QueryCollectionItems{Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(ls.Items))
+	assert.Equal(t, domain.AudienceAssetType, ls.Items[0].AssetType)
+	assert.Equal(t, domain.ChartAssetType, ls.Items[1].AssetType)
+
+	ls, err = db.ListCollectionItems(ctx, collection.ID, domain.QueryCollectionItems{Limit: 2, LastPosition: ls.LastPosition})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(ls.Items))
+	assert.Equal(t, domain.InsightAssetType, ls.Items[0].AssetType)
+
+	err = db.RemoveAssetFromCollections(ctx, domain.InsightAssetType, 1)
+	assert.NoError(t, err)
+	ls, err = db.ListCollectionItems(ctx, collection.ID, domain.QueryCollectionItems{Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(ls.Items))
+
+	err = db.DeleteCollection(ctx, 1, collection.ID)
+	assert.NoError(t, err)
+	collections, err := db.ListCollections(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(collections))
+}
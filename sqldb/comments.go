@@ -0,0 +1,216 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// commentRow is a comment with the username of its author and the number of its replies.
+type commentRow struct {
+	Comment
+	Author  string
+	Replies int64
+}
+
+func (r commentRow) ToComment() domain.Comment {
+	return domain.Comment{
+		ID:        r.ID,
+		AssetType: domain.AssetType(r.AssetType),
+		AssetID:   r.AssetID,
+		ParentID:  r.ParentID,
+		AuthorID:  r.AuthorID,
+		Author:    r.Author,
+		Text:      r.Text,
+		Replies:   r.Replies,
+		CreatedAt: r.CreatedAt,
+		EditedAt:  r.EditedAt,
+		Deleted:   r.Removed,
+		Hidden:    r.Hidden,
+	}
+}
+
+// selectComments selects the comments with their authors and the number of their replies.
+func (d *DB) selectComments() *gorm.DB {
+	return d.db.Model(&Comment{}).
+		Select("comments.*, users.username AS author, " +
+			"(SELECT COUNT(*) FROM comments AS replies WHERE replies.parent_id = comments.id AND replies.deleted_at IS NULL) AS replies").
+		Joins("LEFT JOIN users ON users.id = comments.author_id")
+}
+
+// attachMentions adds the usernames of the mentioned users to the comments.
+func (d *DB) attachMentions(comments []domain.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	ids := []uint{}
+	for _, v := range comments {
+		ids = append(ids, v.ID)
+	}
+	rows := []struct {
+		CommentID uint
+		Username  string
+	}{}
+	err := d.db.Model(&CommentMention{}).Select("comment_mentions.comment_id, users.username").
+		Joins("JOIN users ON users.id = comment_mentions.user_id").
+		Where("comment_mentions.comment_id IN ?", ids).Order("comment_mentions.id").Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+	mentions := map[uint][]string{}
+	for _, v := range rows {
+		mentions[v.CommentID] = append(mentions[v.CommentID], v.Username)
+	}
+	for i := range comments {
+		comments[i].Mentions = mentions[comments[i].ID]
+	}
+	return nil
+}
+
+// takeComment returns the first comment of the selection with its mentions.
+func (d *DB) takeComment(selection *gorm.DB) (*domain.Comment, error) {
+	row := commentRow{}
+	res := selection.Limit(1).Scan(&row)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	comments := []domain.Comment{row.ToComment()}
+	err := d.attachMentions(comments)
+	if err != nil {
+		return nil, err
+	}
+	return &comments[0], nil
+}
+
+func replaceMentions(tx *gorm.DB, commentID uint, mentionIDs []uint) error {
+	err := tx.Unscoped().Where("comment_id = ?", commentID).Delete(&CommentMention{}).Error
+	if err != nil {
+		return err
+	}
+	if len(mentionIDs) == 0 {
+		return nil
+	}
+	mentions := []CommentMention{}
+	for _, id := range mentionIDs {
+		mentions = append(mentions, CommentMention{CommentID: commentID, UserID: id})
+	}
+	return tx.Create(&mentions).Error
+}
+
+// AddComment stores the comment with the users that it mentions in a transaction.
+func (d *DB) AddComment(ctx context.Context, comment domain.Comment, mentionIDs []uint) (*domain.Comment, error) {
+	row := Comment{
+		AssetType: string(comment.AssetType),
+		AssetID:   comment.AssetID,
+		ParentID:  comment.ParentID,
+		AuthorID:  comment.AuthorID,
+		Text:      comment.Text,
+	}
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&row).Error
+		if err != nil {
+			return err
+		}
+		return replaceMentions(tx, row.ID, mentionIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d.takeComment(d.selectComments().Where("comments.id = ?", row.ID))
+}
+
+// GetComment returns a comment of the asset.
+func (d *DB) GetComment(ctx context.Context, at domain.AssetType, assetID, commentID uint) (*domain.Comment, error) {
+	return d.takeComment(d.selectComments().
+		Where("comments.id = ? AND comments.asset_type = ? AND comments.asset_id = ?", commentID, string(at), assetID))
+}
+
+// UpdateComment replaces the text of the comment and the users that it mentions in a transaction.
+func (d *DB) UpdateComment(ctx context.Context, commentID uint, text string, mentionIDs []uint) (*domain.Comment, error) {
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Comment{}).Where("id = ?", commentID).
+			Updates(map[string]interface{}{"text": text, "edited_at": time.Now()}).Error
+		if err != nil {
+			return err
+		}
+		return replaceMentions(tx, commentID, mentionIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d.takeComment(d.selectComments().Where("comments.id = ?", commentID))
+}
+
+// RemoveComment removes the text and the mentions of the comment, which stays in its thread.
+func (d *DB) RemoveComment(ctx context.Context, commentID uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Comment{}).Where("id = ?", commentID).
+			Updates(map[string]interface{}{"text": "", "removed": true}).Error
+		if err != nil {
+			return err
+		}
+		return replaceMentions(tx, commentID, nil)
+	})
+}
+
+func (d *DB) SetCommentHidden(ctx context.Context, commentID uint, hidden bool) error {
+	return d.db.Model(&Comment{}).Where("id = ?", commentID).UpdateColumn("hidden", hidden).Error
+}
+
+func listCommentRows(ls *domain.ListedComments, rows []commentRow) {
+	ls.Comments = []domain.Comment{}
+	for _, v := range rows {
+		ls.Comments = append(ls.Comments, v.ToComment())
+	}
+	if len(rows) > 0 {
+		ls.FirstID = rows[0].ID
+		ls.LastID = rows[len(rows)-1].ID
+	}
+}
+
+// ListComments lists the comments of a thread of the asset after the last ID.
+func (d *DB) ListComments(ctx context.Context, at domain.AssetType, assetID uint, query domain.QueryComments) (*domain.ListedComments, error) {
+	rows := []commentRow{}
+	err := d.selectComments().
+		Where("comments.asset_type = ? AND comments.asset_id = ? AND comments.parent_id = ? AND comments.id > ?",
+			string(at), assetID, query.ParentID, query.LastID).
+		Order("comments.id").Limit(query.Limit).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	ls := &domain.ListedComments{Limit: query.Limit}
+	listCommentRows(ls, rows)
+	return ls, d.attachMentions(ls.Comments)
+}
+
+// ListMentions lists the comments that mention the user after the last ID.
+func (d *DB) ListMentions(ctx context.Context, userID uint, query domain.QueryComments) (*domain.ListedComments, error) {
+	rows := []commentRow{}
+	err := d.selectComments().
+		Joins("JOIN comment_mentions ON comment_mentions.comment_id = comments.id AND comment_mentions.deleted_at IS NULL").
+		Where("comment_mentions.user_id = ? AND comments.id > ?", userID, query.LastID).
+		Order("comments.id").Limit(query.Limit).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	ls := &domain.ListedComments{Limit: query.Limit}
+	listCommentRows(ls, rows)
+	return ls, d.attachMentions(ls.Comments)
+}
+
+// RemoveAssetComments removes the comments of the asset with their mentions.
+func (d *DB) RemoveAssetComments(ctx context.Context, at domain.AssetType, assetID uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		ids := tx.Model(&Comment{}).Select("id").Where("asset_type = ? AND asset_id = ?", string(at), assetID)
+		err := tx.Unscoped().Where("comment_id IN (?)", ids).Delete(&CommentMention{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("asset_type = ? AND asset_id = ?", string(at), assetID).Delete(&Comment{}).Error
+	})
+}
@@ -0,0 +1,75 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComments(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	author, err := db.AddUser(ctx, domain.User{Username: "manos", Password: "hashed"})
+	assert.NoError(t, err)
+	mentioned, err := db.AddUser(ctx, domain.User{Username: "maria", Password: "hashed"})
+	assert.NoError(t, err)
+	insight, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+		Data: &domain.Insight{Text: "40% of millenials", Description: "bla bla"}})
+	assert.NoError(t, err)
+
+	first, err := db.AddComment(ctx, domain.Comment{AssetType: domain.InsightAssetType, AssetID: insight.ID,
+		AuthorID: author.ID, Text: "@maria look"}, []uint{mentioned.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, "manos", first.Author)
+	assert.Equal(t, []string{"maria"}, first.Mentions)
+	_, err = db.AddComment(ctx, domain.Comment{AssetType: domain.InsightAssetType, AssetID: insight.ID,
+		ParentID: first.ID, AuthorID: mentioned.ID, Text: "nice"}, nil)
+	assert.NoError(t, err)
+	second, err := db.AddComment(ctx, domain.Comment{AssetType: domain.InsightAssetType, AssetID: insight.ID,
+		AuthorID: author.ID, Text: "second"}, nil)
+	assert.NoError(t, err)
+
+	ls, err := db.ListComments(ctx, domain.InsightAssetType, insight.ID, domain.QueryComments{Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(ls.Comments))
+	assert.Equal(t, int64(1), ls.Comments[0].Replies)
+	ls, err = db.ListComments(ctx, domain.InsightAssetType, insight.ID, domain.QueryComments{Limit: 10, LastID: ls.LastID})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(ls.Comments))
+	assert.Equal(t, second.ID, ls.Comments[0].ID)
+	ls, err = db.ListComments(ctx, domain.InsightAssetType, insight.ID, domain.QueryComments{Limit: 10, ParentID: first.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, "nice", ls.Comments[0].Text)
+
+	mentions, err := db.ListMentions(ctx, mentioned.ID, domain.QueryComments{Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(mentions.Comments))
+
+	edited, err := db.UpdateComment(ctx, first.ID, "look again", nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, edited.EditedAt)
+	assert.Nil(t, edited.Mentions)
+	err = db.SetCommentHidden(ctx, second.ID, true)
+	assert.NoError(t, err)
+	err = db.RemoveComment(ctx, first.ID)
+	assert.NoError(t, err)
+	removed, err := db.GetComment(ctx, domain.InsightAssetType, insight.ID, first.ID)
+	assert.NoError(t, err)
+	assert.True(t, removed.Deleted)
+	assert.Equal(t, "", removed.Text)
+	assert.Equal(t, int64(1), removed.Replies)
+	hidden, err := db.GetComment(ctx, domain.InsightAssetType, insight.ID, second.ID)
+	assert.NoError(t, err)
+	assert.True(t, hidden.Hidden)
+	_, err = db.GetComment(ctx, domain.ChartAssetType, insight.ID, second.ID)
+	assert.Error(t, err)
+
+	err = db.RemoveAssetComments(ctx, domain.InsightAssetType, insight.ID)
+	assert.NoError(t, err)
+	ls, err = db.ListComments(ctx, domain.InsightAssetType, insight.ID, domain.QueryComments{Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(ls.Comments))
+}
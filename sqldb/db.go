@@ -23,13 +23,44 @@ func (db *DB) GormDB() *gorm.DB {
 }
 
 func (db *DB) CreateTables() {
+	// the favourites of the tables that are migrated without the favourite count are counted once
+	uncounted := db.uncountedAssetTypes()
 	db.db.AutoMigrate(&User{})
 	db.db.AutoMigrate(&Insight{})
 	db.db.AutoMigrate(&Audience{})
 	db.db.AutoMigrate(&Chart{})
+	err := db.removeDuplicateFavourites()
+	if err != nil {
+		log.Println("Error DB: ", err)
+	}
 	db.db.AutoMigrate(&FavouriteInsight{})
 	db.db.AutoMigrate(&FavouriteChart{})
 	db.db.AutoMigrate(&FavouriteAudience{})
+	db.db.AutoMigrate(&Organization{})
+	db.db.AutoMigrate(&OrganizationMember{})
+	db.db.AutoMigrate(&UserGroup{})
+	db.db.AutoMigrate(&UserGroupMember{})
+	db.db.AutoMigrate(&AssetGrant{})
+	db.db.AutoMigrate(&Collection{})
+	db.db.AutoMigrate(&CollectionItem{})
+	db.db.AutoMigrate(&Tag{})
+	db.db.AutoMigrate(&AssetTag{})
+	db.db.AutoMigrate(&AssetLink{})
+	db.db.AutoMigrate(&AssetTranslation{})
+	db.db.AutoMigrate(&AssetSimilarity{})
+	db.db.AutoMigrate(&AssetView{})
+	db.db.AutoMigrate(&Comment{})
+	db.db.AutoMigrate(&CommentMention{})
+	db.db.AutoMigrate(&AssetRating{})
+	db.db.AutoMigrate(&AssetFlag{})
+	db.db.AutoMigrate(&Webhook{})
+	db.db.AutoMigrate(&WebhookDelivery{})
+	for _, at := range uncounted {
+		err := db.countFavourites(at)
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
 }
 
 func (db *DB) DropTablesIfExist() {
@@ -76,4 +107,143 @@ func (db *DB) DropTablesIfExist() {
 			log.Println("Error DB: ", err)
 		}
 	}
+	if mgt.HasTable(&Organization{}) {
+		err := mgt.DropTable(&Organization{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&OrganizationMember{}) {
+		err := mgt.DropTable(&OrganizationMember{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&UserGroup{}) {
+		err := mgt.DropTable(&UserGroup{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&UserGroupMember{}) {
+		err := mgt.DropTable(&UserGroupMember{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&AssetGrant{}) {
+		err := mgt.DropTable(&AssetGrant{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&Collection{}) {
+		err := mgt.DropTable(&Collection{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&CollectionItem{}) {
+		err := mgt.DropTable(&CollectionItem{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&Tag{}) {
+		err := mgt.DropTable(&Tag{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&AssetTag{}) {
+		err := mgt.DropTable(&AssetTag{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&AssetLink{}) {
+		err := mgt.DropTable(&AssetLink{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&AssetTranslation{}) {
+		err := mgt.DropTable(&AssetTranslation{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&AssetSimilarity{}) {
+		err := mgt.DropTable(&AssetSimilarity{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&AssetView{}) {
+		err := mgt.DropTable(&AssetView{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&Comment{}) {
+		err := mgt.DropTable(&Comment{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&CommentMention{}) {
+		err := mgt.DropTable(&CommentMention{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&AssetRating{}) {
+		err := mgt.DropTable(&AssetRating{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&AssetFlag{}) {
+		err := mgt.DropTable(&AssetFlag{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&Webhook{}) {
+		err := mgt.DropTable(&Webhook{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+	if mgt.HasTable(&WebhookDelivery{}) {
+		err := mgt.DropTable(&WebhookDelivery{})
+		if err != nil {
+			log.Println("Error DB: ", err)
+		}
+	}
+}
+
+// removeDuplicateFavourites keeps the oldest favourite of a user for an asset, so the unique index of the
+// favourites can be created on the tables that were created without it.
+func (db *DB) removeDuplicateFavourites() error {
+	mgt := db.db.Migrator()
+	tables := []struct {
+		model  interface{}
+		table  string
+		column string
+	}{
+		{&FavouriteInsight{}, "favourite_insights", "insight_id"},
+		{&FavouriteChart{}, "favourite_charts", "chart_id"},
+		{&FavouriteAudience{}, "favourite_audiences", "audience_id"},
+	}
+	for _, v := range tables {
+		if !mgt.HasTable(v.model) {
+			continue
+		}
+		err := db.db.Exec(fmt.Sprintf("DELETE f1 FROM %[1]s f1 JOIN %[1]s f2 ON f1.user_id = f2.user_id AND f1.%[2]s = f2.%[2]s AND f1.id > f2.id", v.table, v.column)).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
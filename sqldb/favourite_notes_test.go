@@ -0,0 +1,54 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFavouriteNotes(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	scope := domain.AssetScope{UserID: 1}
+	texts := []string{"useful for the retail report", "100% outdated"}
+	for _, text := range texts {
+		asset, err := db.AddAsset(ctx, scope, domain.InputAsset{
+			Visibility: domain.OrganizationVisibility,
+			Data: &domain.Insight{
+				Text:        "40% of millenials spend more than 3hours on social media daily",
+				Description: "example",
+			}})
+		assert.NoError(t, err)
+
+		found, err := db.SetFavouriteNote(ctx, 1, asset.ID, domain.InsightAssetType, domain.FavouriteNote{Text: text, UpdatedAt: time.Now()})
+		assert.NoError(t, err)
+		assert.False(t, found)
+
+		_, err = db.FavouriteAsset(ctx, scope, 1, asset.ID, domain.InsightAssetType, true)
+		assert.NoError(t, err)
+		found, err = db.SetFavouriteNote(ctx, 1, asset.ID, domain.InsightAssetType, domain.FavouriteNote{Text: text, UpdatedAt: time.Now()})
+		assert.NoError(t, err)
+		assert.True(t, found)
+	}
+
+	qa := domain.QueryAssets{
+		Limit:  10,
+		LastID: 0,
+		Type:   domain.InsightAssetType,
+	}
+	la, err := db.ListFavouriteAssets(ctx, scope, 1, true, qa)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(la.Assets))
+	assert.NotNil(t, la.Assets[0].Note)
+	assert.Equal(t, texts[0], la.Assets[0].Note.Text)
+
+	qa.NoteSearch = "%"
+	la, err = db.ListFavouriteAssets(ctx, scope, 1, true, qa)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(la.Assets))
+	assert.Equal(t, uint(2), la.Assets[0].ID)
+}
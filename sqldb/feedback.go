@@ -0,0 +1,333 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"platform-go-challenge/domain"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// lockAsset locks the row of the asset until the end of the transaction, so the ratings of the asset
+// change one after the other and the check of the rating of the user is not raced by another request.
+func lockAsset(tx *gorm.DB, model interface{}, assetID uint) error {
+	row := AssetModel{}
+	res := tx.Model(model).Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", assetID).Scan(&row)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: %v", domain.ErrAssetNotFound, gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// getFeedback returns the ratings and the open flags of the asset.
+func getFeedback(tx *gorm.DB, at domain.AssetType, assetID uint) (*domain.AssetFeedback, error) {
+	model, err := assetModel(at)
+	if err != nil {
+		return nil, fmt.Errorf("getFeedback: %w", err)
+	}
+	row := AssetModel{}
+	res := tx.Model(model).Select("rating_count, rating_sum, outdated_flags, incorrect_flags").
+		Where("id = ?", assetID).Scan(&row)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, fmt.Errorf("%w: %v", domain.ErrAssetNotFound, gorm.ErrRecordNotFound)
+	}
+	feedback := row.feedback()
+	return &feedback, nil
+}
+
+// RateAsset adds or replaces the rating of the user and changes the rating count and sum of the asset
+// by the rating that was stored before, in a transaction that locks the asset.
+func (d *DB) RateAsset(ctx context.Context, userID uint, at domain.AssetType, assetID uint, rating int) (*domain.AssetFeedback, error) {
+	model, err := assetModel(at)
+	if err != nil {
+		return nil, fmt.Errorf("RateAsset: %w", err)
+	}
+	var feedback *domain.AssetFeedback
+	err = d.db.Transaction(func(tx *gorm.DB) error {
+		err := lockAsset(tx, model, assetID)
+		if err != nil {
+			return err
+		}
+		existing := AssetRating{}
+		res := tx.Where("user_id = ? AND asset_type = ? AND asset_id = ?", userID, string(at), assetID).Limit(1).Find(&existing)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			err = tx.Create(&AssetRating{UserID: userID, AssetType: string(at), AssetID: assetID, Rating: uint(rating)}).Error
+			if err != nil {
+				return err
+			}
+			err = tx.Model(model).Where("id = ?", assetID).UpdateColumns(map[string]interface{}{
+				"rating_count": gorm.Expr("rating_count + 1"),
+				"rating_sum":   gorm.Expr("rating_sum + ?", rating),
+			}).Error
+			if err != nil {
+				return err
+			}
+		} else {
+			err = tx.Model(&existing).UpdateColumn("rating", rating).Error
+			if err != nil {
+				return err
+			}
+			err = tx.Model(model).Where("id = ?", assetID).
+				UpdateColumn("rating_sum", gorm.Expr("rating_sum + ? - ?", rating, existing.Rating)).Error
+			if err != nil {
+				return err
+			}
+		}
+		feedback, err = getFeedback(tx, at, assetID)
+		return err
+	})
+	return feedback, err
+}
+
+// UnrateAsset removes the rating of the user and changes the rating count and sum of the asset in a transaction
+// that locks the asset.
+// Nothing changes when the user has not rated the asset.
+func (d *DB) UnrateAsset(ctx context.Context, userID uint, at domain.AssetType, assetID uint) (*domain.AssetFeedback, error) {
+	model, err := assetModel(at)
+	if err != nil {
+		return nil, fmt.Errorf("UnrateAsset: %w", err)
+	}
+	var feedback *domain.AssetFeedback
+	err = d.db.Transaction(func(tx *gorm.DB) error {
+		err := lockAsset(tx, model, assetID)
+		if err != nil {
+			return err
+		}
+		existing := AssetRating{}
+		res := tx.Where("user_id = ? AND asset_type = ? AND asset_id = ?", userID, string(at), assetID).Limit(1).Find(&existing)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			err = tx.Unscoped().Delete(&existing).Error
+			if err != nil {
+				return err
+			}
+			err = tx.Model(model).Where("id = ?", assetID).UpdateColumns(map[string]interface{}{
+				"rating_count": gorm.Expr("GREATEST(rating_count, 1) - 1"),
+				"rating_sum":   gorm.Expr("GREATEST(rating_sum, ?) - ?", existing.Rating, existing.Rating),
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		feedback, err = getFeedback(tx, at, assetID)
+		return err
+	})
+	return feedback, err
+}
+
+// flagColumn is the column of the assets that counts the open flags with the reason.
+func flagColumn(reason domain.FlagReason) (string, error) {
+	switch reason {
+	case domain.OutdatedFlag:
+		return "outdated_flags", nil
+	case domain.IncorrectFlag:
+		return "incorrect_flags", nil
+	}
+	return "", fmt.Errorf("unknown flag reason %s", reason)
+}
+
+func (f *AssetFlag) ToDomain() domain.AssetFlag {
+	return domain.AssetFlag{
+		ID:         f.ID,
+		AssetType:  domain.AssetType(f.AssetType),
+		AssetID:    f.AssetID,
+		UserID:     f.UserID,
+		Reason:     domain.FlagReason(f.Reason),
+		Comment:    f.Comment,
+		CreatedAt:  f.CreatedAt,
+		ResolvedAt: f.ResolvedAt,
+		ResolvedBy: f.ResolvedBy,
+	}
+}
+
+// FlagAsset adds the flag and increases the open flags of its asset in a transaction, unless the user
+// has an open flag with the same reason on the asset, which is returned.
+func (d *DB) FlagAsset(ctx context.Context, flag domain.AssetFlag) (*domain.AssetFlag, error) {
+	model, err := assetModel(flag.AssetType)
+	if err != nil {
+		return nil, fmt.Errorf("FlagAsset: %w", err)
+	}
+	column, err := flagColumn(flag.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("FlagAsset: %w", err)
+	}
+	row := AssetFlag{}
+	err = d.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("user_id = ? AND asset_type = ? AND asset_id = ? AND reason = ? AND resolved_at IS NULL",
+			flag.UserID, string(flag.AssetType), flag.AssetID, string(flag.Reason)).Limit(1).Find(&row)
+		if res.Error != nil || res.RowsAffected > 0 {
+			return res.Error
+		}
+		row = AssetFlag{
+			UserID:    flag.UserID,
+			AssetType: string(flag.AssetType),
+			AssetID:   flag.AssetID,
+			Reason:    string(flag.Reason),
+			Comment:   flag.Comment,
+		}
+		err := tx.Create(&row).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(model).Where("id = ?", flag.AssetID).UpdateColumn(column, gorm.Expr(column+" + 1")).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	newFlag := row.ToDomain()
+	return &newFlag, nil
+}
+
+// ListOpenFlags returns the open flags of the asset from the first one.
+func (d *DB) ListOpenFlags(ctx context.Context, at domain.AssetType, assetID uint) ([]domain.AssetFlag, error) {
+	rows := []AssetFlag{}
+	err := d.db.Where("asset_type = ? AND asset_id = ? AND resolved_at IS NULL", string(at), assetID).Order("id").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	flags := []domain.AssetFlag{}
+	for i := range rows {
+		flags = append(flags, rows[i].ToDomain())
+	}
+	return flags, nil
+}
+
+// flaggedRow is an asset with the number of its open flags and the time of the first one.
+type flaggedRow struct {
+	AssetID     uint      `gorm:"column:asset_id"`
+	OpenFlags   int64     `gorm:"column:open_flags"`
+	FirstFlagAt time.Time `gorm:"column:first_flag_at"`
+}
+
+// ListFlaggedAssets returns the assets of the organization with open flags, of the type or of any type without type.
+// The assets with the most open flags come first, and then the assets flagged first.
+func (d *DB) ListFlaggedAssets(ctx context.Context, orgID uint, at domain.AssetType, limit int) ([]domain.FlaggedAsset, error) {
+	types := []domain.AssetType{domain.InsightAssetType, domain.ChartAssetType, domain.AudienceAssetType}
+	if at != "" {
+		types = []domain.AssetType{at}
+	}
+	flagged := []domain.FlaggedAsset{}
+	for _, t := range types {
+		table := string(t)
+		rows := []flaggedRow{}
+		err := d.db.Model(&AssetFlag{}).
+			Select("asset_flags.asset_id, COUNT(*) AS open_flags, MIN(asset_flags.created_at) AS first_flag_at").
+			Joins("INNER JOIN "+table+" ON "+table+".id = asset_flags.asset_id AND "+table+".deleted_at IS NULL").
+			Where("asset_flags.asset_type = ? AND asset_flags.resolved_at IS NULL AND "+table+".organization_id = ?", table, orgID).
+			Group("asset_flags.asset_id").
+			Order("open_flags desc, first_flag_at asc").
+			Limit(limit).
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		ids := make([]uint, len(rows))
+		for i, v := range rows {
+			ids[i] = v.AssetID
+		}
+		assets, err := d.findAssets(t, ids)
+		if err != nil {
+			return nil, err
+		}
+		err = d.attachTags(t, assets)
+		if err != nil {
+			return nil, err
+		}
+		byID := map[uint]domain.Asset{}
+		for _, v := range assets {
+			byID[v.ID] = v
+		}
+		for _, v := range rows {
+			asset, ok := byID[v.AssetID]
+			if !ok {
+				continue
+			}
+			flagged = append(flagged, domain.FlaggedAsset{
+				AssetType:   t,
+				AssetID:     v.AssetID,
+				OpenFlags:   v.OpenFlags,
+				FirstFlagAt: v.FirstFlagAt,
+				Asset:       &asset,
+			})
+		}
+	}
+	sort.SliceStable(flagged, func(i, j int) bool {
+		if flagged[i].OpenFlags != flagged[j].OpenFlags {
+			return flagged[i].OpenFlags > flagged[j].OpenFlags
+		}
+		return flagged[i].FirstFlagAt.Before(flagged[j].FirstFlagAt)
+	})
+	if len(flagged) > limit {
+		flagged = flagged[:limit]
+	}
+	return flagged, nil
+}
+
+// ResolveFlags resolves the open flags of the asset with the reason, or all of them without reason, and counts
+// again the open flags of the asset in a transaction. It returns the number of the resolved flags.
+func (d *DB) ResolveFlags(ctx context.Context, at domain.AssetType, assetID uint, reason domain.FlagReason, resolvedBy uint) (int64, error) {
+	model, err := assetModel(at)
+	if err != nil {
+		return 0, fmt.Errorf("ResolveFlags: %w", err)
+	}
+	var resolved int64
+	err = d.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&AssetFlag{}).Where("asset_type = ? AND asset_id = ? AND resolved_at IS NULL", string(at), assetID)
+		if reason != "" {
+			query = query.Where("reason = ?", string(reason))
+		}
+		res := query.Updates(map[string]interface{}{"resolved_at": time.Now(), "resolved_by": resolvedBy})
+		if res.Error != nil {
+			return res.Error
+		}
+		resolved = res.RowsAffected
+		counts := []struct {
+			Reason    string
+			OpenFlags uint
+		}{}
+		err := tx.Model(&AssetFlag{}).Select("reason, COUNT(*) AS open_flags").
+			Where("asset_type = ? AND asset_id = ? AND resolved_at IS NULL", string(at), assetID).
+			Group("reason").Scan(&counts).Error
+		if err != nil {
+			return err
+		}
+		columns := map[string]interface{}{"outdated_flags": 0, "incorrect_flags": 0}
+		for _, v := range counts {
+			column, err := flagColumn(domain.FlagReason(v.Reason))
+			if err != nil {
+				continue
+			}
+			columns[column] = v.OpenFlags
+		}
+		return tx.Model(model).Where("id = ?", assetID).UpdateColumns(columns).Error
+	})
+	return resolved, err
+}
+
+// RemoveAssetFeedback removes the ratings and the flags of the asset.
+func (d *DB) RemoveAssetFeedback(ctx context.Context, at domain.AssetType, assetID uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("asset_type = ? AND asset_id = ?", string(at), assetID).Delete(&AssetRating{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("asset_type = ? AND asset_id = ?", string(at), assetID).Delete(&AssetFlag{}).Error
+	})
+}
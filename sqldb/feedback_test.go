@@ -0,0 +1,127 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatingsAndFlags(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	insight, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+		Data: &domain.Insight{Text: "40% of millenials", Description: "bla bla"}})
+	assert.NoError(t, err)
+	chart, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+		Data: &domain.Chart{Title: "title", XTitle: "x", YTitle: "y", Description: "bla bla",
+			Data: domain.XYData{X: []float64{1, 2}, Y: []float64{1, 2}}}})
+	assert.NoError(t, err)
+
+	_, err = db.RateAsset(ctx, 1, domain.InsightAssetType, insight.ID, 5)
+	assert.NoError(t, err)
+	feedback, err := db.RateAsset(ctx, 2, domain.InsightAssetType, insight.ID, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), feedback.RatingCount)
+	assert.Equal(t, 3.5, feedback.RatingAverage)
+	// rating again replaces the rating of the user
+	feedback, err = db.RateAsset(ctx, 2, domain.InsightAssetType, insight.ID, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), feedback.RatingCount)
+	assert.Equal(t, 4.5, feedback.RatingAverage)
+	feedback, err = db.UnrateAsset(ctx, 1, domain.InsightAssetType, insight.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), feedback.RatingCount)
+	assert.Equal(t, 4.0, feedback.RatingAverage)
+	_, err = db.UnrateAsset(ctx, 1, domain.InsightAssetType, insight.ID+100)
+	assert.ErrorIs(t, err, domain.ErrAssetNotFound)
+
+	// the counts are not written by the updates of the asset
+	_, err = db.UpdateAsset(ctx, domain.AssetScope{}, insight.ID, domain.InputAsset{
+		Data: &domain.Insight{Text: "41% of millenials", Description: "bla bla"}})
+	assert.NoError(t, err)
+
+	first, err := db.FlagAsset(ctx, domain.AssetFlag{AssetType: domain.InsightAssetType, AssetID: insight.ID,
+		UserID: 1, Reason: domain.OutdatedFlag})
+	assert.NoError(t, err)
+	again, err := db.FlagAsset(ctx, domain.AssetFlag{AssetType: domain.InsightAssetType, AssetID: insight.ID,
+		UserID: 1, Reason: domain.OutdatedFlag})
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID)
+	_, err = db.FlagAsset(ctx, domain.AssetFlag{AssetType: domain.InsightAssetType, AssetID: insight.ID,
+		UserID: 2, Reason: domain.IncorrectFlag})
+	assert.NoError(t, err)
+	_, err = db.FlagAsset(ctx, domain.AssetFlag{AssetType: domain.ChartAssetType, AssetID: chart.ID,
+		UserID: 2, Reason: domain.IncorrectFlag})
+	assert.NoError(t, err)
+
+	got, err := db.GetAsset(ctx, domain.AssetScope{}, domain.InsightAssetType, insight.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), got.Feedback.OutdatedFlags)
+	assert.Equal(t, uint(1), got.Feedback.IncorrectFlags)
+	assert.Equal(t, uint(1), got.Feedback.RatingCount)
+
+	flagged, err := db.ListFlaggedAssets(ctx, 0, "", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(flagged))
+	assert.Equal(t, insight.ID, flagged[0].AssetID)
+	assert.Equal(t, int64(2), flagged[0].OpenFlags)
+	assert.Equal(t, domain.ChartAssetType, flagged[1].AssetType)
+	flagged, err = db.ListFlaggedAssets(ctx, 1, "", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(flagged))
+
+	n, err := db.ResolveFlags(ctx, domain.InsightAssetType, insight.ID, domain.OutdatedFlag, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	flags, err := db.ListOpenFlags(ctx, domain.InsightAssetType, insight.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(flags))
+	assert.Equal(t, domain.IncorrectFlag, flags[0].Reason)
+	got, err = db.GetAsset(ctx, domain.AssetScope{}, domain.InsightAssetType, insight.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), got.Feedback.OutdatedFlags)
+	assert.Equal(t, uint(1), got.Feedback.IncorrectFlags)
+
+	n, err = db.ResolveFlags(ctx, domain.InsightAssetType, insight.ID, "", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	flagged, err = db.ListFlaggedAssets(ctx, 0, domain.InsightAssetType, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(flagged))
+
+	err = db.RemoveAssetFeedback(ctx, domain.ChartAssetType, chart.ID)
+	assert.NoError(t, err)
+	flags, err = db.ListOpenFlags(ctx, domain.ChartAssetType, chart.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(flags))
+}
+
+func TestConcurrentRatings(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	insight, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+		Data: &domain.Insight{Text: "40% of millenials", Description: "bla bla"}})
+	assert.NoError(t, err)
+
+	// the same user rates the asset from many requests at once
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(rating int) {
+			defer wg.Done()
+			_, err := db.RateAsset(ctx, 1, domain.InsightAssetType, insight.ID, rating)
+			assert.NoError(t, err)
+		}(i%5 + 1)
+	}
+	wg.Wait()
+	// the sum follows the stored rating, so a last rating gives its own average
+	feedback, err := db.RateAsset(ctx, 1, domain.InsightAssetType, insight.ID, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), feedback.RatingCount)
+	assert.Equal(t, 3.0, feedback.RatingAverage)
+}
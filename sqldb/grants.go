@@ -0,0 +1,72 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+)
+
+func (d *DB) AddGroup(ctx context.Context, group domain.Group) (*domain.Group, error) {
+	g := &UserGroup{}
+	g.FromDomain(&group)
+	err := d.db.Create(g).Error
+	if err != nil {
+		return nil, err
+	}
+	return g.ToDomain(), nil
+}
+
+func (d *DB) GetGroup(ctx context.Context, groupID uint) (*domain.Group, error) {
+	g := UserGroup{}
+	err := d.db.First(&g, groupID).Error
+	if err != nil {
+		return nil, err
+	}
+	return g.ToDomain(), nil
+}
+
+func (d *DB) AddGroupMember(ctx context.Context, groupID, userID uint) error {
+	var exists bool
+	err := d.db.Model(&UserGroupMember{}).Select("count(*) > 0").Where("user_group_id = ? AND user_id = ? ", groupID, userID).Find(&exists).Error
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	return d.db.Create(&UserGroupMember{UserGroupID: groupID, UserID: userID}).Error
+}
+
+func (d *DB) AddAssetGrant(ctx context.Context, at domain.AssetType, assetID uint, grant domain.AssetGrant) (*domain.AssetGrant, error) {
+	ag := &AssetGrant{
+		AssetType:   string(at),
+		AssetID:     assetID,
+		UserID:      grant.UserID,
+		UserGroupID: grant.GroupID,
+	}
+	err := d.db.Create(ag).Error
+	if err != nil {
+		return nil, err
+	}
+	return ag.ToDomain(), nil
+}
+
+func (d *DB) RemoveAssetGrant(ctx context.Context, at domain.AssetType, assetID, grantID uint) error {
+	return d.db.Unscoped().Where("asset_type = ? AND asset_id = ? ", string(at), assetID).Delete(&AssetGrant{}, grantID).Error
+}
+
+func (d *DB) ListAssetGrants(ctx context.Context, at domain.AssetType, assetID uint) ([]domain.AssetGrant, error) {
+	var ags []AssetGrant
+	err := d.db.Where("asset_type = ? AND asset_id = ? ", string(at), assetID).Order("id asc").Find(&ags).Error
+	if err != nil {
+		return nil, err
+	}
+	grants := []domain.AssetGrant{}
+	for _, v := range ags {
+		grants = append(grants, *v.ToDomain())
+	}
+	return grants, nil
+}
+
+func (d *DB) RemoveAssetGrants(ctx context.Context, at domain.AssetType, assetID uint) error {
+	return d.db.Unscoped().Where("asset_type = ? AND asset_id = ? ", string(at), assetID).Delete(&AssetGrant{}).Error
+}
@@ -0,0 +1,61 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetVisibility(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	owner := domain.AssetScope{OrgID: 1, UserID: 1, IsAdmin: true}
+	member := domain.AssetScope{OrgID: 1, UserID: 2}
+	grantee := domain.AssetScope{OrgID: 1, UserID: 3}
+	outsider := domain.AssetScope{OrgID: 2, UserID: 4}
+
+	visibilities := []domain.Visibility{domain.PublicVisibility, domain.OrganizationVisibility, domain.PrivateVisibility}
+	for _, v := range visibilities {
+		_, err := db.AddAsset(ctx, owner, domain.InputAsset{
+			Visibility: v,
+			Data: &domain.Insight{
+				Text:        "40% of millenials spend more than 3hours on social media daily",
+				Description: string(v),
+			}})
+		assert.NoError(t, err)
+	}
+	group, err := db.AddGroup(ctx, domain.Group{OrgID: 1, Name: "analysts"})
+	assert.NoError(t, err)
+	err = db.AddGroupMember(ctx, group.ID, grantee.UserID)
+	assert.NoError(t, err)
+	_, err = db.AddAssetGrant(ctx, domain.InsightAssetType, 3, domain.AssetGrant{GroupID: group.ID})
+	assert.NoError(t, err)
+
+	qa := domain.QueryAssets{
+		Limit:  10,
+		LastID: 0,
+		Type:   domain.InsightAssetType,
+	}
+	expected := map[uint]domain.AssetScope{3: owner, 2: member, 1: outsider}
+	for count, scope := range expected {
+		la, err := db.ListAssets(ctx, scope, qa)
+		assert.NoError(t, err)
+		assert.Equal(t, int(count), len(la.Assets))
+	}
+	la, err := db.ListAssets(ctx, grantee, qa)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(la.Assets))
+
+	_, err = db.GetAsset(ctx, member, domain.InsightAssetType, 3)
+	assert.Error(t, err)
+	_, err = db.GetAsset(ctx, grantee, domain.InsightAssetType, 3)
+	assert.NoError(t, err)
+
+	err = db.RemoveAssetGrants(ctx, domain.InsightAssetType, 3)
+	assert.NoError(t, err)
+	_, err = db.GetAsset(ctx, grantee, domain.InsightAssetType, 3)
+	assert.Error(t, err)
+}
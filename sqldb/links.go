@@ -0,0 +1,39 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+)
+
+func (d *DB) AddAssetLink(ctx context.Context, link domain.AssetLink) (*domain.AssetLink, error) {
+	l := &AssetLink{}
+	l.FromDomain(&link)
+	err := d.db.Create(l).Error
+	if err != nil {
+		return nil, err
+	}
+	return l.ToDomain(), nil
+}
+
+// RemoveAssetLink removes a link that starts from the asset.
+func (d *DB) RemoveAssetLink(ctx context.Context, at domain.AssetType, assetID, linkID uint) error {
+	return d.db.Unscoped().Where("from_type = ? AND from_id = ? ", string(at), assetID).Delete(&AssetLink{}, linkID).Error
+}
+
+// ListAssetLinks returns the links that start from or end to the asset.
+func (d *DB) ListAssetLinks(ctx context.Context, at domain.AssetType, assetID uint) ([]domain.AssetLink, error) {
+	var ls []AssetLink
+	err := d.db.Where("(from_type = ? AND from_id = ?) OR (to_type = ? AND to_id = ?)", string(at), assetID, string(at), assetID).Order("id asc").Find(&ls).Error
+	if err != nil {
+		return nil, err
+	}
+	links := []domain.AssetLink{}
+	for _, v := range ls {
+		links = append(links, *v.ToDomain())
+	}
+	return links, nil
+}
+
+func (d *DB) RemoveAssetLinks(ctx context.Context, at domain.AssetType, assetID uint) error {
+	return d.db.Unscoped().Where("(from_type = ? AND from_id = ?) OR (to_type = ? AND to_id = ?)", string(at), assetID, string(at), assetID).Delete(&AssetLink{}).Error
+}
@@ -0,0 +1,52 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetLinks(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	link, err := db.AddAssetLink(ctx, domain.AssetLink{
+		Relation: domain.SupportedByRelation,
+		FromType: domain.InsightAssetType,
+		FromID:   1,
+		ToType:   domain.ChartAssetType,
+		ToID:     2,
+	})
+	assert.NoError(t, err)
+	_, err = db.AddAssetLink(ctx, domain.AssetLink{
+		Relation: domain.AboutRelation,
+		FromType: domain.InsightAssetType,
+		FromID:   1,
+		ToType:   domain.AudienceAssetType,
+		ToID:     3,
+	})
+	assert.NoError(t, err)
+
+	links, err := db.ListAssetLinks(ctx, domain.InsightAssetType, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(links))
+	links, err = db.ListAssetLinks(ctx, domain.ChartAssetType, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(links))
+	assert.Equal(t, domain.SupportedByRelation, links[0].Relation)
+
+	err = db.RemoveAssetLink(ctx, domain.ChartAssetType, 2, link.ID)
+	assert.NoError(t, err)
+	links, err = db.ListAssetLinks(ctx, domain.ChartAssetType, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(links))
+
+	err = db.RemoveAssetLinks(ctx, domain.AudienceAssetType, 3)
+	assert.NoError(t, err)
+	links, err = db.ListAssetLinks(ctx, domain.InsightAssetType, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(links))
+	assert.Equal(t, domain.ChartAssetType, links[0].ToType)
+}
@@ -0,0 +1,63 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+)
+
+func (d *DB) AddOrganization(ctx context.Context, org domain.Organization) (*domain.Organization, error) {
+	o := &Organization{}
+	o.FromDomain(&org)
+	err := d.db.Create(o).Error
+	if err != nil {
+		return nil, err
+	}
+	return o.ToDomain(), nil
+}
+
+func (d *DB) GetOrganization(ctx context.Context, orgID uint) (*domain.Organization, error) {
+	o := Organization{}
+	err := d.db.First(&o, orgID).Error
+	if err != nil {
+		return nil, err
+	}
+	return o.ToDomain(), nil
+}
+
+func (d *DB) AddOrganizationMember(ctx context.Context, orgID, userID uint, isAdmin bool) error {
+	m := &OrganizationMember{OrganizationID: orgID, UserID: userID, IsAdmin: isAdmin}
+	return d.db.Create(m).Error
+}
+
+func (d *DB) IsOrganizationMember(ctx context.Context, orgID, userID uint) (bool, error) {
+	var exists bool
+	err := d.db.Model(&OrganizationMember{}).Select("count(*) > 0").Where("organization_id = ? AND user_id = ? ", orgID, userID).Find(&exists).Error
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// IsOrganizationAdmin reports whether the user is a member of the organization with administrator rights in it.
+func (d *DB) IsOrganizationAdmin(ctx context.Context, orgID, userID uint) (bool, error) {
+	var exists bool
+	err := d.db.Model(&OrganizationMember{}).Select("count(*) > 0").Where("organization_id = ? AND user_id = ? AND is_admin = ?", orgID, userID, true).Find(&exists).Error
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (d *DB) ListUserOrganizations(ctx context.Context, userID uint) ([]domain.Organization, error) {
+	var os []Organization
+	err := d.db.Joins("INNER JOIN organization_members ON organization_members.organization_id = organizations.id AND organization_members.user_id = ? AND organization_members.deleted_at IS NULL", userID).
+		Order("organizations.id asc").Find(&os).Error
+	if err != nil {
+		return nil, err
+	}
+	orgs := []domain.Organization{}
+	for _, v := range os {
+		orgs = append(orgs, *v.ToDomain())
+	}
+	return orgs, nil
+}
@@ -0,0 +1,100 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationMembers(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	user, err := db.AddUser(ctx, domain.User{
+		Username: "manos",
+		Password: "hashed",
+	})
+	assert.NoError(t, err)
+
+	org, err := db.AddOrganization(ctx, domain.Organization{Name: "GWI"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), org.ID)
+
+	isMember, err := db.IsOrganizationMember(ctx, org.ID, user.ID)
+	assert.NoError(t, err)
+	assert.False(t, isMember)
+
+	err = db.AddOrganizationMember(ctx, org.ID, user.ID, false)
+	assert.NoError(t, err)
+
+	isMember, err = db.IsOrganizationMember(ctx, org.ID, user.ID)
+	assert.NoError(t, err)
+	assert.True(t, isMember)
+
+	isAdmin, err := db.IsOrganizationAdmin(ctx, org.ID, user.ID)
+	assert.NoError(t, err)
+	assert.False(t, isAdmin)
+
+	admin, err := db.AddUser(ctx, domain.User{
+		Username: "admin",
+		Password: "hashed",
+	})
+	assert.NoError(t, err)
+	err = db.AddOrganizationMember(ctx, org.ID, admin.ID, true)
+	assert.NoError(t, err)
+	isAdmin, err = db.IsOrganizationAdmin(ctx, org.ID, admin.ID)
+	assert.NoError(t, err)
+	assert.True(t, isAdmin)
+
+	orgs, err := db.ListUserOrganizations(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(orgs))
+	assert.Equal(t, "GWI", orgs[0].Name)
+}
+
+func TestAssetsIsolatedByOrganization(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	scope1 := domain.AssetScope{OrgID: 1}
+	scope2 := domain.AssetScope{OrgID: 2}
+	asset, err := db.AddAsset(ctx, scope1, domain.InputAsset{
+		Data: &domain.Audience{
+			AgeMax:            30,
+			AgeMin:            20,
+			Gender:            domain.FemaleGenderType,
+			Country:           "Sweden",
+			HoursSpent:        3,
+			NumberOfPurchases: 3,
+			Description:       "bla bla",
+		}})
+	assert.NoError(t, err)
+
+	_, err = db.GetAsset(ctx, scope1, domain.AudienceAssetType, asset.ID)
+	assert.NoError(t, err)
+	_, err = db.GetAsset(ctx, scope2, domain.AudienceAssetType, asset.ID)
+	assert.Error(t, err)
+
+	qa := domain.QueryAssets{
+		Limit:  10,
+		LastID: 0,
+		Type:   domain.AudienceAssetType,
+	}
+	la, err := db.ListAssets(ctx, scope2, qa)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(la.Assets))
+
+	la, err = db.ListAssets(ctx, scope1, qa)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(la.Assets))
+
+	_, err = db.FavouriteAsset(ctx, scope2, 1, asset.ID, domain.AudienceAssetType, true)
+	assert.Error(t, err)
+
+	err = db.DeleteAsset(ctx, scope2, domain.AudienceAssetType, asset.ID)
+	assert.NoError(t, err)
+	_, err = db.GetAsset(ctx, scope1, domain.AudienceAssetType, asset.ID)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,112 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// similarityBatchSize is the number of similarities that are inserted at once.
+const similarityBatchSize = 500
+
+// ListFavourites returns the assets of every type that the user favours.
+func (d *DB) ListFavourites(ctx context.Context, userID uint) ([]domain.FavouriteItem, error) {
+	favourites, err := d.listFavourites(d.db.Where("user_id = ?", userID))
+	if err != nil {
+		return nil, err
+	}
+	items := []domain.FavouriteItem{}
+	for _, v := range favourites {
+		items = append(items, v.FavouriteItem)
+	}
+	return items, nil
+}
+
+// ListAllFavourites returns the favourites of all the users.
+func (d *DB) ListAllFavourites(ctx context.Context) ([]domain.UserFavourite, error) {
+	return d.listFavourites(d.db)
+}
+
+func (d *DB) listFavourites(query *gorm.DB) ([]domain.UserFavourite, error) {
+	favourites := []domain.UserFavourite{}
+	for _, at := range []domain.AssetType{domain.InsightAssetType, domain.ChartAssetType, domain.AudienceAssetType} {
+		_, column, _ := favouriteModel(at, 0, 0)
+		rows := []struct {
+			UserID  uint
+			AssetID uint
+		}{}
+		err := query.Session(&gorm.Session{}).Table("favourite_" + string(at)).
+			Select("user_id, " + column + " AS asset_id").Where("deleted_at IS NULL").Order("id").Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range rows {
+			favourites = append(favourites, domain.UserFavourite{
+				UserID:        v.UserID,
+				FavouriteItem: domain.FavouriteItem{AssetType: at, AssetID: v.AssetID},
+			})
+		}
+	}
+	return favourites, nil
+}
+
+// ListLiveAudiences returns the published audiences of all the organizations that are not expired.
+func (d *DB) ListLiveAudiences(ctx context.Context) ([]domain.Asset, error) {
+	condition, args := liveCondition("audiences", time.Now())
+	aus := []Audience{}
+	err := d.db.Where(condition, args...).Order("id").Find(&aus).Error
+	if err != nil {
+		return nil, err
+	}
+	return listRowsToAssets(aus), nil
+}
+
+// ReplaceAssetSimilarities replaces all the similarities in a transaction, so they are never read half refreshed.
+func (d *DB) ReplaceAssetSimilarities(ctx context.Context, similarities []domain.AssetSimilarity) error {
+	rows := []AssetSimilarity{}
+	for i := range similarities {
+		row := AssetSimilarity{}
+		row.FromDomain(&similarities[i])
+		rows = append(rows, row)
+	}
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("1 = 1").Delete(&AssetSimilarity{}).Error
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(rows, similarityBatchSize).Error
+	})
+}
+
+// ListAssetSimilarities returns the similarities of the assets to their related assets.
+func (d *DB) ListAssetSimilarities(ctx context.Context, items []domain.FavouriteItem) ([]domain.AssetSimilarity, error) {
+	ids := map[domain.AssetType][]uint{}
+	for _, v := range items {
+		ids[v.AssetType] = append(ids[v.AssetType], v.AssetID)
+	}
+	query := d.db.Where("1 = 0")
+	for at, assetIDs := range ids {
+		query = query.Or("asset_type = ? AND asset_id IN ?", string(at), assetIDs)
+	}
+	rows := []AssetSimilarity{}
+	err := d.db.Where(query).Order("score desc, id").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	similarities := []domain.AssetSimilarity{}
+	for _, v := range rows {
+		similarities = append(similarities, *v.ToDomain())
+	}
+	return similarities, nil
+}
+
+// RemoveAssetSimilarities removes the similarities from and to the asset.
+func (d *DB) RemoveAssetSimilarities(ctx context.Context, at domain.AssetType, assetID uint) error {
+	return d.db.Unscoped().Where("(asset_type = ? AND asset_id = ?) OR (related_type = ? AND related_id = ?)",
+		string(at), assetID, string(at), assetID).Delete(&AssetSimilarity{}).Error
+}
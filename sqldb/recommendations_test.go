@@ -0,0 +1,85 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetSimilarities(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	user, err := db.AddUser(ctx, domain.User{Username: "manos", Password: "hashed"})
+	assert.NoError(t, err)
+	insight, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+		Data: &domain.Insight{Text: "40% of millenials", Description: "bla bla"}})
+	assert.NoError(t, err)
+	chart, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+		Data: &domain.Chart{Title: "title", XTitle: "x", YTitle: "y", Description: "bla bla",
+			Data: domain.XYData{X: []float64{1, 2}, Y: []float64{1, 2}}}})
+	assert.NoError(t, err)
+	_, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, insight.ID, domain.InsightAssetType, true)
+	assert.NoError(t, err)
+	_, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, chart.ID, domain.ChartAssetType, true)
+	assert.NoError(t, err)
+
+	favourites, err := db.ListAllFavourites(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(favourites))
+	items, err := db.ListFavourites(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.FavouriteItem{
+		{AssetType: domain.InsightAssetType, AssetID: insight.ID},
+		{AssetType: domain.ChartAssetType, AssetID: chart.ID},
+	}, items)
+
+	err = db.ReplaceAssetSimilarities(ctx, []domain.AssetSimilarity{
+		{AssetType: domain.InsightAssetType, AssetID: insight.ID, RelatedType: domain.ChartAssetType, RelatedID: chart.ID, Score: 1},
+		{AssetType: domain.ChartAssetType, AssetID: chart.ID, RelatedType: domain.InsightAssetType, RelatedID: insight.ID, Score: 1},
+	})
+	assert.NoError(t, err)
+	similarities, err := db.ListAssetSimilarities(ctx, []domain.FavouriteItem{{AssetType: domain.InsightAssetType, AssetID: insight.ID}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(similarities))
+	assert.Equal(t, chart.ID, similarities[0].RelatedID)
+
+	// a refresh replaces all the similarities
+	err = db.ReplaceAssetSimilarities(ctx, []domain.AssetSimilarity{
+		{AssetType: domain.ChartAssetType, AssetID: chart.ID, RelatedType: domain.InsightAssetType, RelatedID: insight.ID, Score: 0.5},
+	})
+	assert.NoError(t, err)
+	similarities, err = db.ListAssetSimilarities(ctx, []domain.FavouriteItem{{AssetType: domain.InsightAssetType, AssetID: insight.ID}})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(similarities))
+
+	err = db.RemoveAssetSimilarities(ctx, domain.InsightAssetType, insight.ID)
+	assert.NoError(t, err)
+	similarities, err = db.ListAssetSimilarities(ctx, []domain.FavouriteItem{{AssetType: domain.ChartAssetType, AssetID: chart.ID}})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(similarities))
+}
+
+func TestListVisibleAssets(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	published, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+		Data: &domain.Insight{Text: "40% of millenials", Description: "bla bla"}})
+	assert.NoError(t, err)
+	draft, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+		Data: &domain.Insight{Text: "30% of millenials", Description: "bla bla"}})
+	assert.NoError(t, err)
+	err = db.SetAssetStatus(ctx, domain.InsightAssetType, draft.ID, domain.DraftStatus, 0)
+	assert.NoError(t, err)
+
+	assets, err := db.ListVisibleAssets(ctx, domain.AssetScope{UserID: 1}, domain.InsightAssetType, []uint{published.ID, draft.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(assets))
+	assert.Equal(t, published.ID, assets[0].ID)
+	assets, err = db.ListVisibleAssets(ctx, domain.AssetScope{UserID: 1, IsAdmin: true}, domain.InsightAssetType, []uint{published.ID, draft.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(assets))
+}
@@ -2,11 +2,61 @@ package sqldb
 
 import (
 	"encoding/json"
+	"math"
 	"platform-go-challenge/domain"
+	"time"
 )
 
+func (m *AssetModel) ToAsset(data interface{}) *domain.Asset {
+	return &domain.Asset{
+		ID:         m.ID,
+		OrgID:      m.OrganizationID,
+		Visibility: domain.Visibility(m.Visibility),
+		Version:    m.Version,
+		Status:     domain.AssetStatus(m.Status),
+		AuthorID:   m.AuthorID,
+		EditorID:   m.EditorID,
+		ApprovedBy: m.ApprovedBy,
+		PublishAt:  m.PublishAt,
+		ExpireAt:   m.ExpireAt,
+		Locale:     m.Locale,
+		Data:       data,
+
+		FavouriteCount: m.FavouriteCount,
+		Feedback:       m.feedback(),
+	}
+}
+
+func (m *AssetModel) feedback() domain.AssetFeedback {
+	feedback := domain.AssetFeedback{
+		RatingCount:    m.RatingCount,
+		OutdatedFlags:  m.OutdatedFlags,
+		IncorrectFlags: m.IncorrectFlags,
+	}
+	if m.RatingCount > 0 {
+		feedback.RatingAverage = math.Round(float64(m.RatingSum)/float64(m.RatingCount)*100) / 100
+	}
+	return feedback
+}
+
+// chartData is the JSON of the data column of the charts.
+// The rows of the first charts only have the x and y arrays of a single series.
+type chartData struct {
+	X      []float64       `json:"x"`
+	Y      []float64       `json:"y"`
+	Kind   string          `json:"kind,omitempty"`
+	XAxis  string          `json:"xAxis,omitempty"`
+	Series []domain.Series `json:"series,omitempty"`
+}
+
 func (c *Chart) FromDomain(asset *domain.Chart) {
-	dataJson, _ := json.Marshal(asset.Data)
+	dataJson, _ := json.Marshal(chartData{
+		X:      asset.Data.X,
+		Y:      asset.Data.Y,
+		Kind:   string(asset.Kind),
+		XAxis:  string(asset.XAxis),
+		Series: asset.Series,
+	})
 	c.Title = asset.Title
 	c.XTitle = asset.XTitle
 	c.YTitle = asset.YTitle
@@ -21,9 +71,13 @@ func (c *Chart) ToDomain() *domain.Chart {
 		YTitle:      c.YTitle,
 		Description: c.Description,
 	}
-	data := domain.XYData{}
+	data := chartData{}
 	json.Unmarshal(c.Data, &data)
-	asset.Data = data
+	asset.Data = domain.XYData{X: data.X, Y: data.Y}
+	asset.Kind = domain.ChartKind(data.Kind)
+	asset.XAxis = domain.AxisType(data.XAxis)
+	asset.Series = data.Series
+	asset.SetDefaults()
 	return &asset
 }
 
@@ -47,7 +101,27 @@ func (in *Insight) GetID() uint {
 	return in.ID
 }
 
+// audienceSegment is the JSON of the segment column of the audiences.
+type audienceSegment struct {
+	Genders                []domain.GenderType `json:"genders,omitempty"`
+	Countries              []string            `json:"countries,omitempty"`
+	Regions                []string            `json:"regions,omitempty"`
+	HoursSpentRange        *domain.IntRange    `json:"hoursSpentRange,omitempty"`
+	NumberOfPurchasesRange *domain.IntRange    `json:"numberOfPurchasesRange,omitempty"`
+	Interests              []string            `json:"interests,omitempty"`
+	Devices                []domain.DeviceType `json:"devices,omitempty"`
+}
+
 func (au *Audience) FromDomain(asset *domain.Audience) {
+	segmentJson, _ := json.Marshal(audienceSegment{
+		Genders:                asset.Genders,
+		Countries:              asset.Countries,
+		Regions:                asset.Regions,
+		HoursSpentRange:        asset.HoursSpentRange,
+		NumberOfPurchasesRange: asset.NumberOfPurchasesRange,
+		Interests:              asset.Interests,
+		Devices:                asset.Devices,
+	})
 	au.AgeMax = asset.AgeMax
 	au.AgeMin = asset.AgeMin
 	au.Gender = string(asset.Gender)
@@ -55,17 +129,27 @@ func (au *Audience) FromDomain(asset *domain.Audience) {
 	au.HoursSpent = asset.HoursSpent
 	au.NumberOfPurchases = asset.NumberOfPurchases
 	au.Description = asset.Description
+	au.Segment = segmentJson
 }
 
 func (au *Audience) ToDomain() *domain.Audience {
+	segment := audienceSegment{}
+	json.Unmarshal(au.Segment, &segment)
 	return &domain.Audience{
-		AgeMax:            au.AgeMax,
-		AgeMin:            au.AgeMin,
-		Gender:            domain.GenderType(au.Gender),
-		Country:           au.Country,
-		HoursSpent:        au.HoursSpent,
-		NumberOfPurchases: au.NumberOfPurchases,
-		Description:       au.Description,
+		AgeMax:                 au.AgeMax,
+		AgeMin:                 au.AgeMin,
+		Gender:                 domain.GenderType(au.Gender),
+		Genders:                segment.Genders,
+		Country:                au.Country,
+		Countries:              segment.Countries,
+		Regions:                segment.Regions,
+		HoursSpent:             au.HoursSpent,
+		HoursSpentRange:        segment.HoursSpentRange,
+		NumberOfPurchases:      au.NumberOfPurchases,
+		NumberOfPurchasesRange: segment.NumberOfPurchasesRange,
+		Interests:              segment.Interests,
+		Devices:                segment.Devices,
+		Description:            au.Description,
 	}
 }
 
@@ -92,36 +176,180 @@ func (u *User) ToDomain() *domain.User {
 	}
 }
 
+func toFavouriteNote(text string, updatedAt *time.Time) *domain.FavouriteNote {
+	if updatedAt == nil || text == "" {
+		return nil
+	}
+	return &domain.FavouriteNote{
+		Text:      text,
+		UpdatedAt: *updatedAt,
+	}
+}
+
 func listRowsToAssets(rows interface{}) []domain.Asset {
 	assets := []domain.Asset{}
 	switch ls := rows.(type) {
 	case []Insight:
 		for _, v := range ls {
-			assets = append(assets, domain.Asset{ID: v.GetID(), Data: v.ToDomain()})
+			assets = append(assets, *v.ToAsset(v.ToDomain()))
 		}
 	case []Chart:
 		for _, v := range ls {
-			assets = append(assets, domain.Asset{ID: v.GetID(), Data: v.ToDomain()})
+			assets = append(assets, *v.ToAsset(v.ToDomain()))
 		}
 	case []Audience:
 		for _, v := range ls {
-			assets = append(assets, domain.Asset{ID: v.GetID(), Data: v.ToDomain()})
+			assets = append(assets, *v.ToAsset(v.ToDomain()))
 		}
 	case []AudienceWithFavour:
 		for _, v := range ls {
 			favor := v.IsFavour
-			assets = append(assets, domain.Asset{ID: v.GetID(), Data: v.ToDomain(), IsFavourite: &favor})
+			asset := v.ToAsset(v.ToDomain())
+			asset.IsFavourite = &favor
+			asset.Note = toFavouriteNote(v.Note, v.NoteUpdatedAt)
+			assets = append(assets, *asset)
 		}
 	case []InsightWithFavour:
 		for _, v := range ls {
 			favor := v.IsFavour
-			assets = append(assets, domain.Asset{ID: v.GetID(), Data: v.ToDomain(), IsFavourite: &favor})
+			asset := v.ToAsset(v.ToDomain())
+			asset.IsFavourite = &favor
+			asset.Note = toFavouriteNote(v.Note, v.NoteUpdatedAt)
+			assets = append(assets, *asset)
 		}
 	case []ChartWithFavour:
 		for _, v := range ls {
 			favor := v.IsFavour
-			assets = append(assets, domain.Asset{ID: v.GetID(), Data: v.ToDomain(), IsFavourite: &favor})
+			asset := v.ToAsset(v.ToDomain())
+			asset.IsFavourite = &favor
+			asset.Note = toFavouriteNote(v.Note, v.NoteUpdatedAt)
+			assets = append(assets, *asset)
 		}
 	}
 	return assets
 }
+
+func (o *Organization) FromDomain(org *domain.Organization) {
+	o.Name = org.Name
+}
+
+func (o *Organization) ToDomain() *domain.Organization {
+	return &domain.Organization{
+		ID:   o.ID,
+		Name: o.Name,
+	}
+}
+
+func (g *UserGroup) FromDomain(group *domain.Group) {
+	g.OrganizationID = group.OrgID
+	g.Name = group.Name
+}
+
+func (g *UserGroup) ToDomain() *domain.Group {
+	return &domain.Group{
+		ID:    g.ID,
+		OrgID: g.OrganizationID,
+		Name:  g.Name,
+	}
+}
+
+func (ag *AssetGrant) ToDomain() *domain.AssetGrant {
+	return &domain.AssetGrant{
+		ID:      ag.ID,
+		UserID:  ag.UserID,
+		GroupID: ag.UserGroupID,
+	}
+}
+
+func (c *Collection) FromDomain(collection *domain.Collection) {
+	c.Name = collection.Name
+}
+
+func (c *Collection) ToDomain() *domain.Collection {
+	return &domain.Collection{
+		ID:   c.ID,
+		Name: c.Name,
+	}
+}
+
+func (ci *CollectionItem) ToDomain() *domain.CollectionItem {
+	return &domain.CollectionItem{
+		ID:        ci.ID,
+		AssetType: domain.AssetType(ci.AssetType),
+		AssetID:   ci.AssetID,
+		Position:  ci.Position,
+	}
+}
+
+func (t *Tag) FromDomain(tag *domain.Tag) {
+	t.Name = tag.Name
+}
+
+func (t *Tag) ToDomain() *domain.Tag {
+	return &domain.Tag{
+		ID:   t.ID,
+		Name: t.Name,
+	}
+}
+
+func (l *AssetLink) FromDomain(link *domain.AssetLink) {
+	l.Relation = string(link.Relation)
+	l.FromType = string(link.FromType)
+	l.FromID = link.FromID
+	l.ToType = string(link.ToType)
+	l.ToID = link.ToID
+}
+
+func (l *AssetLink) ToDomain() *domain.AssetLink {
+	return &domain.AssetLink{
+		ID:       l.ID,
+		Relation: domain.RelationType(l.Relation),
+		FromType: domain.AssetType(l.FromType),
+		FromID:   l.FromID,
+		ToType:   domain.AssetType(l.ToType),
+		ToID:     l.ToID,
+	}
+}
+
+func (t *AssetTranslation) FromDomain(tr *domain.AssetTranslation) {
+	t.AssetType = string(tr.AssetType)
+	t.AssetID = tr.AssetID
+	t.Locale = tr.Locale
+	t.Text = tr.Text
+	t.Description = tr.Description
+	t.Title = tr.Title
+	t.XTitle = tr.XTitle
+	t.YTitle = tr.YTitle
+}
+
+func (t *AssetTranslation) ToDomain() *domain.AssetTranslation {
+	return &domain.AssetTranslation{
+		ID:          t.ID,
+		AssetType:   domain.AssetType(t.AssetType),
+		AssetID:     t.AssetID,
+		Locale:      t.Locale,
+		Text:        t.Text,
+		Description: t.Description,
+		Title:       t.Title,
+		XTitle:      t.XTitle,
+		YTitle:      t.YTitle,
+	}
+}
+
+func (a *AssetSimilarity) FromDomain(sim *domain.AssetSimilarity) {
+	a.AssetType = string(sim.AssetType)
+	a.AssetID = sim.AssetID
+	a.RelatedType = string(sim.RelatedType)
+	a.RelatedID = sim.RelatedID
+	a.Score = sim.Score
+}
+
+func (a *AssetSimilarity) ToDomain() *domain.AssetSimilarity {
+	return &domain.AssetSimilarity{
+		AssetType:   domain.AssetType(a.AssetType),
+		AssetID:     a.AssetID,
+		RelatedType: domain.AssetType(a.RelatedType),
+		RelatedID:   a.RelatedID,
+		Score:       a.Score,
+	}
+}
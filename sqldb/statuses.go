@@ -0,0 +1,70 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"platform-go-challenge/domain"
+	"time"
+)
+
+// assetModel returns the model of the table of the asset type.
+func assetModel(at domain.AssetType) (interface{}, error) {
+	switch at {
+	case domain.InsightAssetType:
+		return &Insight{}, nil
+	case domain.ChartAssetType:
+		return &Chart{}, nil
+	case domain.AudienceAssetType:
+		return &Audience{}, nil
+	}
+	return nil, ErrThisAssetTypeDoesNotExist
+}
+
+// SetAssetStatus moves the asset to the status without changing its version.
+func (d *DB) SetAssetStatus(ctx context.Context, at domain.AssetType, assetID uint, status domain.AssetStatus, approvedBy uint) error {
+	model, err := assetModel(at)
+	if err != nil {
+		return fmt.Errorf("SetAssetStatus: %w", err)
+	}
+	return d.db.Model(model).Where("id = ?", assetID).Updates(map[string]interface{}{
+		"status":      string(status),
+		"approved_by": approvedBy,
+	}).Error
+}
+
+// SetAssetEditor records the user that changed the content of the asset last, without a new version.
+func (d *DB) SetAssetEditor(ctx context.Context, at domain.AssetType, assetID uint, editorID uint) error {
+	model, err := assetModel(at)
+	if err != nil {
+		return fmt.Errorf("SetAssetEditor: %w", err)
+	}
+	return d.db.Model(model).Where("id = ?", assetID).Update("editor_id", editorID).Error
+}
+
+// PublishScheduledAssets publishes the scheduled assets of every type with a publishAt time that has come.
+func (d *DB) PublishScheduledAssets(ctx context.Context, now time.Time) (int64, error) {
+	count := int64(0)
+	for _, model := range []interface{}{&Insight{}, &Chart{}, &Audience{}} {
+		res := d.db.Model(model).Where("status = ? AND publish_at <= ?", domain.ScheduledStatus, now).
+			Update("status", string(domain.PublishedStatus))
+		if res.Error != nil {
+			return count, res.Error
+		}
+		count += res.RowsAffected
+	}
+	return count, nil
+}
+
+// ArchiveExpiredAssets archives the published assets of every type with an expireAt time that has passed.
+func (d *DB) ArchiveExpiredAssets(ctx context.Context, now time.Time) (int64, error) {
+	count := int64(0)
+	for _, model := range []interface{}{&Insight{}, &Chart{}, &Audience{}} {
+		res := d.db.Model(model).Where("status = ? AND expire_at <= ?", domain.PublishedStatus, now).
+			Update("status", string(domain.ArchivedStatus))
+		if res.Error != nil {
+			return count, res.Error
+		}
+		count += res.RowsAffected
+	}
+	return count, nil
+}
@@ -0,0 +1,104 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetStatus(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	admin := domain.AssetScope{UserID: 1, IsAdmin: true}
+	user := domain.AssetScope{UserID: 2}
+	asset, err := db.AddAsset(ctx, admin, domain.InputAsset{
+		Data: &domain.Insight{
+			Text:        "40% of millenials spend more than 3hours on social media daily",
+			Description: "bla bla",
+		},
+		Visibility: domain.OrganizationVisibility,
+		Status:     domain.DraftStatus,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DraftStatus, asset.Status)
+	assert.Equal(t, uint(1), asset.AuthorID)
+
+	_, err = db.GetAsset(ctx, user, domain.InsightAssetType, asset.ID)
+	assert.Error(t, err)
+	la, err := db.ListAssets(ctx, user, domain.QueryAssets{Limit: 10, Type: domain.InsightAssetType})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(la.Assets))
+	la, err = db.ListAssets(ctx, admin, domain.QueryAssets{Limit: 10, Type: domain.InsightAssetType})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(la.Assets))
+
+	err = db.SetAssetStatus(ctx, domain.InsightAssetType, asset.ID, domain.PublishedStatus, 3)
+	assert.NoError(t, err)
+	gotten, err := db.GetAsset(ctx, user, domain.InsightAssetType, asset.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PublishedStatus, gotten.Status)
+	assert.Equal(t, uint(3), gotten.ApprovedBy)
+	assert.Equal(t, asset.Version, gotten.Version)
+}
+
+func TestScheduledAssets(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	admin := domain.AssetScope{UserID: 1, IsAdmin: true}
+	user := domain.AssetScope{UserID: 2}
+	now := time.Now()
+	publishAt := now.Add(-time.Hour)
+	expireAt := now.Add(-time.Minute)
+	scheduled, err := db.AddAsset(ctx, admin, domain.InputAsset{
+		Data: &domain.Insight{
+			Text:        "campaign",
+			Description: "bla bla",
+		},
+		Visibility: domain.OrganizationVisibility,
+		Status:     domain.ScheduledStatus,
+		PublishAt:  &publishAt,
+	})
+	assert.NoError(t, err)
+	expired, err := db.AddAsset(ctx, admin, domain.InputAsset{
+		Data: &domain.Insight{
+			Text:        "old campaign",
+			Description: "bla bla",
+		},
+		Visibility: domain.OrganizationVisibility,
+		Status:     domain.PublishedStatus,
+		ExpireAt:   &expireAt,
+	})
+	assert.NoError(t, err)
+	_, err = db.FavouriteAsset(ctx, user, 2, expired.ID, domain.InsightAssetType, true)
+	assert.Error(t, err)
+
+	published, err := db.PublishScheduledAssets(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), published)
+	archived, err := db.ArchiveExpiredAssets(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), archived)
+
+	gotten, err := db.GetAsset(ctx, user, domain.InsightAssetType, scheduled.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PublishedStatus, gotten.Status)
+	la, err := db.ListAssets(ctx, user, domain.QueryAssets{Limit: 10, Type: domain.InsightAssetType})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(la.Assets))
+
+	err = db.db.Create(&FavouriteInsight{UserID: 2, InsightID: expired.ID}).Error
+	assert.NoError(t, err)
+	la, err = db.ListFavouriteAssets(ctx, user, 2, true, domain.QueryAssets{Limit: 10, Type: domain.InsightAssetType})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(la.Assets))
+	assert.Equal(t, expired.ID, la.Assets[0].ID)
+	la, err = db.ListFavouriteAssets(ctx, user, 2, false, domain.QueryAssets{Limit: 10, Type: domain.InsightAssetType})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(la.Assets))
+	assert.Equal(t, scheduled.ID, la.Assets[0].ID)
+}
@@ -0,0 +1,159 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+func (d *DB) AddTag(ctx context.Context, orgID uint, tag domain.Tag) (*domain.Tag, error) {
+	t := &Tag{OrganizationID: orgID}
+	t.FromDomain(&tag)
+	err := d.db.Create(t).Error
+	if err != nil {
+		return nil, err
+	}
+	return t.ToDomain(), nil
+}
+
+func (d *DB) GetTag(ctx context.Context, orgID, tagID uint) (*domain.Tag, error) {
+	t := Tag{}
+	err := d.db.Where("organization_id = ? ", orgID).First(&t, tagID).Error
+	if err != nil {
+		return nil, err
+	}
+	return t.ToDomain(), nil
+}
+
+func (d *DB) UpdateTag(ctx context.Context, orgID, tagID uint, tag domain.Tag) (*domain.Tag, error) {
+	t := &Tag{}
+	err := d.db.Where("organization_id = ? ", orgID).First(t, tagID).Error
+	if err != nil {
+		return nil, err
+	}
+	t.FromDomain(&tag)
+	err = d.db.Save(t).Error
+	if err != nil {
+		return nil, err
+	}
+	return t.ToDomain(), nil
+}
+
+// MergeTags moves the assets of the first tag to the second one, skipping the assets
+// that already have the second tag, and deletes the first tag.
+func (d *DB) MergeTags(ctx context.Context, orgID, fromTagID, intoTagID uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&Tag{}).Where("organization_id = ? AND id IN ?", orgID, []uint{fromTagID, intoTagID}).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count != 2 {
+			return gorm.ErrRecordNotFound
+		}
+		err = tx.Exec("UPDATE asset_tags SET tag_id = ? WHERE tag_id = ? AND NOT EXISTS "+
+			"(SELECT 1 FROM (SELECT asset_type, asset_id FROM asset_tags WHERE tag_id = ?) AS merged "+
+			"WHERE merged.asset_type = asset_tags.asset_type AND merged.asset_id = asset_tags.asset_id)",
+			intoTagID, fromTagID, intoTagID).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Unscoped().Where("tag_id = ? ", fromTagID).Delete(&AssetTag{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&Tag{}, fromTagID).Error
+	})
+}
+
+func (d *DB) DeleteTag(ctx context.Context, orgID, tagID uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Unscoped().Where("organization_id = ? ", orgID).Delete(&Tag{}, tagID)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Unscoped().Where("tag_id = ? ", tagID).Delete(&AssetTag{}).Error
+	})
+}
+
+func (d *DB) SetAssetTags(ctx context.Context, at domain.AssetType, assetID uint, tagIDs []uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("asset_type = ? AND asset_id = ? ", string(at), assetID).Delete(&AssetTag{}).Error
+		if err != nil {
+			return err
+		}
+		if len(tagIDs) == 0 {
+			return nil
+		}
+		ats := []AssetTag{}
+		for _, id := range tagIDs {
+			ats = append(ats, AssetTag{TagID: id, AssetType: string(at), AssetID: assetID})
+		}
+		return tx.Create(&ats).Error
+	})
+}
+
+// ListTags returns the tags of the organization of the scope with the number of their assets
+// of the type, or of any type without type, that the user of the scope sees.
+func (d *DB) ListTags(ctx context.Context, scope domain.AssetScope, at domain.AssetType) ([]domain.Tag, error) {
+	types := []domain.AssetType{domain.InsightAssetType, domain.ChartAssetType, domain.AudienceAssetType}
+	if at != "" {
+		types = []domain.AssetType{at}
+	}
+	conditions := []string{}
+	args := []interface{}{}
+	for _, t := range types {
+		table := string(t)
+		visible := visibleScope(table, scope)(d.db.Table(table).Select(table + ".id").Where(table + ".deleted_at IS NULL"))
+		conditions = append(conditions, "(asset_tags.asset_type = ? AND asset_tags.asset_id IN (?))")
+		args = append(args, table, visible)
+	}
+	tags := []domain.Tag{}
+	err := d.db.Model(&Tag{}).Select("tags.id, tags.name, COUNT(asset_tags.id) AS count").
+		Joins("LEFT JOIN asset_tags ON asset_tags.tag_id = tags.id AND ("+strings.Join(conditions, " OR ")+")", args...).
+		Where("tags.organization_id = ? ", scope.OrgID).Group("tags.id, tags.name").Order("tags.name asc").Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (d *DB) RemoveAssetTags(ctx context.Context, at domain.AssetType, assetID uint) error {
+	return d.db.Unscoped().Where("asset_type = ? AND asset_id = ? ", string(at), assetID).Delete(&AssetTag{}).Error
+}
+
+// attachTags sets the names of their tags to the assets.
+func (d *DB) attachTags(at domain.AssetType, assets []domain.Asset) error {
+	if len(assets) == 0 {
+		return nil
+	}
+	ids := []uint{}
+	for _, v := range assets {
+		ids = append(ids, v.ID)
+	}
+	type assetTagName struct {
+		AssetID uint
+		Name    string
+	}
+	var rows []assetTagName
+	err := d.db.Model(&AssetTag{}).Select("asset_tags.asset_id, tags.name").
+		Joins("INNER JOIN tags ON tags.id = asset_tags.tag_id").
+		Where("asset_tags.asset_type = ? AND asset_tags.asset_id IN ?", string(at), ids).
+		Order("tags.name asc").Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+	byAsset := map[uint][]string{}
+	for _, v := range rows {
+		byAsset[v.AssetID] = append(byAsset[v.AssetID], v.Name)
+	}
+	for i := range assets {
+		assets[i].Tags = byAsset[assets[i].ID]
+	}
+	return nil
+}
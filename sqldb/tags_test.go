@@ -0,0 +1,84 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTags(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	scope := domain.AssetScope{UserID: 1}
+	retail, err := db.AddTag(ctx, 0, domain.Tag{Name: "retail"})
+	assert.NoError(t, err)
+	social, err := db.AddTag(ctx, 0, domain.Tag{Name: "social"})
+	assert.NoError(t, err)
+	shops, err := db.AddTag(ctx, 0, domain.Tag{Name: "shops"})
+	assert.NoError(t, err)
+	_, err = db.AddTag(ctx, 0, domain.Tag{Name: "retail"})
+	assert.Error(t, err)
+
+	tagIDs := [][]uint{{retail.ID, social.ID}, {social.ID}, {shops.ID, retail.ID}}
+	for _, ids := range tagIDs {
+		asset, err := db.AddAsset(ctx, scope, domain.InputAsset{
+			Visibility: domain.OrganizationVisibility,
+			Data: &domain.Insight{
+				Text:        "40% of millenials spend more than 3hours on social media daily",
+				Description: "example",
+			}})
+		assert.NoError(t, err)
+		err = db.SetAssetTags(ctx, domain.InsightAssetType, asset.ID, ids)
+		assert.NoError(t, err)
+	}
+
+	asset, err := db.GetAsset(ctx, scope, domain.InsightAssetType, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"retail", "social"}, asset.Tags)
+
+	qa := domain.QueryAssets{
+		Limit: 10,
+		Type:  domain.InsightAssetType,
+		Tags:  []string{"retail", "social"},
+	}
+	la, err := db.ListAssets(ctx, scope, qa)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(la.Assets))
+	qa.Tags = []string{"social"}
+	la, err = db.ListFavouriteAssets(ctx, scope, 1, false, qa)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(la.Assets))
+
+	err = db.MergeTags(ctx, 0, shops.ID, retail.ID)
+	assert.NoError(t, err)
+	tags, err := db.ListTags(ctx, scope, domain.InsightAssetType)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(tags))
+	assert.Equal(t, "retail", tags[0].Name)
+	assert.Equal(t, int64(2), tags[0].Count)
+
+	_, err = db.UpdateTag(ctx, 0, social.ID, domain.Tag{Name: "social media"})
+	assert.NoError(t, err)
+	err = db.RemoveAssetTags(ctx, domain.InsightAssetType, 1)
+	assert.NoError(t, err)
+	draft, err := db.AddAsset(ctx, scope, domain.InputAsset{
+		Visibility: domain.OrganizationVisibility,
+		Status:     domain.DraftStatus,
+		Data:       &domain.Insight{Text: "draft"},
+	})
+	assert.NoError(t, err)
+	err = db.SetAssetTags(ctx, domain.InsightAssetType, draft.ID, []uint{retail.ID})
+	assert.NoError(t, err)
+	tags, err = db.ListTags(ctx, scope, "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), tags[0].Count)
+	assert.Equal(t, "social media", tags[1].Name)
+	assert.Equal(t, int64(1), tags[1].Count)
+	// the admins of the organization see its drafts
+	tags, err = db.ListTags(ctx, domain.AssetScope{UserID: 2, IsAdmin: true}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), tags[0].Count)
+}
@@ -0,0 +1,78 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"platform-go-challenge/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// bumpAssetVersion increases the version of the asset, so that the cached renderings of its texts are not used.
+func bumpAssetVersion(tx *gorm.DB, at domain.AssetType, assetID uint) error {
+	model, err := assetModel(at)
+	if err != nil {
+		return err
+	}
+	return tx.Model(model).Where("id = ?", assetID).Update("version", gorm.Expr("version + 1")).Error
+}
+
+// SetAssetTranslation adds the translation of the asset to its locale or replaces the existing one.
+func (d *DB) SetAssetTranslation(ctx context.Context, translation domain.AssetTranslation) (*domain.AssetTranslation, error) {
+	t := &AssetTranslation{}
+	t.FromDomain(&translation)
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "asset_type"}, {Name: "asset_id"}, {Name: "locale"}},
+			DoUpdates: clause.AssignmentColumns([]string{"text", "description", "title", "x_title", "y_title", "updated_at"}),
+		}).Create(t).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("asset_type = ? AND asset_id = ? AND locale = ?", t.AssetType, t.AssetID, t.Locale).First(t).Error
+		if err != nil {
+			return err
+		}
+		return bumpAssetVersion(tx, translation.AssetType, translation.AssetID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("SetAssetTranslation: %w", err)
+	}
+	return t.ToDomain(), nil
+}
+
+// RemoveAssetTranslation removes the translation of the asset to the locale and reports if it existed.
+func (d *DB) RemoveAssetTranslation(ctx context.Context, at domain.AssetType, assetID uint, locale string) (bool, error) {
+	found := false
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Unscoped().Where("asset_type = ? AND asset_id = ? AND locale = ?", string(at), assetID, locale).Delete(&AssetTranslation{})
+		if res.Error != nil {
+			return res.Error
+		}
+		found = res.RowsAffected > 0
+		if !found {
+			return nil
+		}
+		return bumpAssetVersion(tx, at, assetID)
+	})
+	return found, err
+}
+
+// ListAssetTranslations returns the translations of the assets of the type, ordered by asset and locale.
+func (d *DB) ListAssetTranslations(ctx context.Context, at domain.AssetType, assetIDs []uint) ([]domain.AssetTranslation, error) {
+	var ts []AssetTranslation
+	err := d.db.Where("asset_type = ? AND asset_id IN ?", string(at), assetIDs).Order("asset_id asc, locale asc").Find(&ts).Error
+	if err != nil {
+		return nil, err
+	}
+	translations := []domain.AssetTranslation{}
+	for _, v := range ts {
+		translations = append(translations, *v.ToDomain())
+	}
+	return translations, nil
+}
+
+func (d *DB) RemoveAssetTranslations(ctx context.Context, at domain.AssetType, assetID uint) error {
+	return d.db.Unscoped().Where("asset_type = ? AND asset_id = ?", string(at), assetID).Delete(&AssetTranslation{}).Error
+}
@@ -0,0 +1,61 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetTranslations(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	admin := domain.AssetScope{UserID: 1, IsAdmin: true}
+	asset, err := db.AddAsset(ctx, admin, domain.InputAsset{
+		Data: &domain.Insight{
+			Text:        "40% of millenials spend more than 3hours on social media daily",
+			Description: "bla bla",
+		},
+		Visibility: domain.OrganizationVisibility,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DefaultLocale, asset.Locale)
+
+	tr, err := db.SetAssetTranslation(ctx, domain.AssetTranslation{
+		AssetType: domain.InsightAssetType, AssetID: asset.ID, Locale: "fr", Text: "40% des millenials",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "40% des millenials", tr.Text)
+	tr, err = db.SetAssetTranslation(ctx, domain.AssetTranslation{
+		AssetType: domain.InsightAssetType, AssetID: asset.ID, Locale: "fr", Text: "40% des milléniaux", Description: "bla",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "40% des milléniaux", tr.Text)
+	_, err = db.SetAssetTranslation(ctx, domain.AssetTranslation{
+		AssetType: domain.InsightAssetType, AssetID: asset.ID, Locale: "de", Text: "40% der Millennials",
+	})
+	assert.NoError(t, err)
+
+	trs, err := db.ListAssetTranslations(ctx, domain.InsightAssetType, []uint{asset.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(trs))
+	assert.Equal(t, "de", trs[0].Locale)
+	assert.Equal(t, "bla", trs[1].Description)
+	gotten, err := db.GetAsset(ctx, admin, domain.InsightAssetType, asset.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, asset.Version+3, gotten.Version)
+
+	found, err := db.RemoveAssetTranslation(ctx, domain.InsightAssetType, asset.ID, "de")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	found, err = db.RemoveAssetTranslation(ctx, domain.InsightAssetType, asset.ID, "de")
+	assert.NoError(t, err)
+	assert.False(t, found)
+	err = db.RemoveAssetTranslations(ctx, domain.InsightAssetType, asset.ID)
+	assert.NoError(t, err)
+	trs, err = db.ListAssetTranslations(ctx, domain.InsightAssetType, []uint{asset.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(trs))
+}
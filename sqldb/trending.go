@@ -0,0 +1,96 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"platform-go-challenge/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// gainedFavourites is the number of favourites that an asset gained.
+type gainedFavourites struct {
+	AssetID uint  `gorm:"column:asset_id"`
+	Gained  int64 `gorm:"column:gained"`
+}
+
+// ListTrendingAssets ranks the assets that the user of the scope sees by the favourites they gained since the time.
+// The favourites that were removed do not count, as they are deleted.
+func (d *DB) ListTrendingAssets(ctx context.Context, scope domain.AssetScope, at domain.AssetType, since time.Time, limit int) ([]domain.TrendingAsset, error) {
+	_, column, err := favouriteModel(at, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("ListTrendingAssets: %w", err)
+	}
+	table := string(at)
+	favourites := "favourite_" + table
+	gained := []gainedFavourites{}
+	err = d.db.Table(favourites).
+		Select(favourites+"."+column+" AS asset_id, COUNT(*) AS gained").
+		Joins("INNER JOIN "+table+" ON "+table+".id = "+favourites+"."+column+" AND "+table+".deleted_at IS NULL").
+		Scopes(visibleScope(table, scope)).
+		Where(favourites+".created_at >= ?", since).
+		Group(favourites + "." + column).
+		Order("gained desc, asset_id asc").
+		Limit(limit).
+		Scan(&gained).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(gained) == 0 {
+		return []domain.TrendingAsset{}, nil
+	}
+	ids := make([]uint, len(gained))
+	for i, v := range gained {
+		ids[i] = v.AssetID
+	}
+	assets, err := d.findAssets(at, ids)
+	if err != nil {
+		return nil, err
+	}
+	err = d.attachTags(at, assets)
+	if err != nil {
+		return nil, err
+	}
+	byID := map[uint]domain.Asset{}
+	for _, v := range assets {
+		byID[v.ID] = v
+	}
+	trending := []domain.TrendingAsset{}
+	for _, v := range gained {
+		asset, ok := byID[v.AssetID]
+		if !ok {
+			continue
+		}
+		trending = append(trending, domain.TrendingAsset{Asset: asset, Gained: v.Gained})
+	}
+	return trending, nil
+}
+
+// findAssets returns the assets of the type with the IDs, in the order of their IDs.
+func (d *DB) findAssets(at domain.AssetType, ids []uint, scopes ...func(*gorm.DB) *gorm.DB) ([]domain.Asset, error) {
+	switch at {
+	case domain.InsightAssetType:
+		ins := []Insight{}
+		err := d.db.Scopes(scopes...).Where("id IN ?", ids).Order("id").Find(&ins).Error
+		if err != nil {
+			return nil, err
+		}
+		return listRowsToAssets(ins), nil
+	case domain.ChartAssetType:
+		chs := []Chart{}
+		err := d.db.Scopes(scopes...).Where("id IN ?", ids).Order("id").Find(&chs).Error
+		if err != nil {
+			return nil, err
+		}
+		return listRowsToAssets(chs), nil
+	case domain.AudienceAssetType:
+		aus := []Audience{}
+		err := d.db.Scopes(scopes...).Where("id IN ?", ids).Order("id").Find(&aus).Error
+		if err != nil {
+			return nil, err
+		}
+		return listRowsToAssets(aus), nil
+	}
+	return nil, fmt.Errorf("findAssets: %w", ErrThisAssetTypeDoesNotExist)
+}
@@ -0,0 +1,92 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"platform-go-challenge/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFavouriteCount(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	user, err := db.AddUser(ctx, domain.User{Username: "manos", Password: "hashed"})
+	assert.NoError(t, err)
+	user2, err := db.AddUser(ctx, domain.User{Username: "nikos", Password: "hashed"})
+	assert.NoError(t, err)
+	asset, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+		Data: &domain.Insight{Text: "40% of millenials", Description: "bla bla"}})
+	assert.NoError(t, err)
+
+	for _, userID := range []uint{user.ID, user.ID, user2.ID} {
+		_, err = db.FavouriteAsset(ctx, domain.AssetScope{}, userID, asset.ID, domain.InsightAssetType, true)
+		assert.NoError(t, err)
+	}
+	got, err := db.GetAsset(ctx, domain.AssetScope{}, domain.InsightAssetType, asset.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), got.FavouriteCount)
+
+	// the updates of the asset keep its count
+	_, err = db.UpdateAsset(ctx, domain.AssetScope{}, asset.ID, domain.InputAsset{
+		Data: &domain.Insight{Text: "50% of millenials", Description: "bla bla"}})
+	assert.NoError(t, err)
+	_, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.InsightAssetType, false)
+	assert.NoError(t, err)
+	got, err = db.GetAsset(ctx, domain.AssetScope{}, domain.InsightAssetType, asset.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), got.FavouriteCount)
+
+	err = db.RemoveFavouriteAssetFromEveryone(ctx, asset.ID, domain.InsightAssetType)
+	assert.NoError(t, err)
+	got, err = db.GetAsset(ctx, domain.AssetScope{}, domain.InsightAssetType, asset.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), got.FavouriteCount)
+}
+
+func TestListTrendingAssets(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	users := []*domain.User{}
+	for i := 0; i < 3; i++ {
+		user, err := db.AddUser(ctx, domain.User{Username: fmt.Sprintf("user%d", i), Password: "hashed"})
+		assert.NoError(t, err)
+		users = append(users, user)
+	}
+	charts := []*domain.Asset{}
+	for i := 0; i < 3; i++ {
+		chart, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+			Data: &domain.Chart{Title: "title", XTitle: "x", YTitle: "y", Description: "bla bla",
+				Data: domain.XYData{X: []float64{1, 2}, Y: []float64{1, 2}}}})
+		assert.NoError(t, err)
+		charts = append(charts, chart)
+	}
+	// the first chart is favoured by one user, the second by all of them and the third by none
+	_, err := db.FavouriteAsset(ctx, domain.AssetScope{}, users[0].ID, charts[0].ID, domain.ChartAssetType, true)
+	assert.NoError(t, err)
+	for _, u := range users {
+		_, err = db.FavouriteAsset(ctx, domain.AssetScope{}, u.ID, charts[1].ID, domain.ChartAssetType, true)
+		assert.NoError(t, err)
+	}
+	// an old favourite is out of the window
+	err = db.db.Model(&FavouriteChart{}).Where("user_id = ? AND chart_id = ?", users[2].ID, charts[1].ID).
+		UpdateColumn("created_at", time.Now().Add(-48*time.Hour)).Error
+	assert.NoError(t, err)
+
+	trending, err := db.ListTrendingAssets(ctx, domain.AssetScope{}, domain.ChartAssetType, time.Now().Add(-24*time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(trending))
+	assert.Equal(t, charts[1].ID, trending[0].ID)
+	assert.Equal(t, int64(2), trending[0].Gained)
+	assert.Equal(t, uint(3), trending[0].FavouriteCount)
+	assert.Equal(t, charts[0].ID, trending[1].ID)
+	assert.Equal(t, int64(1), trending[1].Gained)
+
+	trending, err = db.ListTrendingAssets(ctx, domain.AssetScope{}, domain.ChartAssetType, time.Now().Add(-24*time.Hour), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(trending))
+}
@@ -2,6 +2,7 @@ package sqldb
 
 import (
 	"errors"
+	"time"
 
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
@@ -11,14 +12,46 @@ var (
 	ErrThisAssetTypeDoesNotExist = errors.New("this asset type does not exists")
 )
 
-type Insight struct {
+// AssetModel holds the columns that every asset table shares.
+type AssetModel struct {
 	gorm.Model
+	OrganizationID uint   `gorm:"column:organization_id;index"`
+	Visibility     string `gorm:"column:visibility;type:varchar(20);default:organization"`
+	// Version increases on every update of the asset
+	Version uint `gorm:"column:version;default:1"`
+	// Status is published for the assets that were added before the lifecycle of the assets
+	Status   string `gorm:"column:status;type:varchar(20);default:published;index"`
+	AuthorID uint   `gorm:"column:author_id"`
+	// EditorID is the last user that changed the content of the asset
+	EditorID   uint       `gorm:"column:editor_id"`
+	ApprovedBy uint       `gorm:"column:approved_by"`
+	PublishAt  *time.Time `gorm:"column:publish_at;index"`
+	ExpireAt   *time.Time `gorm:"column:expire_at;index"`
+	// Locale is the language of the texts of the asset
+	Locale string `gorm:"column:locale;type:varchar(8);default:en"`
+	// FavouriteCount is kept with the favourites of the asset, the counts are not written by the updates of the asset
+	FavouriteCount uint `gorm:"column:favourite_count;default:0"`
+	// ViewCount counts all the views of the asset, also the views that are removed after the retention
+	ViewCount uint64 `gorm:"column:view_count;default:0"`
+	// RatingCount and RatingSum are kept with the ratings of the asset, the flags with its open flags
+	RatingCount    uint `gorm:"column:rating_count;default:0"`
+	RatingSum      uint `gorm:"column:rating_sum;default:0"`
+	OutdatedFlags  uint `gorm:"column:outdated_flags;default:0"`
+	IncorrectFlags uint `gorm:"column:incorrect_flags;default:0"`
+}
+
+// counterColumns are the columns of the assets that are kept with their favourites, views, ratings and flags,
+// the updates of the assets do not write them.
+var counterColumns = []string{"favourite_count", "view_count", "rating_count", "rating_sum", "outdated_flags", "incorrect_flags"}
+
+type Insight struct {
+	AssetModel
 	Text        string `gorm:"column:text;type:varchar(200)"`
 	Description string `gorm:"column:description;type:varchar(200)"`
 }
 
 type Audience struct {
-	gorm.Model
+	AssetModel
 	AgeMax            int    `gorm:"column:age_max"`
 	AgeMin            int    `gorm:"column:age_min"`
 	Gender            string `gorm:"column:gender"`
@@ -26,25 +59,33 @@ type Audience struct {
 	HoursSpent        int    `gorm:"column:hours_spent"`
 	NumberOfPurchases int    `gorm:"column:purchases"`
 	Description       string `gorm:"column:description"`
+	// Segment holds the lists and the ranges of the audience, it is empty for the first audiences
+	Segment datatypes.JSON `gorm:"column:segment"`
 }
 
 type AudienceWithFavour struct {
 	Audience
-	IsFavour bool `gorm:"column:is_favourite"`
+	IsFavour      bool       `gorm:"column:is_favourite"`
+	Note          string     `gorm:"column:note"`
+	NoteUpdatedAt *time.Time `gorm:"column:note_updated_at"`
 }
 
 type ChartWithFavour struct {
 	Chart
-	IsFavour bool `gorm:"column:is_favourite"`
+	IsFavour      bool       `gorm:"column:is_favourite"`
+	Note          string     `gorm:"column:note"`
+	NoteUpdatedAt *time.Time `gorm:"column:note_updated_at"`
 }
 
 type InsightWithFavour struct {
 	Insight
-	IsFavour bool `gorm:"column:is_favourite"`
+	IsFavour      bool       `gorm:"column:is_favourite"`
+	Note          string     `gorm:"column:note"`
+	NoteUpdatedAt *time.Time `gorm:"column:note_updated_at"`
 }
 
 type Chart struct {
-	gorm.Model
+	AssetModel
 	Title       string         `gorm:"column:title"`
 	XTitle      string         `gorm:"column:x_title"`
 	YTitle      string         `gorm:"column:y_title"`
@@ -54,20 +95,26 @@ type Chart struct {
 
 type FavouriteInsight struct {
 	gorm.Model
-	InsightID uint `gorm:"column:insight_id"`
-	UserID    uint `gorm:"column:user_id"`
+	InsightID     uint       `gorm:"column:insight_id;uniqueIndex:idx_favourite_insight,priority:2"`
+	UserID        uint       `gorm:"column:user_id;uniqueIndex:idx_favourite_insight,priority:1"`
+	Note          string     `gorm:"column:note;type:text"`
+	NoteUpdatedAt *time.Time `gorm:"column:note_updated_at"`
 }
 
 type FavouriteChart struct {
 	gorm.Model
-	ChartID uint `gorm:"column:chart_id"`
-	UserID  uint `gorm:"column:user_id"`
+	ChartID       uint       `gorm:"column:chart_id;uniqueIndex:idx_favourite_chart,priority:2"`
+	UserID        uint       `gorm:"column:user_id;uniqueIndex:idx_favourite_chart,priority:1"`
+	Note          string     `gorm:"column:note;type:text"`
+	NoteUpdatedAt *time.Time `gorm:"column:note_updated_at"`
 }
 
 type FavouriteAudience struct {
 	gorm.Model
-	AudienceID uint `gorm:"column:audience_id"`
-	UserID     uint `gorm:"column:user_id"`
+	AudienceID    uint       `gorm:"column:audience_id;uniqueIndex:idx_favourite_audience,priority:2"`
+	UserID        uint       `gorm:"column:user_id;uniqueIndex:idx_favourite_audience,priority:1"`
+	Note          string     `gorm:"column:note;type:text"`
+	NoteUpdatedAt *time.Time `gorm:"column:note_updated_at"`
 }
 
 type User struct {
@@ -76,3 +123,171 @@ type User struct {
 	Password string `gorm:"column:password;type:varchar(200)"`
 	IsAdmin  bool   `gorm:"is_admin"`
 }
+
+type Organization struct {
+	gorm.Model
+	Name string `gorm:"column:name;type:varchar(200)"`
+}
+
+type OrganizationMember struct {
+	gorm.Model
+	OrganizationID uint `gorm:"column:organization_id;uniqueIndex:idx_organization_member"`
+	UserID         uint `gorm:"column:user_id;uniqueIndex:idx_organization_member"`
+	IsAdmin        bool `gorm:"column:is_admin"`
+}
+
+type UserGroup struct {
+	gorm.Model
+	OrganizationID uint   `gorm:"column:organization_id;index"`
+	Name           string `gorm:"column:name;type:varchar(200)"`
+}
+
+type UserGroupMember struct {
+	gorm.Model
+	UserGroupID uint `gorm:"column:user_group_id;uniqueIndex:idx_user_group_member"`
+	UserID      uint `gorm:"column:user_id;uniqueIndex:idx_user_group_member"`
+}
+
+type AssetGrant struct {
+	gorm.Model
+	AssetType   string `gorm:"column:asset_type;type:varchar(20);index:idx_asset_grant"`
+	AssetID     uint   `gorm:"column:asset_id;index:idx_asset_grant"`
+	UserID      uint   `gorm:"column:user_id"`
+	UserGroupID uint   `gorm:"column:user_group_id"`
+}
+
+type Collection struct {
+	gorm.Model
+	UserID uint   `gorm:"column:user_id;index"`
+	Name   string `gorm:"column:name;type:varchar(200)"`
+}
+
+type Tag struct {
+	gorm.Model
+	OrganizationID uint   `gorm:"column:organization_id;uniqueIndex:idx_tag_name"`
+	Name           string `gorm:"column:name;type:varchar(100);uniqueIndex:idx_tag_name"`
+}
+
+type AssetTag struct {
+	gorm.Model
+	TagID     uint   `gorm:"column:tag_id;uniqueIndex:idx_asset_tag"`
+	AssetType string `gorm:"column:asset_type;type:varchar(20);uniqueIndex:idx_asset_tag"`
+	AssetID   uint   `gorm:"column:asset_id;uniqueIndex:idx_asset_tag"`
+}
+
+// AssetTranslation keeps the texts of an asset in another locale, the texts that are not translated are empty.
+type AssetTranslation struct {
+	gorm.Model
+	AssetType   string `gorm:"column:asset_type;type:varchar(20);uniqueIndex:idx_asset_translation"`
+	AssetID     uint   `gorm:"column:asset_id;uniqueIndex:idx_asset_translation"`
+	Locale      string `gorm:"column:locale;type:varchar(8);uniqueIndex:idx_asset_translation"`
+	Text        string `gorm:"column:text;type:varchar(200)"`
+	Description string `gorm:"column:description;type:varchar(200)"`
+	Title       string `gorm:"column:title"`
+	XTitle      string `gorm:"column:x_title"`
+	YTitle      string `gorm:"column:y_title"`
+}
+
+// AssetSimilarity is how similar the related asset is to the asset, all of them are replaced on every refresh.
+type AssetSimilarity struct {
+	gorm.Model
+	AssetType   string  `gorm:"column:asset_type;type:varchar(20);index:idx_asset_similarity"`
+	AssetID     uint    `gorm:"column:asset_id;index:idx_asset_similarity"`
+	RelatedType string  `gorm:"column:related_type;type:varchar(20);index:idx_related_similarity"`
+	RelatedID   uint    `gorm:"column:related_id;index:idx_related_similarity"`
+	Score       float64 `gorm:"column:score"`
+}
+
+// AssetView is a view of an asset by a user, the views older than the retention are removed.
+type AssetView struct {
+	gorm.Model
+	UserID    uint      `gorm:"column:user_id;index:idx_user_view"`
+	AssetType string    `gorm:"column:asset_type;type:varchar(20);index:idx_asset_view"`
+	AssetID   uint      `gorm:"column:asset_id;index:idx_asset_view"`
+	ViewedAt  time.Time `gorm:"column:viewed_at;index:idx_user_view;index"`
+}
+
+// Comment is a comment of a user on an asset, the removed comments keep their replies without their text.
+type Comment struct {
+	gorm.Model
+	AssetType string     `gorm:"column:asset_type;type:varchar(20);index:idx_asset_comment"`
+	AssetID   uint       `gorm:"column:asset_id;index:idx_asset_comment"`
+	ParentID  uint       `gorm:"column:parent_id;index"`
+	AuthorID  uint       `gorm:"column:author_id"`
+	Text      string     `gorm:"column:text;type:text"`
+	EditedAt  *time.Time `gorm:"column:edited_at"`
+	Removed   bool       `gorm:"column:removed"`
+	Hidden    bool       `gorm:"column:hidden"`
+}
+
+// CommentMention is a user that a comment mentions.
+type CommentMention struct {
+	gorm.Model
+	CommentID uint `gorm:"column:comment_id;uniqueIndex:idx_comment_mention"`
+	UserID    uint `gorm:"column:user_id;uniqueIndex:idx_comment_mention;index"`
+}
+
+// AssetRating is the rating of a user on an asset, the removed ratings are deleted.
+type AssetRating struct {
+	gorm.Model
+	UserID    uint   `gorm:"column:user_id;uniqueIndex:idx_asset_rating,priority:1"`
+	AssetType string `gorm:"column:asset_type;type:varchar(20);uniqueIndex:idx_asset_rating,priority:2"`
+	AssetID   uint   `gorm:"column:asset_id;uniqueIndex:idx_asset_rating,priority:3"`
+	Rating    uint   `gorm:"column:rating"`
+}
+
+// AssetFlag is a flag of a user on an asset, it is open while it has no resolved time.
+type AssetFlag struct {
+	gorm.Model
+	UserID     uint       `gorm:"column:user_id"`
+	AssetType  string     `gorm:"column:asset_type;type:varchar(20);index:idx_asset_flag"`
+	AssetID    uint       `gorm:"column:asset_id;index:idx_asset_flag"`
+	Reason     string     `gorm:"column:reason;type:varchar(20)"`
+	Comment    string     `gorm:"column:comment;type:text"`
+	ResolvedAt *time.Time `gorm:"column:resolved_at;index"`
+	ResolvedBy uint       `gorm:"column:resolved_by"`
+}
+
+// Webhook receives the events of the assets of an organization. The filters are comma separated lists,
+// an empty filter receives everything.
+type Webhook struct {
+	gorm.Model
+	OrganizationID uint   `gorm:"column:organization_id;index"`
+	URL            string `gorm:"column:url;type:varchar(500)"`
+	Events         string `gorm:"column:events;type:varchar(200)"`
+	AssetTypes     string `gorm:"column:asset_types;type:varchar(100)"`
+	Secret         string `gorm:"column:secret;type:varchar(200)"`
+	Active         bool   `gorm:"column:active"`
+}
+
+// WebhookDelivery is a delivery of an event to a webhook with the outcome of its last attempt.
+type WebhookDelivery struct {
+	gorm.Model
+	WebhookID      uint       `gorm:"column:webhook_id;index"`
+	Event          string     `gorm:"column:event;type:varchar(40)"`
+	Payload        string     `gorm:"column:payload;type:mediumtext"`
+	Status         string     `gorm:"column:status;type:varchar(20);index:idx_due_delivery"`
+	Attempts       int        `gorm:"column:attempts"`
+	NextAttemptAt  *time.Time `gorm:"column:next_attempt_at;index:idx_due_delivery"`
+	LastStatusCode int        `gorm:"column:last_status_code"`
+	LastError      string     `gorm:"column:last_error;type:varchar(500)"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at"`
+	RedeliveryOf   uint       `gorm:"column:redelivery_of"`
+}
+
+type AssetLink struct {
+	gorm.Model
+	Relation string `gorm:"column:relation;type:varchar(20);uniqueIndex:idx_asset_link"`
+	FromType string `gorm:"column:from_type;type:varchar(20);uniqueIndex:idx_asset_link"`
+	FromID   uint   `gorm:"column:from_id;uniqueIndex:idx_asset_link"`
+	ToType   string `gorm:"column:to_type;type:varchar(20);uniqueIndex:idx_asset_link;index:idx_asset_link_to"`
+	ToID     uint   `gorm:"column:to_id;uniqueIndex:idx_asset_link;index:idx_asset_link_to"`
+}
+
+type CollectionItem struct {
+	gorm.Model
+	CollectionID uint   `gorm:"column:collection_id;uniqueIndex:idx_collection_item"`
+	AssetType    string `gorm:"column:asset_type;type:varchar(20);uniqueIndex:idx_collection_item"`
+	AssetID      uint   `gorm:"column:asset_id;uniqueIndex:idx_collection_item"`
+	Position     int    `gorm:"column:position"`
+}
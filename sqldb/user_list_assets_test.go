@@ -23,7 +23,7 @@ func TestFavourAudience(t *testing.T) {
 	assert.NotNil(t, user)
 	assert.Equal(t, uint(1), user.ID)
 
-	asset, err := db.AddAsset(ctx, domain.InputAsset{
+	asset, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
 		Data: &domain.Audience{
 			AgeMax:            30,
 			AgeMin:            20,
@@ -35,17 +35,21 @@ func TestFavourAudience(t *testing.T) {
 		}})
 	assert.NoError(t, err)
 	assert.NotNil(t, asset)
-	fid, err := db.FavouriteAsset(ctx, user.ID, asset.ID, domain.AudienceAssetType, true)
+	changed, err := db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.AudienceAssetType, true)
 	assert.NoError(t, err)
-	assert.Equal(t, uint(1), fid)
+	assert.True(t, changed)
 
-	fid, err = db.FavouriteAsset(ctx, user.ID, asset.ID, domain.AudienceAssetType, true)
-	assert.Error(t, err)
-	assert.Equal(t, uint(0), fid)
+	changed, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.AudienceAssetType, true)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.AudienceAssetType, false)
+	assert.NoError(t, err)
+	assert.True(t, changed)
 
-	fid, err = db.FavouriteAsset(ctx, user.ID, asset.ID, domain.AudienceAssetType, false)
+	changed, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.AudienceAssetType, false)
 	assert.NoError(t, err)
-	assert.Equal(t, uint(0), fid)
+	assert.False(t, changed)
 }
 
 func TestListFavouriteAudiences(t *testing.T) {
@@ -68,10 +72,9 @@ func TestListFavouriteAudiences(t *testing.T) {
 	user2, err := db.AddUser(ctx, du2)
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
-	counter := 1
 	for i := 1; i <= 100; i++ {
 		desc := fmt.Sprintf("example %d", i)
-		asset, err := db.AddAsset(ctx, domain.InputAsset{
+		asset, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
 			Data: &domain.Audience{
 				AgeMax:            30,
 				AgeMin:            20,
@@ -87,14 +90,12 @@ func TestListFavouriteAudiences(t *testing.T) {
 		assert.Equal(t, desc, asset.Data.(*domain.Audience).Description)
 
 		if i%2 == 0 {
-			fid, err := db.FavouriteAsset(ctx, user.ID, asset.ID, domain.AudienceAssetType, true)
+			changed, err := db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.AudienceAssetType, true)
 			assert.NoError(t, err)
-			assert.Equal(t, uint(counter), fid)
-			counter += 1
-			fid, err = db.FavouriteAsset(ctx, user2.ID, asset.ID, domain.AudienceAssetType, true)
+			assert.True(t, changed)
+			changed, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user2.ID, asset.ID, domain.AudienceAssetType, true)
 			assert.NoError(t, err)
-			assert.Equal(t, uint(counter), fid)
-			counter += 1
+			assert.True(t, changed)
 		}
 	}
 	qa := domain.QueryAssets{
@@ -103,7 +104,7 @@ func TestListFavouriteAudiences(t *testing.T) {
 		Type:   domain.AudienceAssetType,
 		IsDesc: false,
 	}
-	la, err := db.ListFavouriteAssets(ctx, user.ID, true, qa)
+	la, err := db.ListFavouriteAssets(ctx, domain.AssetScope{}, user.ID, true, qa)
 	assert.NoError(t, err)
 	assert.NotNil(t, la)
 	assert.Equal(t, 10, len(la.Assets))
@@ -117,7 +118,7 @@ func TestListFavouriteAudiences(t *testing.T) {
 		Type:   domain.AudienceAssetType,
 		IsDesc: true,
 	}
-	la, err = db.ListFavouriteAssets(ctx, user.ID, true, qa)
+	la, err = db.ListFavouriteAssets(ctx, domain.AssetScope{}, user.ID, true, qa)
 	assert.NoError(t, err)
 	assert.NotNil(t, la)
 	assert.Equal(t, 10, len(la.Assets))
@@ -133,7 +134,7 @@ func TestListFavouriteAudiences(t *testing.T) {
 		IsDesc: false,
 	}
 
-	la, err = db.ListFavouriteAssets(ctx, user.ID, false, qa)
+	la, err = db.ListFavouriteAssets(ctx, domain.AssetScope{}, user.ID, false, qa)
 	fmt.Println(la)
 	assert.NoError(t, err)
 	assert.NotNil(t, la)
@@ -164,10 +165,9 @@ func TestListFavouriteInsights(t *testing.T) {
 	user2, err := db.AddUser(ctx, du2)
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
-	counter := 1
 	for i := 1; i <= 100; i++ {
 		desc := fmt.Sprintf("example %d", i)
-		asset, err := db.AddAsset(ctx, domain.InputAsset{
+		asset, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
 			Data: &domain.Insight{
 				Text:        "40% of millenials spend more than 3hours on social media daily",
 				Description: desc,
@@ -178,14 +178,12 @@ func TestListFavouriteInsights(t *testing.T) {
 		assert.Equal(t, desc, asset.Data.(*domain.Insight).Description)
 
 		if i%2 == 0 {
-			fid, err := db.FavouriteAsset(ctx, user.ID, asset.ID, domain.InsightAssetType, true)
+			changed, err := db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.InsightAssetType, true)
 			assert.NoError(t, err)
-			assert.Equal(t, uint(counter), fid)
-			counter += 1
-			fid, err = db.FavouriteAsset(ctx, user2.ID, asset.ID, domain.InsightAssetType, true)
+			assert.True(t, changed)
+			changed, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user2.ID, asset.ID, domain.InsightAssetType, true)
 			assert.NoError(t, err)
-			assert.Equal(t, uint(counter), fid)
-			counter += 1
+			assert.True(t, changed)
 		}
 	}
 	qa := domain.QueryAssets{
@@ -194,7 +192,7 @@ func TestListFavouriteInsights(t *testing.T) {
 		Type:   domain.InsightAssetType,
 		IsDesc: false,
 	}
-	la, err := db.ListFavouriteAssets(ctx, user.ID, true, qa)
+	la, err := db.ListFavouriteAssets(ctx, domain.AssetScope{}, user.ID, true, qa)
 	assert.NoError(t, err)
 	assert.NotNil(t, la)
 	assert.Equal(t, 10, len(la.Assets))
@@ -208,7 +206,7 @@ func TestListFavouriteInsights(t *testing.T) {
 		Type:   domain.InsightAssetType,
 		IsDesc: true,
 	}
-	la, err = db.ListFavouriteAssets(ctx, user.ID, true, qa)
+	la, err = db.ListFavouriteAssets(ctx, domain.AssetScope{}, user.ID, true, qa)
 	assert.NoError(t, err)
 	assert.NotNil(t, la)
 	assert.Equal(t, 10, len(la.Assets))
@@ -223,7 +221,7 @@ func TestListFavouriteInsights(t *testing.T) {
 		IsDesc: false,
 	}
 
-	la, err = db.ListFavouriteAssets(ctx, user.ID, false, qa)
+	la, err = db.ListFavouriteAssets(ctx, domain.AssetScope{}, user.ID, false, qa)
 	fmt.Println(la)
 	assert.NoError(t, err)
 	assert.NotNil(t, la)
@@ -254,10 +252,9 @@ func TestListFavouriteCharts(t *testing.T) {
 	user2, err := db.AddUser(ctx, du2)
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
-	counter := 1
 	for i := 1; i <= 100; i++ {
 		desc := fmt.Sprintf("example %d", i)
-		asset, err := db.AddAsset(ctx, domain.InputAsset{
+		asset, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
 			Data: &domain.Chart{
 				Description: desc,
 				Title:       "Relationship between tax and GDP",
@@ -274,14 +271,12 @@ func TestListFavouriteCharts(t *testing.T) {
 		assert.Equal(t, desc, asset.Data.(*domain.Chart).Description)
 
 		if i%2 == 0 {
-			fid, err := db.FavouriteAsset(ctx, user.ID, asset.ID, domain.ChartAssetType, true)
+			changed, err := db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.ChartAssetType, true)
 			assert.NoError(t, err)
-			assert.Equal(t, uint(counter), fid)
-			counter += 1
-			fid, err = db.FavouriteAsset(ctx, user2.ID, asset.ID, domain.ChartAssetType, true)
+			assert.True(t, changed)
+			changed, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user2.ID, asset.ID, domain.ChartAssetType, true)
 			assert.NoError(t, err)
-			assert.Equal(t, uint(counter), fid)
-			counter += 1
+			assert.True(t, changed)
 		}
 	}
 	qa := domain.QueryAssets{
@@ -290,7 +285,7 @@ func TestListFavouriteCharts(t *testing.T) {
 		Type:   domain.ChartAssetType,
 		IsDesc: false,
 	}
-	la, err := db.ListFavouriteAssets(ctx, user.ID, true, qa)
+	la, err := db.ListFavouriteAssets(ctx, domain.AssetScope{}, user.ID, true, qa)
 	assert.NoError(t, err)
 	assert.NotNil(t, la)
 	assert.Equal(t, 10, len(la.Assets))
@@ -304,7 +299,7 @@ func TestListFavouriteCharts(t *testing.T) {
 		Type:   domain.ChartAssetType,
 		IsDesc: true,
 	}
-	la, err = db.ListFavouriteAssets(ctx, user.ID, true, qa)
+	la, err = db.ListFavouriteAssets(ctx, domain.AssetScope{}, user.ID, true, qa)
 	assert.NoError(t, err)
 	assert.NotNil(t, la)
 	assert.Equal(t, 10, len(la.Assets))
@@ -319,7 +314,7 @@ func TestListFavouriteCharts(t *testing.T) {
 		IsDesc: false,
 	}
 
-	la, err = db.ListFavouriteAssets(ctx, user.ID, false, qa)
+	la, err = db.ListFavouriteAssets(ctx, domain.AssetScope{}, user.ID, false, qa)
 	fmt.Println(la)
 	assert.NoError(t, err)
 	assert.NotNil(t, la)
@@ -329,3 +324,62 @@ func TestListFavouriteCharts(t *testing.T) {
 	assert.Equal(t, uint(1), la.FirstID)
 	assert.Equal(t, uint(10), la.LastID)
 }
+
+func TestFavourAssetsBatch(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	user, err := db.AddUser(ctx, domain.User{Username: "manos", Password: "hashed"})
+	assert.NoError(t, err)
+	insight, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+		Data: &domain.Insight{Text: "40% of millenials", Description: "bla bla"}})
+	assert.NoError(t, err)
+	chart, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+		Data: &domain.Chart{Title: "title", XTitle: "x", YTitle: "y", Description: "bla bla",
+			Data: domain.XYData{X: []float64{1, 2}, Y: []float64{1, 2}}}})
+	assert.NoError(t, err)
+
+	items := []domain.FavouriteItem{
+		{AssetType: domain.InsightAssetType, AssetID: insight.ID},
+		{AssetType: domain.ChartAssetType, AssetID: chart.ID},
+		{AssetType: domain.AudienceAssetType, AssetID: 10},
+	}
+	results, err := db.FavouriteAssets(ctx, domain.AssetScope{}, user.ID, items, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(results))
+	assert.True(t, results[0].OK)
+	assert.True(t, results[1].OK)
+	assert.True(t, results[0].Changed)
+	assert.False(t, results[2].OK)
+
+	// favouring again succeeds without changing anything
+	results, err = db.FavouriteAssets(ctx, domain.AssetScope{}, user.ID, items[:2], true)
+	assert.NoError(t, err)
+	assert.True(t, results[0].OK)
+	assert.False(t, results[0].Changed)
+
+	la, err := db.ListFavouriteAssets(ctx, domain.AssetScope{}, user.ID, true, domain.QueryAssets{Limit: 10, Type: domain.ChartAssetType})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(la.Assets))
+
+	results, err = db.FavouriteAssets(ctx, domain.AssetScope{}, user.ID, items[:2], false)
+	assert.NoError(t, err)
+	assert.True(t, results[1].OK)
+	la, err = db.ListFavouriteAssets(ctx, domain.AssetScope{}, user.ID, true, domain.QueryAssets{Limit: 10, Type: domain.ChartAssetType})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(la.Assets))
+}
+
+func TestFavourMissingAsset(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	user, err := db.AddUser(ctx, domain.User{Username: "manos", Password: "hashed"})
+	assert.NoError(t, err)
+
+	changed, err := db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, 10, domain.InsightAssetType, true)
+	assert.ErrorIs(t, err, domain.ErrAssetNotFound)
+	assert.False(t, changed)
+	_, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, 10, domain.InsightAssetType, false)
+	assert.ErrorIs(t, err, domain.ErrAssetNotFound)
+}
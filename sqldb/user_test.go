@@ -58,24 +58,28 @@ func TestFavourInsight(t *testing.T) {
 	assert.NotNil(t, user)
 	assert.Equal(t, uint(1), user.ID)
 
-	asset, err := db.AddAsset(ctx, domain.InputAsset{
+	asset, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
 		Data: &domain.Insight{
 			Text:        "40% of millenials spend more than 3hours on social media daily",
 			Description: "example",
 		}})
 	assert.NoError(t, err)
 	assert.NotNil(t, asset)
-	fid, err := db.FavouriteAsset(ctx, user.ID, asset.ID, domain.InsightAssetType, true)
+	changed, err := db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.InsightAssetType, true)
 	assert.NoError(t, err)
-	assert.Equal(t, uint(1), fid)
+	assert.True(t, changed)
 
-	fid, err = db.FavouriteAsset(ctx, user.ID, asset.ID, domain.InsightAssetType, true)
-	assert.Error(t, err)
-	assert.Equal(t, uint(0), fid)
+	changed, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.InsightAssetType, true)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.InsightAssetType, false)
+	assert.NoError(t, err)
+	assert.True(t, changed)
 
-	fid, err = db.FavouriteAsset(ctx, user.ID, asset.ID, domain.InsightAssetType, false)
+	changed, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.InsightAssetType, false)
 	assert.NoError(t, err)
-	assert.Equal(t, uint(0), fid)
+	assert.False(t, changed)
 
 }
 
@@ -93,7 +97,7 @@ func TestFavourChart(t *testing.T) {
 	assert.NotNil(t, user)
 	assert.Equal(t, uint(1), user.ID)
 
-	asset, err := db.AddAsset(ctx, domain.InputAsset{
+	asset, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
 		Data: &domain.Chart{
 			Description: "bla bla",
 			Title:       "Relationship between tax and GDP",
@@ -106,15 +110,19 @@ func TestFavourChart(t *testing.T) {
 		}})
 	assert.NoError(t, err)
 	assert.NotNil(t, asset)
-	fid, err := db.FavouriteAsset(ctx, user.ID, asset.ID, domain.ChartAssetType, true)
+	changed, err := db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.ChartAssetType, true)
 	assert.NoError(t, err)
-	assert.Equal(t, uint(1), fid)
+	assert.True(t, changed)
 
-	fid, err = db.FavouriteAsset(ctx, user.ID, asset.ID, domain.ChartAssetType, true)
-	assert.Error(t, err)
-	assert.Equal(t, uint(0), fid)
+	changed, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.ChartAssetType, true)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.ChartAssetType, false)
+	assert.NoError(t, err)
+	assert.True(t, changed)
 
-	fid, err = db.FavouriteAsset(ctx, user.ID, asset.ID, domain.ChartAssetType, false)
+	changed, err = db.FavouriteAsset(ctx, domain.AssetScope{}, user.ID, asset.ID, domain.ChartAssetType, false)
 	assert.NoError(t, err)
-	assert.Equal(t, uint(0), fid)
+	assert.False(t, changed)
 }
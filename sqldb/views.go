@@ -0,0 +1,95 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"platform-go-challenge/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// viewBatchSize is the number of views that are inserted at once.
+const viewBatchSize = 500
+
+// AddAssetViews stores the views and increases the view counts of their assets in a transaction.
+func (d *DB) AddAssetViews(ctx context.Context, views []domain.AssetView) error {
+	rows := []AssetView{}
+	counts := map[domain.FavouriteItem]int{}
+	for _, v := range views {
+		rows = append(rows, AssetView{UserID: v.UserID, AssetType: string(v.AssetType), AssetID: v.AssetID, ViewedAt: v.ViewedAt})
+		counts[domain.FavouriteItem{AssetType: v.AssetType, AssetID: v.AssetID}]++
+	}
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.CreateInBatches(rows, viewBatchSize).Error
+		if err != nil {
+			return err
+		}
+		for k, n := range counts {
+			model, err := assetModel(k.AssetType)
+			if err != nil {
+				return fmt.Errorf("AddAssetViews: %w", err)
+			}
+			err = tx.Model(model).Where("id = ?", k.AssetID).UpdateColumn("view_count", gorm.Expr("view_count + ?", n)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListRecentViews returns the assets that the user viewed from the last one, each with the time of its last view.
+func (d *DB) ListRecentViews(ctx context.Context, userID uint, limit int) ([]domain.RecentAsset, error) {
+	rows := []struct {
+		AssetType string
+		AssetID   uint
+		ViewedAt  time.Time
+	}{}
+	err := d.db.Model(&AssetView{}).Select("asset_type, asset_id, MAX(viewed_at) AS viewed_at").
+		Where("user_id = ?", userID).Group("asset_type, asset_id").
+		Order("viewed_at desc, asset_type, asset_id").Limit(limit).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	recent := []domain.RecentAsset{}
+	for _, v := range rows {
+		recent = append(recent, domain.RecentAsset{AssetType: domain.AssetType(v.AssetType), AssetID: v.AssetID, ViewedAt: v.ViewedAt})
+	}
+	return recent, nil
+}
+
+// GetAssetViewStats counts all the views of the asset, and the views and viewers of the views that are kept.
+func (d *DB) GetAssetViewStats(ctx context.Context, at domain.AssetType, assetID uint) (*domain.AssetViewStats, error) {
+	model, err := assetModel(at)
+	if err != nil {
+		return nil, fmt.Errorf("GetAssetViewStats: %w", err)
+	}
+	stats := &domain.AssetViewStats{AssetType: at, AssetID: assetID}
+	err = d.db.Model(model).Where("id = ?", assetID).Select("view_count").Scan(&stats.Views).Error
+	if err != nil {
+		return nil, err
+	}
+	recent := struct {
+		RecentViews int64
+		Viewers     int64
+	}{}
+	err = d.db.Model(&AssetView{}).Select("COUNT(*) AS recent_views, COUNT(DISTINCT user_id) AS viewers").
+		Where("asset_type = ? AND asset_id = ?", string(at), assetID).Scan(&recent).Error
+	if err != nil {
+		return nil, err
+	}
+	stats.RecentViews = recent.RecentViews
+	stats.Viewers = recent.Viewers
+	return stats, nil
+}
+
+// PurgeAssetViews removes the views before the time and returns their number.
+func (d *DB) PurgeAssetViews(ctx context.Context, before time.Time) (int64, error) {
+	res := d.db.Unscoped().Where("viewed_at < ?", before).Delete(&AssetView{})
+	return res.RowsAffected, res.Error
+}
+
+func (d *DB) RemoveAssetViews(ctx context.Context, at domain.AssetType, assetID uint) error {
+	return d.db.Unscoped().Where("asset_type = ? AND asset_id = ?", string(at), assetID).Delete(&AssetView{}).Error
+}
@@ -0,0 +1,60 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetViews(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	insight, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+		Data: &domain.Insight{Text: "40% of millenials", Description: "bla bla"}})
+	assert.NoError(t, err)
+	chart, err := db.AddAsset(ctx, domain.AssetScope{}, domain.InputAsset{
+		Data: &domain.Chart{Title: "title", XTitle: "x", YTitle: "y", Description: "bla bla",
+			Data: domain.XYData{X: []float64{1, 2}, Y: []float64{1, 2}}}})
+	assert.NoError(t, err)
+
+	now := time.Now().Truncate(time.Second)
+	err = db.AddAssetViews(ctx, []domain.AssetView{
+		{UserID: 1, AssetType: domain.InsightAssetType, AssetID: insight.ID, ViewedAt: now.Add(-48 * time.Hour)},
+		{UserID: 1, AssetType: domain.ChartAssetType, AssetID: chart.ID, ViewedAt: now.Add(-time.Hour)},
+		{UserID: 1, AssetType: domain.InsightAssetType, AssetID: insight.ID, ViewedAt: now},
+		{UserID: 2, AssetType: domain.InsightAssetType, AssetID: insight.ID, ViewedAt: now},
+	})
+	assert.NoError(t, err)
+
+	recent, err := db.ListRecentViews(ctx, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(recent))
+	assert.Equal(t, insight.ID, recent[0].AssetID)
+	assert.Equal(t, domain.InsightAssetType, recent[0].AssetType)
+	assert.Equal(t, chart.ID, recent[1].AssetID)
+
+	stats, err := db.GetAssetViewStats(ctx, domain.InsightAssetType, insight.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), stats.Views)
+	assert.Equal(t, int64(3), stats.RecentViews)
+	assert.Equal(t, int64(2), stats.Viewers)
+
+	// the purged views do not change the view count
+	n, err := db.PurgeAssetViews(ctx, now.Add(-24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	stats, err = db.GetAssetViewStats(ctx, domain.InsightAssetType, insight.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), stats.Views)
+	assert.Equal(t, int64(2), stats.RecentViews)
+
+	err = db.RemoveAssetViews(ctx, domain.InsightAssetType, insight.ID)
+	assert.NoError(t, err)
+	recent, err = db.ListRecentViews(ctx, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(recent))
+}
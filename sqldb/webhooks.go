@@ -0,0 +1,226 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"platform-go-challenge/domain"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// webhookDeliveryBatchSize is the number of deliveries that are inserted at once.
+const webhookDeliveryBatchSize = 500
+
+func splitList(list string) []string {
+	if list == "" {
+		return []string{}
+	}
+	return strings.Split(list, ",")
+}
+
+func (w *Webhook) FromDomain(hook domain.Webhook) {
+	events := []string{}
+	for _, v := range hook.Events {
+		events = append(events, string(v))
+	}
+	types := []string{}
+	for _, v := range hook.AssetTypes {
+		types = append(types, string(v))
+	}
+	w.OrganizationID = hook.OrgID
+	w.URL = hook.URL
+	w.Events = strings.Join(events, ",")
+	w.AssetTypes = strings.Join(types, ",")
+	w.Secret = hook.Secret
+	w.Active = hook.Active
+}
+
+func (w *Webhook) ToDomain() domain.Webhook {
+	hook := domain.Webhook{
+		ID:         w.ID,
+		OrgID:      w.OrganizationID,
+		URL:        w.URL,
+		Events:     []domain.WebhookEventType{},
+		AssetTypes: []domain.AssetType{},
+		Active:     w.Active,
+		Secret:     w.Secret,
+		CreatedAt:  w.CreatedAt,
+	}
+	for _, v := range splitList(w.Events) {
+		hook.Events = append(hook.Events, domain.WebhookEventType(v))
+	}
+	for _, v := range splitList(w.AssetTypes) {
+		hook.AssetTypes = append(hook.AssetTypes, domain.AssetType(v))
+	}
+	return hook
+}
+
+func (w *WebhookDelivery) FromDomain(delivery domain.WebhookDelivery) {
+	w.ID = delivery.ID
+	w.WebhookID = delivery.WebhookID
+	w.Event = string(delivery.Event)
+	w.Payload = delivery.Payload
+	w.Status = string(delivery.Status)
+	w.Attempts = delivery.Attempts
+	w.NextAttemptAt = delivery.NextAttemptAt
+	w.LastStatusCode = delivery.LastStatusCode
+	w.LastError = delivery.LastError
+	w.DeliveredAt = delivery.DeliveredAt
+	w.RedeliveryOf = delivery.RedeliveryOf
+}
+
+func (w *WebhookDelivery) ToDomain() domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		ID:             w.ID,
+		WebhookID:      w.WebhookID,
+		Event:          domain.WebhookEventType(w.Event),
+		Payload:        w.Payload,
+		Status:         domain.DeliveryStatus(w.Status),
+		Attempts:       w.Attempts,
+		NextAttemptAt:  w.NextAttemptAt,
+		LastStatusCode: w.LastStatusCode,
+		LastError:      w.LastError,
+		DeliveredAt:    w.DeliveredAt,
+		RedeliveryOf:   w.RedeliveryOf,
+		CreatedAt:      w.CreatedAt,
+	}
+}
+
+func (d *DB) AddWebhook(ctx context.Context, hook domain.Webhook) (*domain.Webhook, error) {
+	row := Webhook{}
+	row.FromDomain(hook)
+	err := d.db.Create(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	newHook := row.ToDomain()
+	return &newHook, nil
+}
+
+func (d *DB) GetWebhook(ctx context.Context, webhookID uint) (*domain.Webhook, error) {
+	row := Webhook{}
+	err := d.db.Where("id = ?", webhookID).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %v", domain.ErrWebhookNotFound, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	hook := row.ToDomain()
+	return &hook, nil
+}
+
+func (d *DB) UpdateWebhook(ctx context.Context, hook domain.Webhook) (*domain.Webhook, error) {
+	row := Webhook{}
+	err := d.db.Where("id = ?", hook.ID).Take(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	row.FromDomain(hook)
+	err = d.db.Save(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	newHook := row.ToDomain()
+	return &newHook, nil
+}
+
+// RemoveWebhook removes the webhook with its deliveries.
+func (d *DB) RemoveWebhook(ctx context.Context, webhookID uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("webhook_id = ?", webhookID).Delete(&WebhookDelivery{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id = ?", webhookID).Delete(&Webhook{}).Error
+	})
+}
+
+// ListWebhooks returns the webhooks of the organization with their secrets.
+func (d *DB) ListWebhooks(ctx context.Context, orgID uint) ([]domain.Webhook, error) {
+	rows := []Webhook{}
+	err := d.db.Where("organization_id = ?", orgID).Order("id").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	hooks := []domain.Webhook{}
+	for i := range rows {
+		hooks = append(hooks, rows[i].ToDomain())
+	}
+	return hooks, nil
+}
+
+// AddWebhookDeliveries stores the deliveries and returns them with their IDs.
+func (d *DB) AddWebhookDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) ([]domain.WebhookDelivery, error) {
+	rows := make([]WebhookDelivery, len(deliveries))
+	for i, v := range deliveries {
+		rows[i].FromDomain(v)
+	}
+	err := d.db.CreateInBatches(rows, webhookDeliveryBatchSize).Error
+	if err != nil {
+		return nil, err
+	}
+	added := []domain.WebhookDelivery{}
+	for i := range rows {
+		added = append(added, rows[i].ToDomain())
+	}
+	return added, nil
+}
+
+func (d *DB) GetWebhookDelivery(ctx context.Context, webhookID, deliveryID uint) (*domain.WebhookDelivery, error) {
+	row := WebhookDelivery{}
+	err := d.db.Where("id = ? AND webhook_id = ?", deliveryID, webhookID).Take(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	delivery := row.ToDomain()
+	return &delivery, nil
+}
+
+// UpdateWebhookDelivery stores the outcome of the last attempt of the delivery.
+func (d *DB) UpdateWebhookDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	return d.db.Model(&WebhookDelivery{}).Where("id = ?", delivery.ID).Updates(map[string]interface{}{
+		"status":           string(delivery.Status),
+		"attempts":         delivery.Attempts,
+		"next_attempt_at":  delivery.NextAttemptAt,
+		"last_status_code": delivery.LastStatusCode,
+		"last_error":       delivery.LastError,
+		"delivered_at":     delivery.DeliveredAt,
+	}).Error
+}
+
+// ListDueDeliveries returns the pending deliveries with a next attempt before the time, from the oldest attempt.
+func (d *DB) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	rows := []WebhookDelivery{}
+	err := d.db.Where("status = ? AND next_attempt_at <= ?", string(domain.PendingDelivery), now).
+		Order("next_attempt_at, id").Limit(limit).Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	deliveries := []domain.WebhookDelivery{}
+	for i := range rows {
+		deliveries = append(deliveries, rows[i].ToDomain())
+	}
+	return deliveries, nil
+}
+
+// ListWebhookDeliveries lists the deliveries of the webhook after the last ID.
+func (d *DB) ListWebhookDeliveries(ctx context.Context, webhookID uint, query domain.QueryDeliveries) (*domain.ListedDeliveries, error) {
+	rows := []WebhookDelivery{}
+	err := d.db.Where("webhook_id = ? AND id > ?", webhookID, query.LastID).Order("id").Limit(query.Limit).Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	ls := &domain.ListedDeliveries{Limit: query.Limit, Deliveries: []domain.WebhookDelivery{}}
+	for i := range rows {
+		ls.Deliveries = append(ls.Deliveries, rows[i].ToDomain())
+	}
+	if len(rows) > 0 {
+		ls.FirstID = rows[0].ID
+		ls.LastID = rows[len(rows)-1].ID
+	}
+	return ls, nil
+}
@@ -0,0 +1,83 @@
+package sqldb
+
+import (
+	"context"
+	"platform-go-challenge/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhooks(t *testing.T) {
+	db, teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+	ctx := context.Background()
+	hook, err := db.AddWebhook(ctx, domain.Webhook{OrgID: 1, URL: "http://localhost:9000", Active: true, Secret: "secret",
+		Events: []domain.WebhookEventType{domain.AssetCreatedEvent, domain.AssetDeletedEvent}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(hook.Events))
+	assert.Equal(t, 0, len(hook.AssetTypes))
+	_, err = db.AddWebhook(ctx, domain.Webhook{OrgID: 2, URL: "http://localhost:9001", Secret: "secret"})
+	assert.NoError(t, err)
+
+	hook.AssetTypes = []domain.AssetType{domain.ChartAssetType}
+	hook.Active = false
+	updated, err := db.UpdateWebhook(ctx, *hook)
+	assert.NoError(t, err)
+	assert.False(t, updated.Active)
+	hooks, err := db.ListWebhooks(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(hooks))
+	assert.Equal(t, []domain.AssetType{domain.ChartAssetType}, hooks[0].AssetTypes)
+	assert.Equal(t, "secret", hooks[0].Secret)
+
+	now := time.Now().Truncate(time.Second)
+	later := now.Add(time.Minute)
+	added, err := db.AddWebhookDeliveries(ctx, []domain.WebhookDelivery{
+		{WebhookID: hook.ID, Event: domain.AssetCreatedEvent, Payload: `{"event":"asset.created"}`, Status: domain.PendingDelivery, NextAttemptAt: &now},
+		{WebhookID: hook.ID, Event: domain.AssetDeletedEvent, Payload: `{"event":"asset.deleted"}`, Status: domain.PendingDelivery, NextAttemptAt: &later},
+	})
+	assert.NoError(t, err)
+	assert.NotZero(t, added[0].ID)
+
+	due, err := db.ListDueDeliveries(ctx, now, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(due))
+	assert.Equal(t, added[0].ID, due[0].ID)
+
+	due[0].Status = domain.DeliveredDelivery
+	due[0].Attempts = 1
+	due[0].LastStatusCode = 204
+	due[0].NextAttemptAt = nil
+	due[0].DeliveredAt = &now
+	err = db.UpdateWebhookDelivery(ctx, due[0])
+	assert.NoError(t, err)
+	delivery, err := db.GetWebhookDelivery(ctx, hook.ID, added[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeliveredDelivery, delivery.Status)
+	assert.Nil(t, delivery.NextAttemptAt)
+	_, err = db.GetWebhookDelivery(ctx, hook.ID+1, added[0].ID)
+	assert.Error(t, err)
+
+	due, err = db.ListDueDeliveries(ctx, later, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(due))
+	assert.Equal(t, added[1].ID, due[0].ID)
+
+	ls, err := db.ListWebhookDeliveries(ctx, hook.ID, domain.QueryDeliveries{Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(ls.Deliveries))
+	ls, err = db.ListWebhookDeliveries(ctx, hook.ID, domain.QueryDeliveries{Limit: 10, LastID: ls.LastID})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(ls.Deliveries))
+	assert.Equal(t, added[1].ID, ls.Deliveries[0].ID)
+
+	err = db.RemoveWebhook(ctx, hook.ID)
+	assert.NoError(t, err)
+	_, err = db.GetWebhook(ctx, hook.ID)
+	assert.Error(t, err)
+	ls, err = db.ListWebhookDeliveries(ctx, hook.ID, domain.QueryDeliveries{Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(ls.Deliveries))
+}